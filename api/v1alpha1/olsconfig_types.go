@@ -124,6 +124,174 @@ type OLSConfigStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	DiagnosticInfo []PodDiagnostic `json:"diagnosticInfo,omitempty"`
+
+	// DiagnosticsRef points to the ConfigMap holding the log tails and Events captured
+	// when a component failed. The ConfigMap keeps a bounded number of the most recent snapshots.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	DiagnosticsRef *DiagnosticsReference `json:"diagnosticsRef,omitempty"`
+
+	// Providers reports the connectivity preflight result of each LLM provider.
+	// Only populated when spec.llm.preflight.enabled is true.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Providers []ProviderStatus `json:"providers,omitempty"`
+
+	// RAGBuilds reports the state of the BYOK RAG index builds of spec.ols.ragBuilds.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RAGBuilds []RAGBuildStatus `json:"ragBuilds,omitempty"`
+
+	// RAG reports the state of each BYOK RAG source of spec.ols.rag, in the same order.
+	// +optional
+	// +listType=map
+	// +listMapKey=index
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RAG []RAGSourceStatus `json:"rag,omitempty"`
+
+	// RAGUpdate reports the rollout of new BYOK RAG image digests under spec.ols.ragUpdatePolicy.
+	// Only set when the policy is Manual or Window, or uses a canary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RAGUpdate *RAGUpdateStatus `json:"ragUpdate,omitempty"`
+}
+
+// RAGUpdateStatus is the rollout state of the BYOK RAG image digests
+type RAGUpdateStatus struct {
+	// Phase of the rollout
+	Phase RAGUpdatePhase `json:"phase"`
+	// CurrentRevision identifies the RAG image digests run by the app server
+	// +optional
+	CurrentRevision string `json:"currentRevision,omitempty"`
+	// PendingRevision identifies the newer RAG image digests waiting to roll out. With the Manual
+	// policy, setting the ols.openshift.io/approve-rag-update annotation to it approves the rollout.
+	// +optional
+	PendingRevision string `json:"pendingRevision,omitempty"`
+	// NextWindowTime is the start of the next update window of the Window policy
+	// +optional
+	NextWindowTime *metav1.Time `json:"nextWindowTime,omitempty"`
+	// Message explains a pending or failed rollout
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RAGUpdatePhase is the phase of the rollout of new BYOK RAG image digests
+// +kubebuilder:validation:Enum=UpToDate;Pending;Canary;CanaryFailed
+type RAGUpdatePhase string
+
+const (
+	RAGUpdateUpToDate     RAGUpdatePhase = "UpToDate"
+	RAGUpdatePending      RAGUpdatePhase = "Pending"
+	RAGUpdateCanary       RAGUpdatePhase = "Canary"
+	RAGUpdateCanaryFailed RAGUpdatePhase = "CanaryFailed"
+)
+
+// RAGSourceStatus is the state of one BYOK RAG source
+type RAGSourceStatus struct {
+	// Index of the source in spec.ols.rag
+	Index int32 `json:"index"`
+	// Origin of the source, the image or OCI artifact reference, pvc/<claimName> or configmap/<name>
+	Origin string `json:"origin"`
+	// ImageDigest is the digest the ImageStream of an image source resolved the image to,
+	// or the digest an OCI artifact reference is pinned to
+	// +optional
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// LastImportTime is the time the ImageStream of an image source last imported a new digest
+	// +optional
+	LastImportTime *metav1.Time `json:"lastImportTime,omitempty"`
+	// IndexID is the index ID found in the index by the init container of the newest app server pod
+	// +optional
+	IndexID string `json:"indexID,omitempty"`
+	// IndexFound tells whether the init container of the newest app server pod found files at indexPath.
+	// Unset until an app server pod ran the init container.
+	// +optional
+	IndexFound *bool `json:"indexFound,omitempty"`
+	// Message explains a failed check of the source
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RAGBuildStatus is the state of one BYOK RAG index build
+type RAGBuildStatus struct {
+	// Name of the build in spec.ols.ragBuilds
+	Name string `json:"name"`
+	// Phase of the most recent build Job
+	Phase RAGBuildPhase `json:"phase"`
+	// JobName is the name of the most recent build Job, empty until one is created
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+	// PersistentVolumeClaim holding the index
+	PersistentVolumeClaim string `json:"persistentVolumeClaim"`
+	// LastSucceededTime is the completion time of the most recent successful build
+	// +optional
+	LastSucceededTime *metav1.Time `json:"lastSucceededTime,omitempty"`
+	// Retries is the number of times the build of the current spec was retried after a failure
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+	// NextRetryTime is when a failed build of the current spec is retried
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+	// Message explains a failed build
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RAGBuildPhase is the phase of a BYOK RAG index build Job
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+type RAGBuildPhase string
+
+const (
+	RAGBuildPending   RAGBuildPhase = "Pending"
+	RAGBuildRunning   RAGBuildPhase = "Running"
+	RAGBuildSucceeded RAGBuildPhase = "Succeeded"
+	RAGBuildFailed    RAGBuildPhase = "Failed"
+)
+
+// ProviderStatus is the outcome of the connectivity preflight of one LLM provider
+type ProviderStatus struct {
+	// Name of the provider in spec.llm.providers
+	Name string `json:"name"`
+	// Reachable is true when the provider endpoint answered over HTTP(S), whatever the status code
+	Reachable bool `json:"reachable"`
+	// AuthResult tells whether the provider accepted the configured credentials.
+	// NotChecked is reported for provider types whose authentication the preflight cannot exercise.
+	AuthResult ProviderAuthResult `json:"authResult"`
+	// LatencyMilliseconds is the round trip time of the preflight request
+	// +optional
+	LatencyMilliseconds int64 `json:"latencyMilliseconds,omitempty"`
+	// LastError is the error of the preflight request, empty when it succeeded
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// LastChecked is the time the preflight request was made
+	LastChecked metav1.Time `json:"lastChecked"`
+}
+
+// ProviderAuthResult is the authentication outcome of a provider preflight
+// +kubebuilder:validation:Enum=Succeeded;Failed;NotChecked
+type ProviderAuthResult string
+
+const (
+	ProviderAuthSucceeded  ProviderAuthResult = "Succeeded"
+	ProviderAuthFailed     ProviderAuthResult = "Failed"
+	ProviderAuthNotChecked ProviderAuthResult = "NotChecked"
+)
+
+// DiagnosticsReference locates the failure snapshots captured by the operator
+type DiagnosticsReference struct {
+	// Name of the ConfigMap
+	Name string `json:"name"`
+	// Namespace of the ConfigMap
+	Namespace string `json:"namespace"`
+	// LatestKey is the ConfigMap data key of the most recent snapshot
+	// +optional
+	LatestKey string `json:"latestKey,omitempty"`
+	// LastCaptured is the time the most recent snapshot was taken
+	// +optional
+	LastCaptured metav1.Time `json:"lastCaptured,omitempty"`
 }
 
 // PodDiagnostic describes a pod-level issue
@@ -216,18 +384,61 @@ type LLMSpec struct {
 	// +required
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Providers"
 	Providers []ProviderSpec `json:"providers"`
+	// Connectivity preflight of the providers. When enabled, the operator makes a minimal
+	// authenticated request to every provider and reports the outcome in status.providers.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Provider Preflight",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Preflight *ProviderPreflightSpec `json:"preflight,omitempty"`
+	// Days ahead of the expiry of credentials in a referenced Secret when the operator raises the
+	// CredentialsExpiring condition. The expiry is read from the ols.openshift.io/credentials-expiry
+	// annotation (RFC 3339) of the Secret, or else from the credentials where possible.
+	// +kubebuilder:default=14
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=365
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credentials Expiry Warning (days)",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	CredentialsExpiryWarningDays int32 `json:"credentialsExpiryWarningDays,omitempty"`
+}
+
+// ProviderPreflightSpec configures the connectivity preflight of the LLM providers.
+// The preflight lists the provider models (GET <url>/models for OpenAI compatible providers)
+// through spec.ols.proxyConfig, trusting spec.ols.additionalCAConfigMapRef and honouring the
+// provider tlsSecurityProfile, so that a wrong URL or API key shows up before a user asks a question.
+type ProviderPreflightSpec struct {
+	// Run the preflight
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// Timeout of a preflight request in seconds
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=60
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout Seconds"
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// Minutes a successful result is reused before the provider is probed again on the next reconciliation.
+	// A provider is probed again right away when its configuration, credentials or trusted CAs change.
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1440
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Recheck Interval Minutes"
+	RecheckIntervalMinutes int32 `json:"recheckIntervalMinutes,omitempty"`
 }
 
 // OLSSpec defines the desired state of OLS deployment.
 //
-// OKP (Offline Knowledge Portal) / Solr hybrid RAG is operator-managed, not configured on this CR:
+// OKP (Offline Knowledge Portal) / Solr hybrid RAG is operator-managed:
 //   - Enabled by default. The operator deploys the RHOKP sidecar and writes ols_config.solr_hybrid
-//     into olsconfig.yaml (Solr URL, hybrid tuning, and related keys use operator defaults).
+//     into olsconfig.yaml. The hybrid retrieval tuning uses operator defaults unless overridden in spec.okp.
+//   - Set spec.okp.external to use an external or shared knowledge portal instead of deploying RHOKP.
 //   - The app-server pod receives OCP_CLUSTER_VERSION for Solr chunk_filter_query resolution.
+//     spec.okp.documentationScopes adds scopes, e.g. another OCP version, that a query can select by name.
 //   - OCP documentation is retrieved via the search_openshift_documentation tool (Solr hybrid), not
 //     direct prompt RAG. BYOK content remains on spec.rag (FAISS indexes).
 //   - Set byokRAGOnly to disable OKP: no RHOKP sidecar, no solr_hybrid section, and no built-in
 //     OCP documentation retrieval—only BYOK FAISS indexes from spec.rag are used.
+//
+// +kubebuilder:validation:XValidation:message="the PersistentVolumeClaims of spec.ols.ragBuilds are ReadWriteOnce volumes, a rag entry mounting one allows at most 1 api replica",rule="!has(self.rag) || !has(self.deployment) || !has(self.deployment.api) || !has(self.deployment.api.replicas) || self.deployment.api.replicas <= 1 || !self.rag.exists(r, has(r.persistentVolumeClaim) && r.persistentVolumeClaim.claimName.startsWith('lightspeed-rag-build-'))"
 type OLSSpec struct {
 	// Conversation cache settings
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2,displayName="Conversation Cache"
@@ -249,6 +460,12 @@ type OLSSpec struct {
 	// +required
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Default Provider",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	DefaultProvider string `json:"defaultProvider"`
+	// Routing of queries across the configured providers: failover, weighted splitting and
+	// model selection by request attributes. Without it every query uses the default provider and model
+	// unless the client selects one.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Routing"
+	Routing *RoutingSpec `json:"routing,omitempty"`
 	// Query filters
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query Filters"
 	QueryFilters []QueryFiltersSpec `json:"queryFilters,omitempty"`
@@ -283,13 +500,30 @@ type OLSSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Proxy Settings",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
 	// +kubebuilder:validation:Optional
 	ProxyConfig *ProxyConfig `json:"proxyConfig,omitempty"`
-	// BYOK RAG databases (bring-your-own container images with FAISS vector indexes).
+	// BYOK RAG databases (bring-your-own FAISS vector indexes from container images, PVCs, OCI artifacts or ConfigMaps).
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="BYOK RAG Databases",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
 	RAG []RAGSpec `json:"rag,omitempty"`
+	// BYOK RAG index builds. The operator embeds Markdown, HTML and PDF documents into a FAISS index
+	// on a PersistentVolumeClaim, which a spec.ols.rag entry can then use as its source.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="BYOK RAG Index Builds",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	RAGBuilds []RAGBuildSpec `json:"ragBuilds,omitempty"`
+	// Rollout of the new digests of the BYOK RAG container images imported by their ImageStreams.
+	// Defaults to rolling out every new digest immediately.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="BYOK RAG Update Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	RAGUpdatePolicy *RAGUpdatePolicy `json:"ragUpdatePolicy,omitempty"`
 	// LLM Token Quota Configuration
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="LLM Token Quota Configuration"
 	QuotaHandlersConfig *QuotaHandlersConfig `json:"quotaHandlersConfig,omitempty"`
+	// Alerting rules added to the application server PrometheusRule
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Alerting Rules",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Alerting *AlertingSpec `json:"alerting,omitempty"`
 	// Persistent Storage Configuration
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Persistent Storage Configuration",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
@@ -298,6 +532,10 @@ type OLSSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Only use BYOK RAG sources",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	ByokRAGOnly bool `json:"byokRAGOnly,omitempty"`
+	// Solr hybrid retrieval tuning of the OKP knowledge source. Unset fields use the operator defaults.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OKP Retrieval Tuning",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	OKP *OKPSpec `json:"okp,omitempty"`
 	// Custom system prompt for LLM queries. If not specified, uses the default OpenShift Lightspeed prompt.
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query System Prompt",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
@@ -342,9 +580,13 @@ type MCPKubeServerConfiguration struct {
 	Timeout int `json:"timeout,omitempty"`
 }
 
-// RAGSpec defines a BYOK RAG database (container image and index path).
+// RAGSpec defines a BYOK RAG database: its source (a container image, a PVC, an OCI artifact or a ConfigMap)
+// and the index path.
+// +kubebuilder:validation:XValidation:message="exactly one of image, persistentVolumeClaim, ociArtifact or configMap must be set",rule="[has(self.image), has(self.persistentVolumeClaim), has(self.ociArtifact), has(self.configMap)].filter(x, x).size() == 1"
+// +kubebuilder:validation:XValidation:message="embeddingModel.path is not supported for a configMap source",rule="!has(self.configMap) || !has(self.embeddingModel) || !has(self.embeddingModel.path)"
 type RAGSpec struct {
-	// The path to the BYOK RAG database inside of the container image
+	// The path to the BYOK RAG database inside of the container image, the PVC or the OCI artifact.
+	// Ignored for a configMap source.
 	// +kubebuilder:default="/rag/vector_db"
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Index Path in the Image"
 	IndexPath string `json:"indexPath,omitempty"`
@@ -352,13 +594,255 @@ type RAGSpec struct {
 	// +kubebuilder:default=""
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Index ID"
 	IndexID string `json:"indexID,omitempty"`
-	// The URL of the container image to use as a BYOK RAG source
+	// The URL of the container image to use as a BYOK RAG source. The index is copied from the image
+	// by an init container on every app server pod start.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image"
+	Image string `json:"image,omitempty"`
+	// Existing PersistentVolumeClaim holding the index, mounted read-only without copy.
+	// Use a ReadOnlyMany or ReadWriteMany claim when the app server runs several replicas.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PersistentVolumeClaim"
+	PersistentVolumeClaim *RAGPersistentVolumeClaimSource `json:"persistentVolumeClaim,omitempty"`
+	// OCI artifact holding the index, mounted read-only as an image volume without copy. On clusters
+	// without image volume support (before OpenShift 4.20) the source is rejected and ApiReady is False.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OCI Artifact"
+	OCIArtifact *RAGOCIArtifactSource `json:"ociArtifact,omitempty"`
+	// ConfigMap holding a small index, one file per key, mounted read-only without copy
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ConfigMap"
+	ConfigMap *corev1.LocalObjectReference `json:"configMap,omitempty"`
+	// Embedding model the index was built with, used to embed the queries against it.
+	// Defaults to the embedding model of the app server image.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Embedding Model"
+	EmbeddingModel *EmbeddingModelSpec `json:"embeddingModel,omitempty"`
+	// Weight of the chunks of this index when merged with the other knowledge sources, relative to
+	// the weights of the other indexes and of the OKP documentation. Unweighted sources count as 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Weight"
+	Weight int32 `json:"weight,omitempty"`
+	// Maximum number of chunks of this index in the merged results. Not limited by default.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Chunks"
+	MaxChunks int32 `json:"maxChunks,omitempty"`
+	// Topics, such as the names of your own operators, for which the chunks of this index are ranked
+	// before the OKP documentation when the query mentions them
+	// +kubebuilder:validation:MaxItems=50
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	// +listType=set
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Preferred Topics"
+	PreferredTopics []string `json:"preferredTopics,omitempty"`
+}
+
+// RAGPersistentVolumeClaimSource defines a PersistentVolumeClaim holding a BYOK RAG database
+type RAGPersistentVolumeClaimSource struct {
+	// Name of the PersistentVolumeClaim in the operator namespace
 	// +kubebuilder:validation:Required
 	// +required
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image"
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Claim Name"
+	ClaimName string `json:"claimName"`
+}
+
+// RAGOCIArtifactSource defines an OCI artifact holding a BYOK RAG database
+type RAGOCIArtifactSource struct {
+	// Reference of the OCI artifact, e.g. quay.io/example/rag-index:1.0
+	// +kubebuilder:validation:Required
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Reference"
+	Reference string `json:"reference"`
+	// Pull policy of the OCI artifact. Defaults to Always for the latest tag, IfNotPresent otherwise.
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pull Policy"
+	PullPolicy corev1.PullPolicy `json:"pullPolicy,omitempty"`
+}
+
+// RAGBuildSpec defines a BYOK RAG index built by the operator. A Job running the app server image embeds
+// the documents with the embedding model of the app server and writes the FAISS index, with the index ID <name>,
+// to the directory /vector_db of the PersistentVolumeClaim lightspeed-rag-build-<name>.
+type RAGBuildSpec struct {
+	// Name of the build, also the index ID of the index and the suffix of its Job, CronJob and PersistentVolumeClaim
+	// +kubebuilder:validation:Required
+	// +required
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name"`
+	// Documents to index
+	// +kubebuilder:validation:Required
+	// +required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Source"
+	Source RAGBuildSource `json:"source"`
+	// Cron schedule of the rebuilds, e.g. "0 3 * * 0". Without a schedule the index is only built
+	// when the build is added or its spec changes.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rebuild Schedule"
+	Schedule string `json:"schedule,omitempty"`
+	// Size and storage class of the PersistentVolumeClaim holding the index. Defaults to 1Gi in the
+	// default storage class. The claim is created once and kept when the build is removed.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Index Storage"
+	Storage *Storage `json:"storage,omitempty"`
+}
+
+// RAGUpdatePolicy defines when the app server rolls out a new digest of the BYOK RAG container images
+// +kubebuilder:validation:XValidation:message="window is required when type is Window",rule="!has(self.type) || self.type != 'Window' || has(self.window)"
+type RAGUpdatePolicy struct {
+	// Immediate rolls out a new digest as soon as the ImageStream imports it. Manual waits for the
+	// ols.openshift.io/approve-rag-update annotation of the OLSConfig to be set to status.ragUpdate.pendingRevision.
+	// Window waits for the next update window. Default: Immediate
+	// +kubebuilder:default=Immediate
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Type"
+	Type RAGUpdatePolicyType `json:"type,omitempty"`
+	// Update window of the Window type
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Update Window"
+	Window *RAGUpdateWindow `json:"window,omitempty"`
+	// Roll a new digest out to a single canary replica first. The app server is only updated once the
+	// canary pod is ready; a canary that does not become ready leaves the app server on the current digest.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Canary",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Canary bool `json:"canary,omitempty"`
+}
+
+// RAGUpdatePolicyType is the rollout policy of new BYOK RAG image digests
+// +kubebuilder:validation:Enum=Immediate;Manual;Window
+type RAGUpdatePolicyType string
+
+const (
+	RAGUpdatePolicyImmediate RAGUpdatePolicyType = "Immediate"
+	RAGUpdatePolicyManual    RAGUpdatePolicyType = "Manual"
+	RAGUpdatePolicyWindow    RAGUpdatePolicyType = "Window"
+)
+
+// RAGUpdateWindow defines the recurring windows in which new BYOK RAG image digests roll out
+type RAGUpdateWindow struct {
+	// Cron schedule of the start of the windows in UTC, e.g. "0 2 * * 6". The five fields accept
+	// numbers, *, ranges, lists and steps.
+	// +kubebuilder:validation:Required
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule"
+	Schedule string `json:"schedule"`
+	// Length of the windows in minutes, 60 by default
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10080
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Duration Minutes"
+	DurationMinutes int32 `json:"durationMinutes,omitempty"`
+}
+
+// RAGBuildSource defines where the documents of a BYOK RAG index build are read from
+// +kubebuilder:validation:XValidation:message="exactly one of configMap, persistentVolumeClaim or git must be set",rule="[has(self.configMap), has(self.persistentVolumeClaim), has(self.git)].filter(x, x).size() == 1"
+type RAGBuildSource struct {
+	// ConfigMap in the operator namespace holding the documents, one per key
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ConfigMap"
+	ConfigMap *corev1.LocalObjectReference `json:"configMap,omitempty"`
+	// PersistentVolumeClaim in the operator namespace holding the documents
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Persistent Volume Claim"
+	PersistentVolumeClaim *RAGBuildPersistentVolumeClaimSource `json:"persistentVolumeClaim,omitempty"`
+	// Git repository holding the documents, typically an in-cluster mirror
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Git Repository"
+	Git *RAGBuildGitSource `json:"git,omitempty"`
+}
+
+// RAGBuildPersistentVolumeClaimSource defines a PersistentVolumeClaim holding the documents of a BYOK RAG index build
+type RAGBuildPersistentVolumeClaimSource struct {
+	// Name of the PersistentVolumeClaim in the operator namespace
+	// +kubebuilder:validation:Required
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Claim Name"
+	ClaimName string `json:"claimName"`
+	// Directory of the documents, relative to the root of the volume. Defaults to the root.
+	// +kubebuilder:validation:Pattern=`^[^/]`
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Path"
+	Path string `json:"path,omitempty"`
+}
+
+// RAGBuildGitSource defines a git repository holding the documents of a BYOK RAG index build
+type RAGBuildGitSource struct {
+	// URL of the repository, e.g. https://git-mirror.example.svc/docs/product-docs.git
+	// +kubebuilder:validation:Required
+	// +required
+	// +kubebuilder:validation:Pattern=`^(https?|git)://.+$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="URL"
+	URL string `json:"url"`
+	// Branch or tag to clone
+	// +kubebuilder:default=main
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ref"
+	Ref string `json:"ref,omitempty"`
+	// Directory of the documents, relative to the root of the repository. Defaults to the root.
+	// +kubebuilder:validation:Pattern=`^[^/]`
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Path"
+	Path string `json:"path,omitempty"`
+	// Image providing the git command, used to clone the repository
+	// +kubebuilder:validation:Required
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Git Image"
 	Image string `json:"image"`
 }
 
+// EmbeddingModelSpec defines the embedding model used for vector retrieval: either a local model
+// directory or a remote OpenAI compatible embeddings endpoint.
+// +kubebuilder:validation:XValidation:message="exactly one of path or endpoint must be set",rule="has(self.path) != has(self.endpoint)"
+type EmbeddingModelSpec struct {
+	// Path of a local embedding model directory: inside the image, the PVC or the OCI artifact of a
+	// RAG source, inside the app server image for tool filtering
+	// +kubebuilder:validation:Pattern=`^/.*$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Model Path"
+	Path string `json:"path,omitempty"`
+	// Remote OpenAI compatible embeddings endpoint
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoint"
+	Endpoint *EmbeddingEndpointSpec `json:"endpoint,omitempty"`
+	// Dimension of the embedding vectors produced by the model. When set for a RAG source,
+	// the index must have been built with the same dimension or the app server does not start.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65536
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Dimension"
+	Dimension int32 `json:"dimension,omitempty"`
+}
+
+// EmbeddingEndpointSpec defines a remote OpenAI compatible embeddings endpoint
+type EmbeddingEndpointSpec struct {
+	// Base URL of the embeddings API, e.g. https://embeddings.example.com/v1
+	// +kubebuilder:validation:Required
+	// +required
+	// +kubebuilder:validation:Pattern=`^https?://.*$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="URL"
+	URL string `json:"url"`
+	// Name of the embedding model served by the endpoint
+	// +kubebuilder:validation:Required
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Model"
+	Model string `json:"model"`
+	// Secret holding the API key of the endpoint under the apitoken key
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credential Secret"
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
 // QuotaHandlersConfig defines the token quota configuration
 type QuotaHandlersConfig struct {
 	// Token quota limiters
@@ -396,6 +880,38 @@ type LimiterConfig struct {
 	Period string `json:"period"`
 }
 
+// AlertingSpec defines the opt-in alerting rules for the OLS operands
+type AlertingSpec struct {
+	// Add alerting rules to the application server PrometheusRule. Default: false
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Alerting Rules",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// Base URL of the runbooks linked from the alerts' runbook_url annotation.
+	// The alert name followed by ".md" is appended to it.
+	// +kubebuilder:default="https://github.com/openshift/lightspeed-operator/blob/main/docs/runbooks/"
+	// +kubebuilder:validation:Pattern=`^https?://.*$`
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Runbook Base URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	RunbookBaseURL string `json:"runbookBaseURL,omitempty"`
+	// Percentage of /v1/streaming_query requests answered with 5xx above which OLSStreamingQueryErrorsHigh fires. Default: 5
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Streaming Query Error Percentage",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	StreamingQueryErrorPercent int `json:"streamingQueryErrorPercent,omitempty"`
+	// Percentage of a cluster limiter's initial quota consumed within its period above which OLSTokenQuotaNearlyExhausted fires. Default: 90
+	// +kubebuilder:default=90
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Token Quota Usage Percentage",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	TokenQuotaUsagePercent int `json:"tokenQuotaUsagePercent,omitempty"`
+	// How long a condition must hold before the alert fires, as a Prometheus duration. Default: "10m"
+	// +kubebuilder:default="10m"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(s|m|h))+$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Alert Pending Duration",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	For string `json:"for,omitempty"`
+}
+
 // DeploymentConfig defines the schema for overriding deployment of OLS instance.
 type DeploymentConfig struct {
 	// API container settings.
@@ -409,7 +925,7 @@ type DeploymentConfig struct {
 	MCPServerContainer Config `json:"mcpServer,omitempty"`
 	// RHOKP standalone deployment settings (Solr / OKP).
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="RHOKP Container"
-	RHOKPContainer Config `json:"rhokp,omitempty"`
+	RHOKPContainer RHOKPSpec `json:"rhokp,omitempty"`
 	// Console container settings.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Console Deployment"
 	ConsoleContainer Config `json:"console,omitempty"`
@@ -440,10 +956,45 @@ type AlertsAdapterSpec struct {
 	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
 }
 
+// RHOKPSpec defines the deployment settings of the standalone RHOKP operand
+// +kubebuilder:validation:XValidation:rule="!has(self.storage) || !has(self.replicas) || self.replicas <= 1",message="storage is a ReadWriteOnce volume, it allows at most 1 replica"
+type RHOKPSpec struct {
+	Config `json:",inline"`
+	// Persistent volume for the Solr data directory, so that a restarted pod reuses the index
+	// instead of rebuilding it. Without it, the data directory is an emptyDir. The size defaults to 75Gi
+	// and the class to the default storage class of the cluster.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Solr Data Storage"
+	Storage *Storage `json:"storage,omitempty"`
+	// Keep a new RHOKP pod out of the Service until the operator has run a warm-up query
+	// returning documentation chunks, through the ols.openshift.io/rhokp-warmed-up readiness gate
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Warm-up Readiness Gate"
+	WarmUpGate bool `json:"warmUpGate,omitempty"`
+	// Operator-managed rotation of the rhokp-access-key Secret. When set, the operator generates the
+	// access key, rotates it periodically and hands it to the app server and the agentic operator.
+	// A rotation restarts RHOKP and the app server together and RHOKP only accepts the current key, so
+	// retrieval from the portal fails until both have rolled out; with storage, the RHOKP pod is recreated
+	// and the outage lasts until its Solr core is loaded. Without a rotation, the Secret is optional and managed by the user.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Access Key Rotation"
+	AccessKeyRotation *RHOKPAccessKeyRotation `json:"accessKeyRotation,omitempty"`
+}
+
+// RHOKPAccessKeyRotation defines the rotation of the RHOKP access key
+type RHOKPAccessKeyRotation struct {
+	// Days between two rotations of the access key
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=365
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Interval Days"
+	IntervalDays int32 `json:"intervalDays,omitempty"`
+}
+
 // Config defines pod configuration using standard Kubernetes types
 type Config struct {
 	// Defines the number of desired pods. Default: "1"
-	// Note: Replicas are configurable for APIContainer and MCP server (mcpServer).
+	// Note: Replicas are configurable for APIContainer, MCP server (mcpServer) and RHOKP (rhokp).
 	// For PostgreSQL, Console, Agentic Console, Alerts Adapter, OTEL Collector, and
 	// Agentic Sandbox (spec.agenticOLS.agenticSandboxConfig), the number of replicas is always set to 1.
 	// +kubebuilder:default=1
@@ -502,6 +1053,142 @@ type PostgresSpec struct {
 	MaxConnections int `json:"maxConnections,omitempty"`
 }
 
+// RoutingTarget references a model of a configured provider.
+type RoutingTarget struct {
+	// Name of a provider in spec.llm.providers
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Provider"
+	Provider string `json:"provider"`
+	// Name of a model of the provider
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Model"
+	Model string `json:"model"`
+}
+
+// FailoverTrigger is a class of provider errors that moves a query to the next target.
+// +kubebuilder:validation:Enum=ServerError;Timeout;QuotaExceeded
+type FailoverTrigger string
+
+const (
+	// FailoverOnServerError fails over on HTTP 5xx responses of the provider
+	FailoverOnServerError FailoverTrigger = "ServerError"
+	// FailoverOnTimeout fails over when the provider does not answer in time
+	FailoverOnTimeout FailoverTrigger = "Timeout"
+	// FailoverOnQuotaExceeded fails over on throttling and quota errors (HTTP 429)
+	FailoverOnQuotaExceeded FailoverTrigger = "QuotaExceeded"
+)
+
+// FailoverSpec defines the targets tried when the selected provider fails.
+type FailoverSpec struct {
+	// Targets tried in order after the selected provider and model failed
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=atomic
+	// +required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Failover Chain"
+	Chain []RoutingTarget `json:"chain"`
+	// Errors that trigger a failover. Default: all of ServerError, Timeout and QuotaExceeded.
+	// +kubebuilder:validation:MaxItems=3
+	// +listType=set
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Failover On"
+	On []FailoverTrigger `json:"on,omitempty"`
+	// Seconds a failed target is skipped before it is tried again. Default: 60
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cooldown Seconds"
+	CooldownSeconds int32 `json:"cooldownSeconds,omitempty"`
+}
+
+// WeightedTarget is a target receiving a share of the queries.
+type WeightedTarget struct {
+	RoutingTarget `json:",inline"`
+	// Relative share of the queries sent to the target; 0 drains it
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Weight"
+	Weight int32 `json:"weight"`
+}
+
+// RoutingMatch selects queries by request attributes. All set attributes must match.
+// +kubebuilder:validation:XValidation:message="at least one match attribute must be set",rule="has(self.hasAttachments) || has(self.minQueryLength) || has(self.mediaTypes) || has(self.userGroups)"
+type RoutingMatch struct {
+	// Matches queries with (true) or without (false) attachments
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Has Attachments"
+	HasAttachments *bool `json:"hasAttachments,omitempty"`
+	// Matches queries of at least this many characters
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimum Query Length"
+	MinQueryLength *int32 `json:"minQueryLength,omitempty"`
+	// Matches queries requesting one of these response media types
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:items:Enum=text/plain;application/json
+	// +listType=set
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Media Types"
+	MediaTypes []string `json:"mediaTypes,omitempty"`
+	// Matches queries of users in one of these groups
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=set
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="User Groups"
+	UserGroups []string `json:"userGroups,omitempty"`
+}
+
+// RoutingRule sends the queries matching its attributes to a target.
+type RoutingRule struct {
+	// Rule name
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name"`
+	// Request attributes the rule matches
+	// +kubebuilder:validation:Required
+	// +required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Match"
+	Match RoutingMatch `json:"match"`
+	// Provider and model serving the matching queries
+	// +kubebuilder:validation:Required
+	// +required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target"
+	Target RoutingTarget `json:"target"`
+}
+
+// RoutingSpec defines how queries that do not select a provider and model are routed.
+// Rules are evaluated first, in order; queries matching no rule are split across
+// weightedTargets, or use the default provider and model when none are set.
+// The failover chain applies to every routed query.
+// +kubebuilder:validation:XValidation:message="at least one weightedTargets entry must have a positive weight",rule="!has(self.weightedTargets) || self.weightedTargets.exists(t, t.weight > 0)"
+type RoutingSpec struct {
+	// Failover chain for failed queries
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Failover"
+	Failover *FailoverSpec `json:"failover,omitempty"`
+	// Weighted split of queries matching no rule, for A/B testing.
+	// Include the default provider and model to keep a share of the queries on it.
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=atomic
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Weighted Targets"
+	WeightedTargets []WeightedTarget `json:"weightedTargets,omitempty"`
+	// Rules selecting the target by request attributes; the first matching rule wins
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rules"
+	Rules []RoutingRule `json:"rules,omitempty"`
+}
+
 // QueryFiltersSpec defines filters to manipulate questions/queries.
 type QueryFiltersSpec struct {
 	// Filter name.
@@ -525,6 +1212,76 @@ type VertexConfig struct {
 	Location string `json:"location,omitempty"`
 }
 
+// BedrockConfig defines the configuration for the AWS Bedrock provider.
+// +kubebuilder:validation:XValidation:message="modelARN must be in the region set in region",rule="!has(self.modelARN) || self.modelARN.matches('^arn:aws[a-z-]*:bedrock:(' + self.region + ')?:')"
+// +kubebuilder:validation:XValidation:message="crossRegionInference cannot be combined with an inference profile modelARN",rule="!has(self.crossRegionInference) || !self.crossRegionInference || !has(self.modelARN) || !self.modelARN.matches(':(application-)?inference-profile/')"
+type BedrockConfig struct {
+	// AWS region of the Bedrock runtime, for example us-east-1
+	// +kubebuilder:validation:Required
+	// +required
+	// +kubebuilder:validation:Pattern=`^[a-z]{2}(-gov|-iso[a-z]*)?-[a-z]+-[0-9]+$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="AWS Region"
+	Region string `json:"region"`
+	// ARN of the foundation model, inference profile or provisioned model to invoke.
+	// When not set, the model names listed for the provider are used as Bedrock model IDs.
+	// +kubebuilder:validation:Pattern=`^arn:aws[a-z-]*:bedrock:[a-z0-9-]*:[0-9]*:(foundation-model|inference-profile|application-inference-profile|provisioned-model|custom-model)/.+$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Model or Inference Profile ARN"
+	ModelARN string `json:"modelARN,omitempty"`
+	// Route requests through the geographic cross-region inference profile of the model,
+	// so Bedrock may serve them from any region of the geography.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cross-Region Inference"
+	CrossRegionInference bool `json:"crossRegionInference,omitempty"`
+	// Bedrock runtime endpoint overriding the regional default, for example a VPC interface endpoint
+	// +kubebuilder:validation:Pattern=`^https://.*$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoint URL"
+	EndpointURL string `json:"endpointURL,omitempty"`
+	// ARN of the IAM role to assume through STS before invoking Bedrock.
+	// Use either this field or the role_arn key of the credentials secret, not both.
+	// +kubebuilder:validation:Pattern=`^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="STS Role ARN"
+	RoleARN string `json:"roleARN,omitempty"`
+}
+
+// WorkloadIdentitySpec defines keyless provider authentication: the app server exchanges a
+// projected token of its ServiceAccount for short-lived cloud credentials.
+// +kubebuilder:validation:XValidation:message="clientID and tenantID must be set together",rule="has(self.clientID) == has(self.tenantID)"
+type WorkloadIdentitySpec struct {
+	// Audience of the projected ServiceAccount token. Defaults to "sts.amazonaws.com" for bedrock,
+	// "api://AzureADTokenExchange" for azure_openai and "//iam.googleapis.com/<gcpWorkloadIdentityProvider>"
+	// for google_vertex and google_vertex_anthropic.
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Token Audience"
+	Audience string `json:"audience,omitempty"`
+	// Client ID of the Entra ID application or managed identity federated with the app server ServiceAccount (azure_openai)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Azure Client ID"
+	ClientID string `json:"clientID,omitempty"`
+	// Entra ID tenant ID (azure_openai)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Azure Tenant ID"
+	TenantID string `json:"tenantID,omitempty"`
+	// Full resource name of the GCP workload identity pool provider (google_vertex and google_vertex_anthropic),
+	// for example projects/123456789/locations/global/workloadIdentityPools/my-pool/providers/my-provider
+	// +kubebuilder:validation:Pattern=`^projects/[0-9]+/locations/global/workloadIdentityPools/[^/]+/providers/[^/]+$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GCP Workload Identity Provider"
+	GCPWorkloadIdentityProvider string `json:"gcpWorkloadIdentityProvider,omitempty"`
+	// GCP service account to impersonate. When not set, the federated identity accesses Vertex AI directly.
+	// +kubebuilder:validation:Pattern=`^[^@]+@[^@]+\.iam\.gserviceaccount\.com$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GCP Service Account Email"
+	GCPServiceAccountEmail string `json:"gcpServiceAccountEmail,omitempty"`
+}
+
+// ProviderCredentialsSource defines provider credentials mounted by the Secrets Store CSI driver.
+// The objects of the SecretProviderClass must be mounted under the file names the provider type
+// expects in a credentials secret, e.g. apitoken (or credentialKey), or client_id, tenant_id and client_secret.
+type ProviderCredentialsSource struct {
+	// Name of the SecretProviderClass in the operator namespace
+	// +kubebuilder:validation:Required
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="SecretProviderClass"
+	SecretProviderClass string `json:"secretProviderClass"`
+}
+
 // ModelParametersSpec
 type ModelParametersSpec struct {
 	// Max tokens for response. The default is 2048 tokens.
@@ -539,6 +1296,7 @@ type ModelParametersSpec struct {
 }
 
 // ModelSpec defines the LLM model to use and its parameters.
+// +kubebuilder:validation:XValidation:message="parameters.maxTokensForResponse must not exceed capabilities.maxOutputTokens",rule="!has(self.capabilities) || !has(self.capabilities.maxOutputTokens) || !has(self.parameters) || !has(self.parameters.maxTokensForResponse) || self.parameters.maxTokensForResponse <= self.capabilities.maxOutputTokens"
 type ModelSpec struct {
 	// Model name
 	// +kubebuilder:validation:Required
@@ -556,6 +1314,165 @@ type ModelSpec struct {
 	// Model API parameters
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Parameters"
 	Parameters ModelParametersSpec `json:"parameters,omitempty"`
+	// Request timeout for the model in seconds, overriding the provider timeout
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout (seconds)"
+	Timeout int32 `json:"timeout,omitempty"`
+	// Retry policy for the model, overriding the provider retry policy
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retries"
+	Retries *RetryPolicySpec `json:"retries,omitempty"`
+	// Stable name of the model, e.g. "prod-default", usable instead of the model name in defaultModel and
+	// routing targets. Aliases must be unique across all providers.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9._]*[a-z0-9])?$`
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Alias"
+	Alias string `json:"alias,omitempty"`
+	// Marks the model as deprecated. The model keeps serving requests, and the service reports the deprecation to clients.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Deprecated",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Deprecated bool `json:"deprecated,omitempty"`
+	// Features supported by the model. When unset, the model is assumed to support tool calling.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Capabilities"
+	Capabilities *ModelCapabilitiesSpec `json:"capabilities,omitempty"`
+}
+
+// ModelCapabilitiesSpec declares the features supported by a model
+type ModelCapabilitiesSpec struct {
+	// The model supports tool calling, used by MCP servers and tools approval. The default is true.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tool Calling",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	ToolCalling *bool `json:"toolCalling,omitempty"`
+	// The model accepts image input
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Vision",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Vision bool `json:"vision,omitempty"`
+	// The model supports reasoning (thinking) before answering
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Reasoning",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Reasoning bool `json:"reasoning,omitempty"`
+	// Maximum number of tokens the model can produce in a response
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Output Tokens"
+	MaxOutputTokens int32 `json:"maxOutputTokens,omitempty"`
+}
+
+// ProviderHeaderSourceType defines the type of a provider header value source
+// +enum
+type ProviderHeaderSourceType string
+
+const (
+	// ProviderHeaderSourceTypeValue uses the literal value
+	ProviderHeaderSourceTypeValue ProviderHeaderSourceType = "value"
+	// ProviderHeaderSourceTypeSecret uses a value from a Kubernetes secret
+	ProviderHeaderSourceTypeSecret ProviderHeaderSourceType = "secret"
+)
+
+// ProviderHeaderValueSource defines where a provider header value comes from.
+// Like MCPHeaderValueSource, the Type field determines which of the other fields should be set.
+// Secrets must exist in the operator's namespace and hold the value under the "header" key.
+//
+// Examples:
+//
+//	# Use a literal value:
+//	valueFrom:
+//	  type: value
+//	  value: tenant-a
+//
+//	# Use a secret:
+//	valueFrom:
+//	  type: secret
+//	  secretRef:
+//	    name: my-gateway-key
+//
+// +kubebuilder:validation:XValidation:rule="self.type == 'value' ? has(self.value) && size(self.value) > 0 : !has(self.value)",message="value is required when type is 'value' and must not be set otherwise"
+// +kubebuilder:validation:XValidation:rule="self.type == 'secret' ? has(self.secretRef) && size(self.secretRef.name) > 0 : !has(self.secretRef)",message="secretRef with non-empty name is required when type is 'secret' and must not be set otherwise"
+type ProviderHeaderValueSource struct {
+	// Type specifies the source type for the header value
+	// +unionDiscriminator
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=value;secret
+	// +required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Source Type"
+	Type ProviderHeaderSourceType `json:"type"`
+
+	// Literal header value. Required when Type is "value".
+	// +unionMember
+	// +kubebuilder:validation:MaxLength=4096
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Value"
+	Value string `json:"value,omitempty"`
+
+	// Reference to a secret containing the header value under the "header" key.
+	// Required when Type is "secret".
+	// +unionMember
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Reference"
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// ProviderHeader defines an extra HTTP header sent with every request to the LLM provider
+type ProviderHeader struct {
+	// Name of the header (e.g., "X-Tenant-ID", "OpenAI-Organization")
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9-]+$`
+	// +required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Header Name"
+	Name string `json:"name"`
+
+	// Source of the header value
+	// +kubebuilder:validation:Required
+	// +required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Value Source"
+	ValueFrom ProviderHeaderValueSource `json:"valueFrom"`
+}
+
+// RetryPolicySpec configures retries of failed LLM provider requests.
+// The delay before a retry starts at backoffSeconds and doubles on every further retry, up to maxBackoffSeconds.
+// +kubebuilder:validation:XValidation:message="maxBackoffSeconds must not be lower than backoffSeconds",rule="!has(self.backoffSeconds) || !has(self.maxBackoffSeconds) || self.maxBackoffSeconds >= self.backoffSeconds"
+type RetryPolicySpec struct {
+	// Maximum number of retries of a failed request. 0 disables retries.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Retries"
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+	// Delay before the first retry in seconds
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=60
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backoff (seconds)"
+	BackoffSeconds int32 `json:"backoffSeconds,omitempty"`
+	// Upper bound of the delay between retries in seconds
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Backoff (seconds)"
+	MaxBackoffSeconds int32 `json:"maxBackoffSeconds,omitempty"`
+}
+
+// InferenceServiceReference selects the KServe InferenceServices serving the models of a provider.
+// +kubebuilder:validation:XValidation:message="exactly one of name or selector must be set",rule="has(self.name) != has(self.selector)"
+type InferenceServiceReference struct {
+	// Name of the InferenceService
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name,omitempty"`
+	// Namespace of the InferenceService. Defaults to the operator namespace when name is set.
+	// With a selector, restricts the selection to this namespace; all namespaces are searched when empty.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Namespace"
+	Namespace string `json:"namespace,omitempty"`
+	// Label selector of the InferenceServices. Every ready InferenceService matching the selector
+	// is added as a model named after the InferenceService.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Selector"
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
 // ProviderSpec defines the desired state of LLM provider.
@@ -566,6 +1483,20 @@ type ModelSpec struct {
 // +kubebuilder:validation:XValidation:message="googleVertexAnthropicConfig is required for google_vertex_anthropic provider",rule="self.type != \"google_vertex_anthropic\" || has(self.googleVertexAnthropicConfig)"
 // +kubebuilder:validation:XValidation:message="googleVertexConfig may only be set when type is google_vertex",rule="self.type == \"google_vertex\" || !has(self.googleVertexConfig)"
 // +kubebuilder:validation:XValidation:message="googleVertexAnthropicConfig may only be set when type is google_vertex_anthropic",rule="self.type == \"google_vertex_anthropic\" || !has(self.googleVertexAnthropicConfig)"
+// +kubebuilder:validation:XValidation:message="bedrockConfig may only be set when type is bedrock",rule="self.type == \"bedrock\" || !has(self.bedrockConfig)"
+// +kubebuilder:validation:XValidation:message="either url or bedrockConfig is required for bedrock provider",rule="self.type != \"bedrock\" || has(self.url) || has(self.bedrockConfig)"
+// +kubebuilder:validation:XValidation:message="url and bedrockConfig.endpointURL are mutually exclusive",rule="!has(self.url) || !has(self.bedrockConfig) || !has(self.bedrockConfig.endpointURL)"
+// +kubebuilder:validation:XValidation:message="credentialsSecretRef is required unless workloadIdentity or credentialsSource is set",rule="has(self.workloadIdentity) || has(self.credentialsSource) || (has(self.credentialsSecretRef) && has(self.credentialsSecretRef.name) && self.credentialsSecretRef.name != \"\")"
+// +kubebuilder:validation:XValidation:message="credentialsSecretRef and workloadIdentity are mutually exclusive",rule="!has(self.workloadIdentity) || !has(self.credentialsSecretRef) || !has(self.credentialsSecretRef.name) || self.credentialsSecretRef.name == \"\""
+// +kubebuilder:validation:XValidation:message="credentialsSecretRef and credentialsSource are mutually exclusive",rule="!has(self.credentialsSource) || !has(self.credentialsSecretRef) || !has(self.credentialsSecretRef.name) || self.credentialsSecretRef.name == \"\""
+// +kubebuilder:validation:XValidation:message="credentialsSource and workloadIdentity are mutually exclusive",rule="!has(self.credentialsSource) || !has(self.workloadIdentity)"
+// +kubebuilder:validation:XValidation:message="workloadIdentity is only supported for bedrock, azure_openai, google_vertex and google_vertex_anthropic providers",rule="!has(self.workloadIdentity) || self.type in [\"bedrock\", \"azure_openai\", \"google_vertex\", \"google_vertex_anthropic\"]"
+// +kubebuilder:validation:XValidation:message="workloadIdentity for bedrock requires bedrockConfig.roleARN",rule="self.type != \"bedrock\" || !has(self.workloadIdentity) || (has(self.bedrockConfig) && has(self.bedrockConfig.roleARN))"
+// +kubebuilder:validation:XValidation:message="workloadIdentity for azure_openai requires clientID and tenantID",rule="self.type != \"azure_openai\" || !has(self.workloadIdentity) || has(self.workloadIdentity.clientID)"
+// +kubebuilder:validation:XValidation:message="workloadIdentity for google_vertex and google_vertex_anthropic requires gcpWorkloadIdentityProvider",rule="!(self.type in [\"google_vertex\", \"google_vertex_anthropic\"]) || !has(self.workloadIdentity) || has(self.workloadIdentity.gcpWorkloadIdentityProvider)"
+// +kubebuilder:validation:XValidation:message="inferenceServiceRef may only be set when type is rhoai_vllm",rule="self.type == \"rhoai_vllm\" || !has(self.inferenceServiceRef)"
+// +kubebuilder:validation:XValidation:message="url and inferenceServiceRef are mutually exclusive",rule="!has(self.url) || !has(self.inferenceServiceRef)"
+// +kubebuilder:validation:XValidation:message="models is required unless inferenceServiceRef is set",rule="has(self.inferenceServiceRef) || has(self.models)"
 type ProviderSpec struct {
 	// Provider name
 	// +kubebuilder:validation:Required
@@ -576,17 +1507,22 @@ type ProviderSpec struct {
 	// +kubebuilder:validation:Pattern=`^https?://.*$`
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2,displayName="URL"
 	URL string `json:"url,omitempty"`
-	// The name of the secret object that stores API provider credentials
-	// +kubebuilder:validation:Required
-	// +required
+	// The name of the secret object that stores API provider credentials.
+	// Required unless workloadIdentity or credentialsSource is set.
+	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3,displayName="Credential Secret"
-	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
-	// List of models from the provider
-	// +kubebuilder:validation:Required
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+	// Mount the provider credentials from an external key store through the Secrets Store CSI driver
+	// instead of a Kubernetes secret
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credentials Source"
+	CredentialsSource *ProviderCredentialsSource `json:"credentialsSource,omitempty"`
+	// List of models from the provider. Required unless inferenceServiceRef is set.
+	// With inferenceServiceRef.selector, entries only set the parameters of the discovered model of the same name.
 	// +kubebuilder:validation:MaxItems=50
-	// +required
+	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Models"
-	Models []ModelSpec `json:"models"`
+	Models []ModelSpec `json:"models,omitempty"`
 	// Provider type
 	// +kubebuilder:validation:Required
 	// +required
@@ -608,6 +1544,33 @@ type ProviderSpec struct {
 	// Google Vertex Anthropic Config
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Google Vertex Anthropic Config"
 	GoogleVertexAnthropicConfig *VertexConfig `json:"googleVertexAnthropicConfig,omitempty"`
+	// AWS Bedrock Config
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="AWS Bedrock Config"
+	BedrockConfig *BedrockConfig `json:"bedrockConfig,omitempty"`
+	// KServe InferenceServices serving the models, used instead of a static URL.
+	// The operator watches them and fills in the in-cluster URL, the model names and the serving CA.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="InferenceService Reference"
+	InferenceServiceRef *InferenceServiceReference `json:"inferenceServiceRef,omitempty"`
+	// Authenticate with a projected ServiceAccount token federated to the cloud identity
+	// instead of credentials stored in a secret
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Workload Identity"
+	WorkloadIdentity *WorkloadIdentitySpec `json:"workloadIdentity,omitempty"`
+	// Extra HTTP headers sent with every request to the provider, e.g. API gateway tenant or routing keys.
+	// Each header is either a literal value or read from a secret.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Headers"
+	Headers []ProviderHeader `json:"headers,omitempty"`
+	// Request timeout for the provider in seconds. The default is set by the service.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout (seconds)"
+	Timeout int32 `json:"timeout,omitempty"`
+	// Retry policy for failed requests to the provider. The default is set by the service.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retries"
+	Retries *RetryPolicySpec `json:"retries,omitempty"`
 	// Fake Provider MCP Tool Call
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Fake Provider MCP Tool Call"
 	FakeProviderMCPToolCall bool `json:"fakeProviderMCPToolCall,omitempty"`
@@ -681,9 +1644,120 @@ type ProxyCACertConfigMapRef struct {
 	Key string `json:"key,omitempty"`
 }
 
+// OKPSpec tunes the Solr hybrid retrieval of the OKP (Offline Knowledge Portal) knowledge source.
+// +kubebuilder:validation:XValidation:rule="self.maxResults >= 1 && self.maxResults <= 50",message="maxResults must be between 1 and 50"
+// +kubebuilder:validation:XValidation:rule="self.hybridVectorBoost > 0.0 && self.hybridVectorBoost <= 100.0",message="hybridVectorBoost must be greater than 0.0 and at most 100.0"
+// +kubebuilder:validation:XValidation:rule="self.hybridPoolDocs >= 1 && self.hybridPoolDocs <= 1000",message="hybridPoolDocs must be between 1 and 1000"
+// +kubebuilder:validation:XValidation:rule="self.hybridPoolDocs >= self.maxResults",message="hybridPoolDocs must not be lower than maxResults"
+// +kubebuilder:validation:XValidation:rule="self.hybridScoreThreshold >= 0.0 && self.hybridScoreThreshold <= 1000.0",message="hybridScoreThreshold must be between 0.0 and 1000.0"
+// +kubebuilder:validation:XValidation:rule="self.solrTimeoutSeconds >= 1 && self.solrTimeoutSeconds <= 600",message="solrTimeoutSeconds must be between 1 and 600"
+type OKPSpec struct {
+	// Maximum number of documentation chunks returned per search
+	// +kubebuilder:default=5
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Results"
+	MaxResults int `json:"maxResults,omitempty"`
+
+	// Boost applied to the vector (dense) score relative to the lexical score
+	// +kubebuilder:default=8.0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hybrid Vector Boost"
+	HybridVectorBoost float64 `json:"hybridVectorBoost,omitempty"`
+
+	// Number of candidate documents retrieved from Solr before hybrid re-ranking
+	// +kubebuilder:default=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hybrid Pool Documents"
+	HybridPoolDocs int `json:"hybridPoolDocs,omitempty"`
+
+	// Minimum hybrid score of a returned chunk, 0.0 returns all chunks
+	// +kubebuilder:default=0.0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hybrid Score Threshold"
+	HybridScoreThreshold float64 `json:"hybridScoreThreshold,omitempty"`
+
+	// Timeout of a Solr query in seconds
+	// +kubebuilder:default=60
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Solr Timeout Seconds"
+	SolrTimeoutSeconds int `json:"solrTimeoutSeconds,omitempty"`
+
+	// Solr filter query applied to the documentation chunks, e.g. product_version:4.18.
+	// If not specified, the app server derives it from the OpenShift cluster version.
+	// +kubebuilder:validation:MaxLength=1024
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Chunk Filter Query"
+	ChunkFilterQuery string `json:"chunkFilterQuery,omitempty"`
+
+	// Additional documentation scopes, such as the OpenShift version of an upcoming upgrade or a
+	// layered product. A query selects a scope by name, queries without a scope keep the default filter.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=20
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Documentation Scopes"
+	DocumentationScopes []OKPDocumentationScope `json:"documentationScopes,omitempty"`
+
+	// Weight of the OKP documentation chunks when merged with the BYOK RAG indexes, relative to
+	// the weights of spec.ols.rag entries. Unweighted sources count as 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Weight"
+	Weight int32 `json:"weight,omitempty"`
+
+	// External or shared knowledge portal Solr. When set, the operator does not deploy RHOKP
+	// and the app server queries this endpoint instead.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="External Knowledge Portal"
+	External *OKPExternalSpec `json:"external,omitempty"`
+}
+
+// OKPDocumentationScope defines a set of documentation chunks a query can be restricted to
+// +kubebuilder:validation:XValidation:rule="has(self.version) || has(self.product) || has(self.chunkFilterQuery)",message="one of version, product or chunkFilterQuery must be set"
+type OKPDocumentationScope struct {
+	// Name of the scope, selected by the documentation_scope parameter of a query
+	// +kubebuilder:validation:Required
+	// +required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name"`
+	// Documentation version, e.g. 4.19
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+\.[0-9]+$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Version"
+	Version string `json:"version,omitempty"`
+	// Documentation product, e.g. openshift_container_platform
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.-]+$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Product"
+	Product string `json:"product,omitempty"`
+	// Solr filter query of the scope. When set, version and product are ignored.
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Chunk Filter Query"
+	ChunkFilterQuery string `json:"chunkFilterQuery,omitempty"`
+}
+
+// OKPExternalSpec defines an external knowledge portal Solr used instead of the per-cluster RHOKP deployment
+type OKPExternalSpec struct {
+	// Base URL of the external knowledge portal, e.g. https://okp.example.com:8443
+	// +kubebuilder:validation:Required
+	// +required
+	// +kubebuilder:validation:Pattern=`^https?://.*$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="URL"
+	URL string `json:"url"`
+	// ConfigMap holding the CA certificate of the external knowledge portal under the ca.crt key.
+	// If not specified, the certificate must be signed by a CA trusted by the system or by additionalCAConfigMapRef.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA Certificate ConfigMap"
+	CAConfigMapRef *corev1.LocalObjectReference `json:"caConfigMapRef,omitempty"`
+	// Secret holding the credentials of the external knowledge portal: the apitoken key for bearer
+	// authentication, or the username and password keys for basic authentication
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credential Secret"
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
 // ToolFilteringConfig defines configuration for tool filtering using hybrid RAG retrieval.
 // If this config is present, tool filtering is enabled. If absent, all tools are used.
-// The embedding model is not exposed as it's handled by the container image.
+// The embedding model defaults to the one shipped in the app server image.
 // +kubebuilder:validation:XValidation:rule="self.alpha >= 0.0 && self.alpha <= 1.0",message="alpha must be between 0.0 and 1.0"
 // +kubebuilder:validation:XValidation:rule="self.threshold >= 0.0 && self.threshold <= 1.0",message="threshold must be between 0.0 and 1.0"
 type ToolFilteringConfig struct {
@@ -703,6 +1777,12 @@ type ToolFilteringConfig struct {
 	// +kubebuilder:default=0.01
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Similarity Threshold"
 	Threshold float64 `json:"threshold,omitempty"`
+
+	// Embedding model used to embed the tool descriptions and queries.
+	// Defaults to the embedding model of the app server image.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Embedding Model"
+	EmbeddingModel *EmbeddingModelSpec `json:"embeddingModel,omitempty"`
 }
 
 // ApprovalType defines the approval strategy for tool execution
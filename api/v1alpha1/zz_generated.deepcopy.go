@@ -43,6 +43,21 @@ func (in *AgenticOLSSpec) DeepCopy() *AgenticOLSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertingSpec) DeepCopyInto(out *AlertingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertingSpec.
+func (in *AlertingSpec) DeepCopy() *AlertingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertsAdapterSpec) DeepCopyInto(out *AlertsAdapterSpec) {
 	*out = *in
@@ -84,6 +99,21 @@ func (in *AuditConfig) DeepCopy() *AuditConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BedrockConfig) DeepCopyInto(out *BedrockConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BedrockConfig.
+func (in *BedrockConfig) DeepCopy() *BedrockConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BedrockConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
@@ -183,6 +213,107 @@ func (in *DeploymentConfig) DeepCopy() *DeploymentConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiagnosticsReference) DeepCopyInto(out *DiagnosticsReference) {
+	*out = *in
+	in.LastCaptured.DeepCopyInto(&out.LastCaptured)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticsReference.
+func (in *DiagnosticsReference) DeepCopy() *DiagnosticsReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DiagnosticsReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddingEndpointSpec) DeepCopyInto(out *EmbeddingEndpointSpec) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddingEndpointSpec.
+func (in *EmbeddingEndpointSpec) DeepCopy() *EmbeddingEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddingEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddingModelSpec) DeepCopyInto(out *EmbeddingModelSpec) {
+	*out = *in
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(EmbeddingEndpointSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddingModelSpec.
+func (in *EmbeddingModelSpec) DeepCopy() *EmbeddingModelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddingModelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverSpec) DeepCopyInto(out *FailoverSpec) {
+	*out = *in
+	if in.Chain != nil {
+		in, out := &in.Chain, &out.Chain
+		*out = make([]RoutingTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.On != nil {
+		in, out := &in.On, &out.On
+		*out = make([]FailoverTrigger, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverSpec.
+func (in *FailoverSpec) DeepCopy() *FailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceServiceReference) DeepCopyInto(out *InferenceServiceReference) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceReference.
+func (in *InferenceServiceReference) DeepCopy() *InferenceServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LLMSpec) DeepCopyInto(out *LLMSpec) {
 	*out = *in
@@ -193,6 +324,11 @@ func (in *LLMSpec) DeepCopyInto(out *LLMSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Preflight != nil {
+		in, out := &in.Preflight, &out.Preflight
+		*out = new(ProviderPreflightSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMSpec.
@@ -293,6 +429,26 @@ func (in *MCPServerConfig) DeepCopy() *MCPServerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelCapabilitiesSpec) DeepCopyInto(out *ModelCapabilitiesSpec) {
+	*out = *in
+	if in.ToolCalling != nil {
+		in, out := &in.ToolCalling, &out.ToolCalling
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelCapabilitiesSpec.
+func (in *ModelCapabilitiesSpec) DeepCopy() *ModelCapabilitiesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelCapabilitiesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelParametersSpec) DeepCopyInto(out *ModelParametersSpec) {
 	*out = *in
@@ -312,6 +468,16 @@ func (in *ModelParametersSpec) DeepCopy() *ModelParametersSpec {
 func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
 	*out = *in
 	out.Parameters = in.Parameters
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(RetryPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(ModelCapabilitiesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
@@ -324,6 +490,71 @@ func (in *ModelSpec) DeepCopy() *ModelSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OKPDocumentationScope) DeepCopyInto(out *OKPDocumentationScope) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OKPDocumentationScope.
+func (in *OKPDocumentationScope) DeepCopy() *OKPDocumentationScope {
+	if in == nil {
+		return nil
+	}
+	out := new(OKPDocumentationScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OKPExternalSpec) DeepCopyInto(out *OKPExternalSpec) {
+	*out = *in
+	if in.CAConfigMapRef != nil {
+		in, out := &in.CAConfigMapRef, &out.CAConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OKPExternalSpec.
+func (in *OKPExternalSpec) DeepCopy() *OKPExternalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OKPExternalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OKPSpec) DeepCopyInto(out *OKPSpec) {
+	*out = *in
+	if in.DocumentationScopes != nil {
+		in, out := &in.DocumentationScopes, &out.DocumentationScopes
+		*out = make([]OKPDocumentationScope, len(*in))
+		copy(*out, *in)
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(OKPExternalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OKPSpec.
+func (in *OKPSpec) DeepCopy() *OKPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OKPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OLSConfig) DeepCopyInto(out *OLSConfig) {
 	*out = *in
@@ -436,6 +667,37 @@ func (in *OLSConfigStatus) DeepCopyInto(out *OLSConfigStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DiagnosticsRef != nil {
+		in, out := &in.DiagnosticsRef, &out.DiagnosticsRef
+		*out = new(DiagnosticsReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]ProviderStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RAGBuilds != nil {
+		in, out := &in.RAGBuilds, &out.RAGBuilds
+		*out = make([]RAGBuildStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RAG != nil {
+		in, out := &in.RAG, &out.RAG
+		*out = make([]RAGSourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RAGUpdate != nil {
+		in, out := &in.RAGUpdate, &out.RAGUpdate
+		*out = new(RAGUpdateStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OLSConfigStatus.
@@ -468,6 +730,11 @@ func (in *OLSSpec) DeepCopyInto(out *OLSSpec) {
 	*out = *in
 	out.ConversationCache = in.ConversationCache
 	in.DeploymentConfig.DeepCopyInto(&out.DeploymentConfig)
+	if in.Routing != nil {
+		in, out := &in.Routing, &out.Routing
+		*out = new(RoutingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.QueryFilters != nil {
 		in, out := &in.QueryFilters, &out.QueryFilters
 		*out = make([]QueryFiltersSpec, len(*in))
@@ -512,18 +779,42 @@ func (in *OLSSpec) DeepCopyInto(out *OLSSpec) {
 	if in.RAG != nil {
 		in, out := &in.RAG, &out.RAG
 		*out = make([]RAGSpec, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RAGBuilds != nil {
+		in, out := &in.RAGBuilds, &out.RAGBuilds
+		*out = make([]RAGBuildSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RAGUpdatePolicy != nil {
+		in, out := &in.RAGUpdatePolicy, &out.RAGUpdatePolicy
+		*out = new(RAGUpdatePolicy)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.QuotaHandlersConfig != nil {
 		in, out := &in.QuotaHandlersConfig, &out.QuotaHandlersConfig
 		*out = new(QuotaHandlersConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(AlertingSpec)
+		**out = **in
+	}
 	if in.Storage != nil {
 		in, out := &in.Storage, &out.Storage
 		*out = new(Storage)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OKP != nil {
+		in, out := &in.OKP, &out.OKP
+		*out = new(OKPSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]corev1.LocalObjectReference, len(*in))
@@ -532,7 +823,7 @@ func (in *OLSSpec) DeepCopyInto(out *OLSSpec) {
 	if in.ToolFilteringConfig != nil {
 		in, out := &in.ToolFilteringConfig, &out.ToolFilteringConfig
 		*out = new(ToolFilteringConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ToolsApprovalConfig != nil {
 		in, out := &in.ToolsApprovalConfig, &out.ToolsApprovalConfig
@@ -588,115 +879,414 @@ func (in *PostgresSpec) DeepCopy() *PostgresSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
+func (in *ProviderCredentialsSource) DeepCopyInto(out *ProviderCredentialsSource) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
-	if in.Models != nil {
-		in, out := &in.Models, &out.Models
-		*out = make([]ModelSpec, len(*in))
-		copy(*out, *in)
-	}
-	if in.GoogleVertexConfig != nil {
-		in, out := &in.GoogleVertexConfig, &out.GoogleVertexConfig
-		*out = new(VertexConfig)
-		**out = **in
-	}
-	if in.GoogleVertexAnthropicConfig != nil {
-		in, out := &in.GoogleVertexAnthropicConfig, &out.GoogleVertexAnthropicConfig
-		*out = new(VertexConfig)
-		**out = **in
-	}
-	if in.TLSSecurityProfile != nil {
-		in, out := &in.TLSSecurityProfile, &out.TLSSecurityProfile
-		*out = new(configv1.TLSSecurityProfile)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderSpec.
-func (in *ProviderSpec) DeepCopy() *ProviderSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCredentialsSource.
+func (in *ProviderCredentialsSource) DeepCopy() *ProviderCredentialsSource {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderSpec)
+	out := new(ProviderCredentialsSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyCACertConfigMapRef) DeepCopyInto(out *ProxyCACertConfigMapRef) {
+func (in *ProviderHeader) DeepCopyInto(out *ProviderHeader) {
 	*out = *in
-	out.LocalObjectReference = in.LocalObjectReference
+	in.ValueFrom.DeepCopyInto(&out.ValueFrom)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyCACertConfigMapRef.
-func (in *ProxyCACertConfigMapRef) DeepCopy() *ProxyCACertConfigMapRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderHeader.
+func (in *ProviderHeader) DeepCopy() *ProviderHeader {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyCACertConfigMapRef)
+	out := new(ProviderHeader)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+func (in *ProviderHeaderValueSource) DeepCopyInto(out *ProviderHeaderValueSource) {
 	*out = *in
-	if in.ProxyCACertificateRef != nil {
-		in, out := &in.ProxyCACertificateRef, &out.ProxyCACertificateRef
-		*out = new(ProxyCACertConfigMapRef)
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
-func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderHeaderValueSource.
+func (in *ProviderHeaderValueSource) DeepCopy() *ProviderHeaderValueSource {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyConfig)
+	out := new(ProviderHeaderValueSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryFiltersSpec) DeepCopyInto(out *QueryFiltersSpec) {
+func (in *ProviderPreflightSpec) DeepCopyInto(out *ProviderPreflightSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryFiltersSpec.
-func (in *QueryFiltersSpec) DeepCopy() *QueryFiltersSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderPreflightSpec.
+func (in *ProviderPreflightSpec) DeepCopy() *ProviderPreflightSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryFiltersSpec)
+	out := new(ProviderPreflightSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuotaHandlersConfig) DeepCopyInto(out *QuotaHandlersConfig) {
+func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 	*out = *in
-	if in.LimitersConfig != nil {
-		in, out := &in.LimitersConfig, &out.LimitersConfig
-		*out = make([]LimiterConfig, len(*in))
-		copy(*out, *in)
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.CredentialsSource != nil {
+		in, out := &in.CredentialsSource, &out.CredentialsSource
+		*out = new(ProviderCredentialsSource)
+		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaHandlersConfig.
-func (in *QuotaHandlersConfig) DeepCopy() *QuotaHandlersConfig {
-	if in == nil {
-		return nil
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]ModelSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	out := new(QuotaHandlersConfig)
-	in.DeepCopyInto(out)
+	if in.GoogleVertexConfig != nil {
+		in, out := &in.GoogleVertexConfig, &out.GoogleVertexConfig
+		*out = new(VertexConfig)
+		**out = **in
+	}
+	if in.GoogleVertexAnthropicConfig != nil {
+		in, out := &in.GoogleVertexAnthropicConfig, &out.GoogleVertexAnthropicConfig
+		*out = new(VertexConfig)
+		**out = **in
+	}
+	if in.BedrockConfig != nil {
+		in, out := &in.BedrockConfig, &out.BedrockConfig
+		*out = new(BedrockConfig)
+		**out = **in
+	}
+	if in.InferenceServiceRef != nil {
+		in, out := &in.InferenceServiceRef, &out.InferenceServiceRef
+		*out = new(InferenceServiceReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(WorkloadIdentitySpec)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]ProviderHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(RetryPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSSecurityProfile != nil {
+		in, out := &in.TLSSecurityProfile, &out.TLSSecurityProfile
+		*out = new(configv1.TLSSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderSpec.
+func (in *ProviderSpec) DeepCopy() *ProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderStatus) DeepCopyInto(out *ProviderStatus) {
+	*out = *in
+	in.LastChecked.DeepCopyInto(&out.LastChecked)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderStatus.
+func (in *ProviderStatus) DeepCopy() *ProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyCACertConfigMapRef) DeepCopyInto(out *ProxyCACertConfigMapRef) {
+	*out = *in
+	out.LocalObjectReference = in.LocalObjectReference
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyCACertConfigMapRef.
+func (in *ProxyCACertConfigMapRef) DeepCopy() *ProxyCACertConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyCACertConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+	if in.ProxyCACertificateRef != nil {
+		in, out := &in.ProxyCACertificateRef, &out.ProxyCACertificateRef
+		*out = new(ProxyCACertConfigMapRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryFiltersSpec) DeepCopyInto(out *QueryFiltersSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryFiltersSpec.
+func (in *QueryFiltersSpec) DeepCopy() *QueryFiltersSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryFiltersSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaHandlersConfig) DeepCopyInto(out *QuotaHandlersConfig) {
+	*out = *in
+	if in.LimitersConfig != nil {
+		in, out := &in.LimitersConfig, &out.LimitersConfig
+		*out = make([]LimiterConfig, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaHandlersConfig.
+func (in *QuotaHandlersConfig) DeepCopy() *QuotaHandlersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaHandlersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAGBuildGitSource) DeepCopyInto(out *RAGBuildGitSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGBuildGitSource.
+func (in *RAGBuildGitSource) DeepCopy() *RAGBuildGitSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RAGBuildGitSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAGBuildPersistentVolumeClaimSource) DeepCopyInto(out *RAGBuildPersistentVolumeClaimSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGBuildPersistentVolumeClaimSource.
+func (in *RAGBuildPersistentVolumeClaimSource) DeepCopy() *RAGBuildPersistentVolumeClaimSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RAGBuildPersistentVolumeClaimSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAGBuildSource) DeepCopyInto(out *RAGBuildSource) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(RAGBuildPersistentVolumeClaimSource)
+		**out = **in
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(RAGBuildGitSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGBuildSource.
+func (in *RAGBuildSource) DeepCopy() *RAGBuildSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RAGBuildSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAGBuildSpec) DeepCopyInto(out *RAGBuildSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(Storage)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGBuildSpec.
+func (in *RAGBuildSpec) DeepCopy() *RAGBuildSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RAGBuildSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAGBuildStatus) DeepCopyInto(out *RAGBuildStatus) {
+	*out = *in
+	if in.LastSucceededTime != nil {
+		in, out := &in.LastSucceededTime, &out.LastSucceededTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGBuildStatus.
+func (in *RAGBuildStatus) DeepCopy() *RAGBuildStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RAGBuildStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAGOCIArtifactSource) DeepCopyInto(out *RAGOCIArtifactSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGOCIArtifactSource.
+func (in *RAGOCIArtifactSource) DeepCopy() *RAGOCIArtifactSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RAGOCIArtifactSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAGPersistentVolumeClaimSource) DeepCopyInto(out *RAGPersistentVolumeClaimSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGPersistentVolumeClaimSource.
+func (in *RAGPersistentVolumeClaimSource) DeepCopy() *RAGPersistentVolumeClaimSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RAGPersistentVolumeClaimSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAGSourceStatus) DeepCopyInto(out *RAGSourceStatus) {
+	*out = *in
+	if in.LastImportTime != nil {
+		in, out := &in.LastImportTime, &out.LastImportTime
+		*out = (*in).DeepCopy()
+	}
+	if in.IndexFound != nil {
+		in, out := &in.IndexFound, &out.IndexFound
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGSourceStatus.
+func (in *RAGSourceStatus) DeepCopy() *RAGSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RAGSourceStatus)
+	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RAGSpec) DeepCopyInto(out *RAGSpec) {
 	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(RAGPersistentVolumeClaimSource)
+		**out = **in
+	}
+	if in.OCIArtifact != nil {
+		in, out := &in.OCIArtifact, &out.OCIArtifact
+		*out = new(RAGOCIArtifactSource)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.EmbeddingModel != nil {
+		in, out := &in.EmbeddingModel, &out.EmbeddingModel
+		*out = new(EmbeddingModelSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreferredTopics != nil {
+		in, out := &in.PreferredTopics, &out.PreferredTopics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGSpec.
@@ -709,6 +1299,220 @@ func (in *RAGSpec) DeepCopy() *RAGSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAGUpdatePolicy) DeepCopyInto(out *RAGUpdatePolicy) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(RAGUpdateWindow)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGUpdatePolicy.
+func (in *RAGUpdatePolicy) DeepCopy() *RAGUpdatePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RAGUpdatePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAGUpdateStatus) DeepCopyInto(out *RAGUpdateStatus) {
+	*out = *in
+	if in.NextWindowTime != nil {
+		in, out := &in.NextWindowTime, &out.NextWindowTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGUpdateStatus.
+func (in *RAGUpdateStatus) DeepCopy() *RAGUpdateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RAGUpdateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAGUpdateWindow) DeepCopyInto(out *RAGUpdateWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGUpdateWindow.
+func (in *RAGUpdateWindow) DeepCopy() *RAGUpdateWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(RAGUpdateWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RHOKPAccessKeyRotation) DeepCopyInto(out *RHOKPAccessKeyRotation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RHOKPAccessKeyRotation.
+func (in *RHOKPAccessKeyRotation) DeepCopy() *RHOKPAccessKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(RHOKPAccessKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RHOKPSpec) DeepCopyInto(out *RHOKPSpec) {
+	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(Storage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessKeyRotation != nil {
+		in, out := &in.AccessKeyRotation, &out.AccessKeyRotation
+		*out = new(RHOKPAccessKeyRotation)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RHOKPSpec.
+func (in *RHOKPSpec) DeepCopy() *RHOKPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RHOKPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicySpec) DeepCopyInto(out *RetryPolicySpec) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicySpec.
+func (in *RetryPolicySpec) DeepCopy() *RetryPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingMatch) DeepCopyInto(out *RoutingMatch) {
+	*out = *in
+	if in.HasAttachments != nil {
+		in, out := &in.HasAttachments, &out.HasAttachments
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinQueryLength != nil {
+		in, out := &in.MinQueryLength, &out.MinQueryLength
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MediaTypes != nil {
+		in, out := &in.MediaTypes, &out.MediaTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserGroups != nil {
+		in, out := &in.UserGroups, &out.UserGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingMatch.
+func (in *RoutingMatch) DeepCopy() *RoutingMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingRule) DeepCopyInto(out *RoutingRule) {
+	*out = *in
+	in.Match.DeepCopyInto(&out.Match)
+	out.Target = in.Target
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingRule.
+func (in *RoutingRule) DeepCopy() *RoutingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingSpec) DeepCopyInto(out *RoutingSpec) {
+	*out = *in
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(FailoverSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WeightedTargets != nil {
+		in, out := &in.WeightedTargets, &out.WeightedTargets
+		*out = make([]WeightedTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RoutingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingSpec.
+func (in *RoutingSpec) DeepCopy() *RoutingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingTarget) DeepCopyInto(out *RoutingTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingTarget.
+func (in *RoutingTarget) DeepCopy() *RoutingTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Storage) DeepCopyInto(out *Storage) {
 	*out = *in
@@ -744,6 +1548,11 @@ func (in *TLSConfig) DeepCopy() *TLSConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ToolFilteringConfig) DeepCopyInto(out *ToolFilteringConfig) {
 	*out = *in
+	if in.EmbeddingModel != nil {
+		in, out := &in.EmbeddingModel, &out.EmbeddingModel
+		*out = new(EmbeddingModelSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolFilteringConfig.
@@ -800,3 +1609,34 @@ func (in *VertexConfig) DeepCopy() *VertexConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeightedTarget) DeepCopyInto(out *WeightedTarget) {
+	*out = *in
+	out.RoutingTarget = in.RoutingTarget
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeightedTarget.
+func (in *WeightedTarget) DeepCopy() *WeightedTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(WeightedTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentitySpec) DeepCopyInto(out *WorkloadIdentitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentitySpec.
+func (in *WorkloadIdentitySpec) DeepCopy() *WorkloadIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
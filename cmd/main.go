@@ -72,6 +72,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -88,6 +89,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openshift/lightspeed-operator/internal/controller"
+	"github.com/openshift/lightspeed-operator/internal/controller/preflight"
+	"github.com/openshift/lightspeed-operator/internal/controller/rhokp"
 	"github.com/openshift/lightspeed-operator/internal/controller/utils"
 	utiltls "github.com/openshift/lightspeed-operator/internal/tls"
 	//+kubebuilder:scaffold:imports
@@ -295,6 +298,48 @@ func main() {
 		}
 	}
 
+	cacheByObject := map[client.Object]cache.ByObject{
+		&corev1.Secret{}: {
+			Namespaces: map[string]cache.Config{
+				namespace: {},
+				// The ClusterRole only permits reading the telemetry pull-secret by name
+				// (resourceNames=pull-secret). Kubernetes requires list/watch requests on a
+				// resourceNames-restricted rule to carry a matching metadata.name field selector,
+				// otherwise the informer's bare LIST is denied with 403 and the manager fails to start.
+				utils.TelemetryPullSecretNamespace: {
+					FieldSelector: fields.SelectorFromSet(fields.Set{"metadata.name": utils.TelemetryPullSecretName}),
+				},
+			},
+		},
+		&corev1.ConfigMap{}: {
+			Namespaces: map[string]cache.Config{
+				namespace: {},
+				// Only the Lightspeed console dashboard is read from openshift-config-managed.
+				utils.ConsoleDashboardNamespace: {
+					FieldSelector: fields.SelectorFromSet(fields.Set{"metadata.name": utils.ConsoleDashboardConfigMapName}),
+				},
+			},
+		},
+		&rbacv1.RoleBinding{}: {
+			Namespaces: map[string]cache.Config{
+				namespace:                          {},
+				utils.OpenShiftMonitoringNamespace: {},
+			},
+		},
+	}
+	// InferenceServices referenced by LLM providers may live in any namespace.
+	// They are only watched when KServe is installed, otherwise the informer would fail to start.
+	kserveAvailable := utils.IsKServeAvailable(ctx, k8sClient)
+	if kserveAvailable {
+		cacheByObject[utils.NewInferenceService()] = cache.ByObject{
+			Namespaces: map[string]cache.Config{cache.AllNamespaces: {}},
+		}
+	}
+	setupLog.Info("KServe InferenceService discovery", "available", kserveAvailable)
+	// SecretProviderClassPodStatuses of the app server pods report credential rotations of the Secrets Store CSI driver
+	secretsStoreCSIAvailable := utils.IsSecretsStoreCSIAvailable(ctx, k8sClient)
+	setupLog.Info("Secrets Store CSI driver rotation watch", "available", secretsStoreCSIAvailable)
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
@@ -312,26 +357,7 @@ func main() {
 			DefaultNamespaces: map[string]cache.Config{
 				namespace: {},
 			},
-			ByObject: map[client.Object]cache.ByObject{
-				&corev1.Secret{}: {
-					Namespaces: map[string]cache.Config{
-						namespace: {},
-						// The ClusterRole only permits reading the telemetry pull-secret by name
-						// (resourceNames=pull-secret). Kubernetes requires list/watch requests on a
-						// resourceNames-restricted rule to carry a matching metadata.name field selector,
-						// otherwise the informer's bare LIST is denied with 403 and the manager fails to start.
-						utils.TelemetryPullSecretNamespace: {
-							FieldSelector: fields.SelectorFromSet(fields.Set{"metadata.name": utils.TelemetryPullSecretName}),
-						},
-					},
-				},
-				&rbacv1.RoleBinding{}: {
-					Namespaces: map[string]cache.Config{
-						namespace:                          {},
-						utils.OpenShiftMonitoringNamespace: {},
-					},
-				},
-			},
+			ByObject: cacheByObject,
 		},
 	})
 	if err != nil {
@@ -459,6 +485,13 @@ func main() {
 		AnnotatedConfigMapMapping: map[string][]string{},
 	}
 
+	// The pod log and Event APIs used for failure diagnostics are not served by the controller-runtime client
+	kubeClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create kubernetes clientset")
+		os.Exit(1)
+	}
+
 	if err = (&controller.OLSConfigReconciler{
 		Client: mgr.GetClient(),
 		Logger: ctrl.Log.WithName("controller").WithName("OLSConfig"),
@@ -478,8 +511,14 @@ func main() {
 			RosaOKPProductEnv:              rosaOKPProductEnv,
 			Namespace:                      namespace,
 			PrometheusAvailable:            prometheusAvailable,
+			KServeAvailable:                kserveAvailable,
+			SecretsStoreCSIAvailable:       secretsStoreCSIAvailable,
 		},
-		WatcherConfig: watcherConfig,
+		WatcherConfig:      watcherConfig,
+		Recorder:           mgr.GetEventRecorder(utils.EventRecorderName),
+		KubeClient:         kubeClient,
+		ProviderPreflight:  preflight.NewProber(),
+		OKPExternalChecker: rhokp.NewExternalChecker(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OLSConfig")
 		os.Exit(1)
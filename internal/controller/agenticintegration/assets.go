@@ -131,9 +131,14 @@ func GenerateAgenticConfigurationConfigMap(r reconciler.Reconciler, cr *olsv1alp
 		data[utils.AgenticConfigurationMCPCASecretKey] = utils.AgenticMCPCASecretName
 	}
 	if !cr.Spec.OLSConfig.ByokRAGOnly {
-		data[utils.AgenticConfigurationRHOKPEndpointKey] = utils.RHOKPServiceURL(ns)
+		data[utils.AgenticConfigurationRHOKPEndpointKey] = utils.OKPServiceURL(cr, ns)
+	}
+	if utils.IsOKPCATrusted(cr) {
 		data[utils.AgenticConfigurationRHOKPCASecretKey] = utils.AgenticRHOKPCASecretName
 	}
+	if utils.RHOKPAccessKeyRotation(cr) != nil {
+		data[utils.AgenticConfigurationRHOKPAccessKeySecretKey] = utils.RHOOKPAccessKeySecretName
+	}
 
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
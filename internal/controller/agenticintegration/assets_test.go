@@ -129,6 +129,29 @@ var _ = Describe("Agentic integration assets", func() {
 		testCR.Spec.OLSConfig.ByokRAGOnly = false
 	})
 
+	It("should publish the external knowledge portal endpoint", func() {
+		testCR.Spec.OLSConfig.OKP = &olsv1alpha1.OKPSpec{External: &olsv1alpha1.OKPExternalSpec{
+			URL: "https://okp.example.com",
+		}}
+		cm, err := GenerateAgenticConfigurationConfigMap(testReconcilerInstance, testCR)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cm.Data[utils.AgenticConfigurationRHOKPEndpointKey]).To(Equal("https://okp.example.com"))
+		Expect(cm.Data).NotTo(HaveKey(utils.AgenticConfigurationRHOKPCASecretKey))
+		testCR.Spec.OLSConfig.OKP = nil
+	})
+
+	It("should name the RHOKP access key Secret when the operator rotates it", func() {
+		cm, err := GenerateAgenticConfigurationConfigMap(testReconcilerInstance, testCR)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cm.Data).NotTo(HaveKey(utils.AgenticConfigurationRHOKPAccessKeySecretKey))
+
+		testCR.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.AccessKeyRotation = &olsv1alpha1.RHOKPAccessKeyRotation{IntervalDays: 30}
+		cm, err = GenerateAgenticConfigurationConfigMap(testReconcilerInstance, testCR)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cm.Data[utils.AgenticConfigurationRHOKPAccessKeySecretKey]).To(Equal(utils.RHOOKPAccessKeySecretName))
+		testCR.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.AccessKeyRotation = nil
+	})
+
 	It("should touch the ConfigMap annotation to bump resourceVersion", func() {
 		testCR.Spec.OLSConfig.IntrospectionEnabled = utils.BoolPtr(false)
 		ensureHandoffCreatePrerequisites(false)
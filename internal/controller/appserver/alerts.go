@@ -0,0 +1,173 @@
+package appserver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+// alertingSettings holds spec.ols.alerting with the defaults applied
+type alertingSettings struct {
+	runbookBaseURL    string
+	errorPercent      int
+	tokenQuotaPercent int
+	pendingFor        monv1.Duration
+	namespaceMatcher  string
+}
+
+func newAlertingSettings(r reconciler.Reconciler, spec *olsv1alpha1.AlertingSpec) alertingSettings {
+	s := alertingSettings{
+		runbookBaseURL:    utils.AlertingRunbookBaseURLDefault,
+		errorPercent:      utils.AlertingStreamingQueryErrorPercentDefault,
+		tokenQuotaPercent: utils.AlertingTokenQuotaUsagePercentDefault,
+		pendingFor:        monv1.Duration(utils.AlertingForDefault),
+		namespaceMatcher:  fmt.Sprintf("namespace=%q", r.GetNamespace()),
+	}
+	if spec.RunbookBaseURL != "" {
+		s.runbookBaseURL = spec.RunbookBaseURL
+	}
+	if !strings.HasSuffix(s.runbookBaseURL, "/") {
+		s.runbookBaseURL += "/"
+	}
+	if spec.StreamingQueryErrorPercent > 0 {
+		s.errorPercent = spec.StreamingQueryErrorPercent
+	}
+	if spec.TokenQuotaUsagePercent > 0 {
+		s.tokenQuotaPercent = spec.TokenQuotaUsagePercent
+	}
+	if spec.For != "" {
+		s.pendingFor = monv1.Duration(spec.For)
+	}
+	return s
+}
+
+func (s alertingSettings) alert(name, severity, expr, summary, description string) monv1.Rule {
+	pendingFor := s.pendingFor
+	return monv1.Rule{
+		Alert: name,
+		Expr:  intstr.FromString(expr),
+		For:   &pendingFor,
+		Labels: map[string]string{
+			"severity": severity,
+		},
+		Annotations: map[string]string{
+			"summary":     summary,
+			"description": description,
+			"runbook_url": s.runbookBaseURL + name + ".md",
+		},
+	}
+}
+
+func (s alertingSettings) deploymentUnavailableAlert(name, severity, deployment, component string) monv1.Rule {
+	return s.alert(name, severity,
+		fmt.Sprintf("kube_deployment_status_replicas_available{%s,deployment=%q} == 0", s.namespaceMatcher, deployment),
+		fmt.Sprintf("OpenShift Lightspeed %s is unavailable.", component),
+		fmt.Sprintf("Deployment {{ $labels.namespace }}/%s has had no available replicas for more than %s.", deployment, s.pendingFor),
+	)
+}
+
+// generateAlertingRuleGroup returns the opt-in OLS alerts, or nil when spec.ols.alerting is not enabled.
+// Alerts for optional operands are only added when the operand is deployed.
+func generateAlertingRuleGroup(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) *monv1.RuleGroup {
+	spec := cr.Spec.OLSConfig.Alerting
+	if spec == nil || !spec.Enabled {
+		return nil
+	}
+	s := newAlertingSettings(r, spec)
+
+	rules := []monv1.Rule{
+		s.alert("OLSStreamingQueryErrorsHigh", utils.AlertSeverityWarning,
+			fmt.Sprintf("sum(rate(ols_rest_api_calls_total{%[1]s,path=\"/v1/streaming_query\",status_code=~\"5..\"}[5m])) / sum(rate(ols_rest_api_calls_total{%[1]s,path=\"/v1/streaming_query\"}[5m])) > %[2]s",
+				s.namespaceMatcher, percentToRatio(s.errorPercent)),
+			"OpenShift Lightspeed streaming queries are failing.",
+			fmt.Sprintf("More than %d%% of /v1/streaming_query requests returned a 5xx status over the last 5 minutes.", s.errorPercent),
+		),
+		s.deploymentUnavailableAlert("OLSAppServerUnavailable", utils.AlertSeverityCritical, utils.OLSAppServerDeploymentName, "application server"),
+		s.deploymentUnavailableAlert("OLSPostgresUnavailable", utils.AlertSeverityCritical, utils.PostgresDeploymentName, "conversation cache database"),
+	}
+
+	if r.GetOtelCollectorImage() != "" {
+		rules = append(rules, s.alert("OLSOtelCollectorExportFailing", utils.AlertSeverityWarning,
+			fmt.Sprintf("sum by (exporter) (rate({__name__=~\"otelcol_exporter_send_failed_(spans|log_records|metric_points)(_total)?\",%s}[5m])) > 0", s.namespaceMatcher),
+			"OpenShift Lightspeed OTEL collector fails to export telemetry.",
+			"Exporter {{ $labels.exporter }} of the OpenShift Lightspeed OTEL collector is failing to send telemetry.",
+		))
+	}
+
+	if utils.IsRHOKPDeployed(cr) {
+		rules = append(rules, s.deploymentUnavailableAlert("OLSRHOKPUnreachable", utils.AlertSeverityWarning, utils.RHOKPDeploymentName, "knowledge portal (RHOKP)"))
+	}
+
+	// Only cluster limiters are covered: the app server exports token usage per provider and model, not per user.
+	if cr.Spec.OLSConfig.QuotaHandlersConfig != nil {
+		for _, limiter := range cr.Spec.OLSConfig.QuotaHandlersConfig.LimitersConfig {
+			if limiter.Type != "cluster_limiter" || limiter.InitialQuota <= 0 {
+				continue
+			}
+			window, err := limiterPeriodToPromRange(limiter.Period)
+			if err != nil {
+				r.GetLogger().Info("skipping token quota alert", "limiter", limiter.Name, "error", err)
+				continue
+			}
+			rule := s.alert("OLSTokenQuotaNearlyExhausted", utils.AlertSeverityWarning,
+				fmt.Sprintf("(sum(increase(ols_llm_token_sent_total{%[1]s}[%[2]s])) + sum(increase(ols_llm_token_received_total{%[1]s}[%[2]s]))) / %[3]d > %[4]s",
+					s.namespaceMatcher, window, limiter.InitialQuota, percentToRatio(s.tokenQuotaPercent)),
+				fmt.Sprintf("OpenShift Lightspeed token quota %s is nearly exhausted.", limiter.Name),
+				fmt.Sprintf("More than %d%% of the %d tokens of limiter %s have been used within its %s period.", s.tokenQuotaPercent, limiter.InitialQuota, limiter.Name, limiter.Period),
+			)
+			rule.Labels["limiter"] = limiter.Name
+			rules = append(rules, rule)
+		}
+	}
+
+	return &monv1.RuleGroup{
+		Name:  utils.AlertingRuleGroupName,
+		Rules: rules,
+	}
+}
+
+// percentToRatio formats a percentage as the ratio used in PromQL comparisons, e.g. 5 -> "0.05"
+func percentToRatio(percent int) string {
+	return strconv.FormatFloat(float64(percent)/100, 'f', -1, 64)
+}
+
+// limiterPeriodUnits maps the PostgreSQL interval units accepted in a quota limiter period to a
+// PromQL duration unit and the count of that unit per interval unit.
+// Months are counted as 30 days and years as 365 days.
+var limiterPeriodUnits = map[string]struct {
+	promUnit   string
+	multiplier int
+}{
+	"s": {"s", 1}, "sec": {"s", 1}, "secs": {"s", 1}, "second": {"s", 1}, "seconds": {"s", 1},
+	"m": {"m", 1}, "min": {"m", 1}, "mins": {"m", 1}, "minute": {"m", 1}, "minutes": {"m", 1},
+	"h": {"h", 1}, "hr": {"h", 1}, "hrs": {"h", 1}, "hour": {"h", 1}, "hours": {"h", 1},
+	"d": {"d", 1}, "day": {"d", 1}, "days": {"d", 1},
+	"w": {"w", 1}, "week": {"w", 1}, "weeks": {"w", 1},
+	"mon": {"d", 30}, "mons": {"d", 30}, "month": {"d", 30}, "months": {"d", 30},
+	"y": {"d", 365}, "yr": {"d", 365}, "yrs": {"d", 365}, "year": {"d", 365}, "years": {"d", 365},
+}
+
+// limiterPeriodToPromRange converts a quota limiter period (e.g. "1 hour", "30 min", "2 d") into a PromQL range.
+// The unit follows the PostgreSQL interval syntax used by the quota limiter, "m" being minutes.
+func limiterPeriodToPromRange(period string) (string, error) {
+	fields := strings.Fields(period)
+	if len(fields) != 2 {
+		return "", fmt.Errorf("invalid limiter period %q", period)
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n <= 0 {
+		return "", fmt.Errorf("invalid limiter period %q", period)
+	}
+	unit, ok := limiterPeriodUnits[strings.ToLower(fields[1])]
+	if !ok {
+		return "", fmt.Errorf("invalid limiter period %q", period)
+	}
+	return fmt.Sprintf("%d%s", n*unit.multiplier, unit.promUnit), nil
+}
@@ -0,0 +1,178 @@
+package appserver
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+var _ = Describe("App server alerting rules", func() {
+	var cr *olsv1alpha1.OLSConfig
+	var tr *utils.TestReconciler
+	var savedOtelImage string
+
+	alertsByName := func(rule *monv1.PrometheusRule) map[string][]monv1.Rule {
+		alerts := map[string][]monv1.Rule{}
+		for _, group := range rule.Spec.Groups {
+			if group.Name != utils.AlertingRuleGroupName {
+				continue
+			}
+			for _, r := range group.Rules {
+				alerts[r.Alert] = append(alerts[r.Alert], r)
+			}
+		}
+		return alerts
+	}
+
+	BeforeEach(func() {
+		cr = utils.GetDefaultOLSConfigCR()
+		tr = testReconcilerInstance.(*utils.TestReconciler)
+		savedOtelImage = tr.OtelCollectorImage
+		tr.OtelCollectorImage = "quay.io/test/lightspeed-otel-collector:test"
+	})
+
+	AfterEach(func() {
+		tr.OtelCollectorImage = savedOtelImage
+	})
+
+	It("should not add alerts unless enabled", func() {
+		rule, err := GeneratePrometheusRule(testReconcilerInstance, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rule.Spec.Groups).To(HaveLen(1))
+
+		cr.Spec.OLSConfig.Alerting = &olsv1alpha1.AlertingSpec{Enabled: false}
+		rule, err = GeneratePrometheusRule(testReconcilerInstance, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rule.Spec.Groups).To(HaveLen(1))
+	})
+
+	It("should add alerts with default thresholds, severities and runbooks", func() {
+		cr.Spec.OLSConfig.Alerting = &olsv1alpha1.AlertingSpec{Enabled: true}
+		rule, err := GeneratePrometheusRule(testReconcilerInstance, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rule.Spec.Groups).To(HaveLen(2))
+		Expect(rule.Spec.Groups[0].Rules).To(HaveLen(4))
+
+		alerts := alertsByName(rule)
+		Expect(alerts).To(HaveKey("OLSStreamingQueryErrorsHigh"))
+		Expect(alerts).To(HaveKey("OLSAppServerUnavailable"))
+		Expect(alerts).To(HaveKey("OLSPostgresUnavailable"))
+		Expect(alerts).To(HaveKey("OLSOtelCollectorExportFailing"))
+		Expect(alerts).To(HaveKey("OLSRHOKPUnreachable"))
+		Expect(alerts).NotTo(HaveKey("OLSTokenQuotaNearlyExhausted"))
+
+		errorsHigh := alerts["OLSStreamingQueryErrorsHigh"][0]
+		Expect(errorsHigh.Expr.String()).To(ContainSubstring(`path="/v1/streaming_query",status_code=~"5.."`))
+		Expect(errorsHigh.Expr.String()).To(HaveSuffix("> 0.05"))
+		Expect(*errorsHigh.For).To(Equal(monv1.Duration(utils.AlertingForDefault)))
+		Expect(errorsHigh.Labels).To(HaveKeyWithValue("severity", utils.AlertSeverityWarning))
+		Expect(errorsHigh.Annotations).To(HaveKeyWithValue("runbook_url", utils.AlertingRunbookBaseURLDefault+"OLSStreamingQueryErrorsHigh.md"))
+
+		appServer := alerts["OLSAppServerUnavailable"][0]
+		Expect(appServer.Expr.String()).To(Equal(`kube_deployment_status_replicas_available{namespace="` + utils.OLSNamespaceDefault + `",deployment="` + utils.OLSAppServerDeploymentName + `"} == 0`))
+		Expect(appServer.Labels).To(HaveKeyWithValue("severity", utils.AlertSeverityCritical))
+		Expect(alerts["OLSPostgresUnavailable"][0].Expr.String()).To(ContainSubstring(utils.PostgresDeploymentName))
+		Expect(alerts["OLSRHOKPUnreachable"][0].Expr.String()).To(ContainSubstring(utils.RHOKPDeploymentName))
+	})
+
+	It("should apply the thresholds from the CR", func() {
+		cr.Spec.OLSConfig.Alerting = &olsv1alpha1.AlertingSpec{
+			Enabled:                    true,
+			RunbookBaseURL:             "https://runbooks.example.com/ols",
+			StreamingQueryErrorPercent: 20,
+			TokenQuotaUsagePercent:     75,
+			For:                        "30m",
+		}
+		cr.Spec.OLSConfig.QuotaHandlersConfig = &olsv1alpha1.QuotaHandlersConfig{
+			LimitersConfig: []olsv1alpha1.LimiterConfig{
+				{Name: "cluster_daily", Type: "cluster_limiter", InitialQuota: 100000, Period: "1 day"},
+				{Name: "cluster_monthly", Type: "cluster_limiter", InitialQuota: 2000000, Period: "2 months"},
+				{Name: "per_user", Type: "user_limiter", InitialQuota: 1000, Period: "1 hour"},
+			},
+		}
+		rule, err := GeneratePrometheusRule(testReconcilerInstance, cr)
+		Expect(err).NotTo(HaveOccurred())
+		alerts := alertsByName(rule)
+
+		errorsHigh := alerts["OLSStreamingQueryErrorsHigh"][0]
+		Expect(errorsHigh.Expr.String()).To(HaveSuffix("> 0.2"))
+		Expect(*errorsHigh.For).To(Equal(monv1.Duration("30m")))
+		Expect(errorsHigh.Annotations).To(HaveKeyWithValue("runbook_url", "https://runbooks.example.com/ols/OLSStreamingQueryErrorsHigh.md"))
+
+		quota := alerts["OLSTokenQuotaNearlyExhausted"]
+		Expect(quota).To(HaveLen(2))
+		Expect(quota[0].Labels).To(HaveKeyWithValue("limiter", "cluster_daily"))
+		Expect(quota[0].Expr.String()).To(ContainSubstring("ols_llm_token_sent_total"))
+		Expect(quota[0].Expr.String()).To(ContainSubstring("[1d]"))
+		Expect(quota[0].Expr.String()).To(HaveSuffix("/ 100000 > 0.75"))
+		Expect(quota[1].Labels).To(HaveKeyWithValue("limiter", "cluster_monthly"))
+		Expect(quota[1].Expr.String()).To(ContainSubstring("[60d]"))
+	})
+
+	It("should skip alerts for operands that are not deployed", func() {
+		tr.OtelCollectorImage = ""
+		cr.Spec.OLSConfig.ByokRAGOnly = true
+		cr.Spec.OLSConfig.Alerting = &olsv1alpha1.AlertingSpec{Enabled: true}
+		rule, err := GeneratePrometheusRule(testReconcilerInstance, cr)
+		Expect(err).NotTo(HaveOccurred())
+		alerts := alertsByName(rule)
+		Expect(alerts).NotTo(HaveKey("OLSOtelCollectorExportFailing"))
+		Expect(alerts).NotTo(HaveKey("OLSRHOKPUnreachable"))
+		Expect(alerts).To(HaveKey("OLSAppServerUnavailable"))
+	})
+
+	DescribeTable("limiterPeriodToPromRange",
+		func(period, expected string) {
+			window, err := limiterPeriodToPromRange(period)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(window).To(Equal(expected))
+		},
+		Entry("seconds", "30 seconds", "30s"),
+		Entry("second", "1 second", "1s"),
+		Entry("sec", "10 sec", "10s"),
+		Entry("secs", "10 secs", "10s"),
+		Entry("short seconds", "1 s", "1s"),
+		Entry("minutes", "5 minutes", "5m"),
+		Entry("minute", "1 minute", "1m"),
+		Entry("min", "30 min", "30m"),
+		Entry("mins", "30 mins", "30m"),
+		Entry("short minutes", "1 m", "1m"),
+		Entry("hours", "2 hours", "2h"),
+		Entry("hour", "1 hour", "1h"),
+		Entry("hr", "1 hr", "1h"),
+		Entry("hrs", "3 hrs", "3h"),
+		Entry("short hours", "1 h", "1h"),
+		Entry("days", "2 days", "2d"),
+		Entry("day", "1 day", "1d"),
+		Entry("short days", "2 d", "2d"),
+		Entry("weeks", "2 weeks", "2w"),
+		Entry("week", "1 week", "1w"),
+		Entry("short weeks", "1 w", "1w"),
+		Entry("months", "2 months", "60d"),
+		Entry("month", "1 month", "30d"),
+		Entry("mon", "1 mon", "30d"),
+		Entry("mons", "3 mons", "90d"),
+		Entry("years", "2 years", "730d"),
+		Entry("year", "1 year", "365d"),
+		Entry("yr", "1 yr", "365d"),
+		Entry("yrs", "2 yrs", "730d"),
+		Entry("short years", "1 y", "365d"),
+		Entry("upper case unit", "1 Hour", "1h"),
+	)
+
+	DescribeTable("limiterPeriodToPromRange with an invalid period",
+		func(period string) {
+			_, err := limiterPeriodToPromRange(period)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("unknown unit", "1 fortnight"),
+		Entry("missing unit", "10"),
+		Entry("zero count", "0 days"),
+		Entry("negative count", "-1 day"),
+		Entry("non numeric count", "one day"),
+	)
+})
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"path"
-	"path/filepath"
 	"reflect"
 	"slices"
 	"strings"
@@ -111,12 +110,28 @@ func postgresCacheConfig(r reconciler.Reconciler, _ *olsv1alpha1.OLSConfig) util
 
 // buildProviderConfigs builds the provider configurations for the OLS config from the CR spec.
 // It handles Azure OpenAI, fake providers, and standard providers with their respective models.
-func buildProviderConfigs(cr *olsv1alpha1.OLSConfig) ([]utils.ProviderConfig, error) {
+func buildProviderConfigs(cr *olsv1alpha1.OLSConfig, inferenceServices map[string][]utils.InferenceServiceEndpoint) ([]utils.ProviderConfig, error) {
 	providerConfigs := []utils.ProviderConfig{}
+	// model aliases are unique across providers, mapped to the provider/model using them
+	aliases := map[string]string{}
 	for _, provider := range cr.Spec.LLMConfig.Providers {
-		credentialPath := path.Join(utils.APIKeyMountRoot, provider.CredentialsSecretRef.Name)
+		if provider.InferenceServiceRef != nil {
+			url, models, err := inferenceServiceModels(provider, inferenceServices[provider.Name])
+			if err != nil {
+				return []utils.ProviderConfig{}, err
+			}
+			provider.URL = url
+			provider.Models = models
+		}
+		credentialPath := credentialsDir(provider)
 		modelConfigs := []utils.ModelConfig{}
 		for _, model := range provider.Models {
+			if model.Alias != "" {
+				if other, ok := aliases[model.Alias]; ok {
+					return []utils.ProviderConfig{}, fmt.Errorf("alias %q of model %s/%s is already used by model %s", model.Alias, provider.Name, model.Name, other)
+				}
+				aliases[model.Alias] = provider.Name + "/" + model.Name
+			}
 			toolBudgetRatio := model.Parameters.ToolBudgetRatio
 			if toolBudgetRatio == 0 {
 				toolBudgetRatio = 0.5
@@ -129,6 +144,18 @@ func buildProviderConfigs(cr *olsv1alpha1.OLSConfig) ([]utils.ProviderConfig, er
 					ToolBudgetRatio:      toolBudgetRatio,
 				},
 				ContextWindowSize: model.ContextWindowSize,
+				Timeout:           model.Timeout,
+				Retries:           retryConfig(model.Retries),
+				Alias:             model.Alias,
+				Deprecated:        model.Deprecated,
+			}
+			if model.Capabilities != nil {
+				modelConfig.Capabilities = &utils.ModelCapabilities{
+					ToolCalling:     utils.ModelSupportsToolCalling(&model),
+					Vision:          model.Capabilities.Vision,
+					Reasoning:       model.Capabilities.Reasoning,
+					MaxOutputTokens: model.Capabilities.MaxOutputTokens,
+				}
 			}
 			modelConfigs = append(modelConfigs, modelConfig)
 		}
@@ -147,10 +174,12 @@ func buildProviderConfigs(cr *olsv1alpha1.OLSConfig) ([]utils.ProviderConfig, er
 				},
 			}
 		case utils.GoogleVertexType, utils.GoogleVertexAnthropicType:
-			if provider.CredentialKey != "" {
-				credentialPath = path.Join(utils.APIKeyMountRoot, provider.CredentialsSecretRef.Name, provider.CredentialKey)
-			} else {
-				credentialPath = path.Join(utils.APIKeyMountRoot, provider.CredentialsSecretRef.Name, utils.DefaultCredentialKey)
+			if credentialPath != "" {
+				credentialKey := provider.CredentialKey
+				if credentialKey == "" {
+					credentialKey = utils.DefaultCredentialKey
+				}
+				credentialPath = path.Join(credentialPath, credentialKey)
 			}
 			providerConfig = utils.ProviderConfig{
 				Name:            provider.Name,
@@ -179,6 +208,26 @@ func buildProviderConfigs(cr *olsv1alpha1.OLSConfig) ([]utils.ProviderConfig, er
 					return []utils.ProviderConfig{}, fmt.Errorf("googleVertexAnthropicConfig is required for google_vertex_anthropic provider")
 				}
 			}
+		case utils.BedrockType:
+			if provider.URL == "" && provider.BedrockConfig == nil {
+				return []utils.ProviderConfig{}, fmt.Errorf("either url or bedrockConfig is required for bedrock provider")
+			}
+			providerConfig = utils.ProviderConfig{
+				Name:            provider.Name,
+				Type:            provider.Type,
+				URL:             provider.URL,
+				CredentialsPath: credentialPath,
+				Models:          modelConfigs,
+			}
+			if provider.BedrockConfig != nil {
+				providerConfig.BedrockConfig = &utils.BedrockConfig{
+					Region:               provider.BedrockConfig.Region,
+					ModelARN:             provider.BedrockConfig.ModelARN,
+					CrossRegionInference: provider.BedrockConfig.CrossRegionInference,
+					EndpointURL:          provider.BedrockConfig.EndpointURL,
+					RoleARN:              provider.BedrockConfig.RoleARN,
+				}
+			}
 		default:
 			providerConfig = utils.ProviderConfig{
 				Name:            provider.Name,
@@ -190,6 +239,27 @@ func buildProviderConfigs(cr *olsv1alpha1.OLSConfig) ([]utils.ProviderConfig, er
 			}
 		}
 
+		if provider.WorkloadIdentity != nil {
+			providerConfig.WorkloadIdentity = &utils.WorkloadIdentityConfig{
+				TokenPath:                workloadIdentityTokenPath(provider),
+				Audience:                 workloadIdentityAudience(provider),
+				ClientID:                 provider.WorkloadIdentity.ClientID,
+				TenantID:                 provider.WorkloadIdentity.TenantID,
+				WorkloadIdentityProvider: provider.WorkloadIdentity.GCPWorkloadIdentityProvider,
+				ServiceAccountEmail:      provider.WorkloadIdentity.GCPServiceAccountEmail,
+			}
+		}
+		for _, header := range provider.Headers {
+			headerConfig := utils.ProviderHeaderConfig{Name: header.Name}
+			if header.ValueFrom.Type == olsv1alpha1.ProviderHeaderSourceTypeSecret && header.ValueFrom.SecretRef != nil {
+				headerConfig.ValuePath = path.Join(utils.LLMProviderHeadersMountRoot, header.ValueFrom.SecretRef.Name, utils.MCPSECRETDATAPATH)
+			} else {
+				headerConfig.Value = header.ValueFrom.Value
+			}
+			providerConfig.Headers = append(providerConfig.Headers, headerConfig)
+		}
+		providerConfig.Timeout = provider.Timeout
+		providerConfig.Retries = retryConfig(provider.Retries)
 		if provider.Type == utils.FakeProviderType {
 			providerConfig.FakeProviderConfig = &utils.FakeProviderConfig{
 				URL:         "http://example.com",
@@ -205,6 +275,89 @@ func buildProviderConfigs(cr *olsv1alpha1.OLSConfig) ([]utils.ProviderConfig, er
 	return providerConfigs, nil
 }
 
+// inferenceServiceModels returns the provider URL and the models served by the InferenceServices of a provider.
+// A provider referencing an InferenceService by name serves its models from the InferenceService URL and
+// defaults to a single model named after it. With a selector, every InferenceService becomes a model with
+// its own URL, taking the parameters of the spec model of the same name.
+func inferenceServiceModels(provider olsv1alpha1.ProviderSpec, endpoints []utils.InferenceServiceEndpoint) (string, []olsv1alpha1.ModelSpec, error) {
+	if len(endpoints) == 0 {
+		return "", nil, fmt.Errorf("InferenceServices of provider %s are not resolved", provider.Name)
+	}
+	if provider.InferenceServiceRef.Name != "" {
+		if len(provider.Models) > 0 {
+			return endpoints[0].URL, provider.Models, nil
+		}
+		return endpoints[0].URL, []olsv1alpha1.ModelSpec{{Name: endpoints[0].Name}}, nil
+	}
+
+	models := make([]olsv1alpha1.ModelSpec, 0, len(endpoints))
+	seen := map[string]utils.InferenceServiceEndpoint{}
+	for _, endpoint := range endpoints {
+		if other, ok := seen[endpoint.Name]; ok {
+			return "", nil, fmt.Errorf("InferenceServices %s/%s and %s/%s of provider %s serve the same model name",
+				other.Namespace, other.Name, endpoint.Namespace, endpoint.Name, provider.Name)
+		}
+		seen[endpoint.Name] = endpoint
+		model := olsv1alpha1.ModelSpec{Name: endpoint.Name}
+		for _, specModel := range provider.Models {
+			if specModel.Name == endpoint.Name {
+				model = specModel
+				break
+			}
+		}
+		model.URL = endpoint.URL
+		models = append(models, model)
+	}
+	return "", models, nil
+}
+
+// retryConfig renders the retry policy of a provider or a model, nil when unset
+func retryConfig(retries *olsv1alpha1.RetryPolicySpec) *utils.RetryConfig {
+	if retries == nil {
+		return nil
+	}
+	return &utils.RetryConfig{
+		MaxRetries:        retries.MaxRetries,
+		BackoffSeconds:    retries.BackoffSeconds,
+		MaxBackoffSeconds: retries.MaxBackoffSeconds,
+	}
+}
+
+// credentialsDir returns the directory the credentials of a provider are mounted in:
+// the credentials secret at APIKeyMountRoot/<secret>, a Secrets Store CSI credentials source
+// at CredentialsSourceMountRoot/<provider>, and none for workload identity providers
+func credentialsDir(provider olsv1alpha1.ProviderSpec) string {
+	switch {
+	case provider.CredentialsSource != nil:
+		return path.Join(utils.CredentialsSourceMountRoot, provider.Name)
+	case provider.CredentialsSecretRef.Name != "":
+		return path.Join(utils.APIKeyMountRoot, provider.CredentialsSecretRef.Name)
+	default:
+		return ""
+	}
+}
+
+// workloadIdentityTokenPath returns where the projected ServiceAccount token of a workload identity provider is mounted
+func workloadIdentityTokenPath(provider olsv1alpha1.ProviderSpec) string {
+	return path.Join(utils.WorkloadIdentityMountRoot, provider.Name, utils.WorkloadIdentityTokenFileName)
+}
+
+// workloadIdentityAudience returns the audience of the projected token of a workload identity provider,
+// defaulting to the one expected by the token exchange of the provider's cloud
+func workloadIdentityAudience(provider olsv1alpha1.ProviderSpec) string {
+	if provider.WorkloadIdentity.Audience != "" {
+		return provider.WorkloadIdentity.Audience
+	}
+	switch provider.Type {
+	case utils.AzureOpenAIType:
+		return utils.AzureWorkloadIdentityAudience
+	case utils.GoogleVertexType, utils.GoogleVertexAnthropicType:
+		return utils.GCPWorkloadIdentityAudiencePrefix + provider.WorkloadIdentity.GCPWorkloadIdentityProvider
+	default:
+		return utils.AWSWorkloadIdentityAudience
+	}
+}
+
 // buildToolFilteringConfig builds the tool filtering configuration if enabled and MCP servers exist.
 // Returns nil if tool filtering should not be enabled.
 func buildToolFilteringConfig(cr *olsv1alpha1.OLSConfig, mcpServers []utils.MCPServerConfig, r reconciler.Reconciler) *utils.ToolFilteringConfig {
@@ -241,11 +394,36 @@ func buildToolFilteringConfig(cr *olsv1alpha1.OLSConfig, mcpServers []utils.MCPS
 		threshold = 0.01
 	}
 
+	var embeddingsModel *utils.EmbeddingModelConfig
+	if cfg.EmbeddingModel != nil {
+		embeddingsModel = buildEmbeddingModelConfig(cfg.EmbeddingModel, cfg.EmbeddingModel.Path)
+	}
+
 	return &utils.ToolFilteringConfig{
-		Alpha:     alpha,
-		TopK:      topK,
-		Threshold: threshold,
+		Alpha:           alpha,
+		TopK:            topK,
+		Threshold:       threshold,
+		EmbeddingsModel: embeddingsModel,
+	}
+}
+
+// buildEmbeddingModelConfig renders an embedding model, localPath being where a local model is found in the
+// app server container. The API key of a remote endpoint is read from its mounted secret.
+func buildEmbeddingModelConfig(model *olsv1alpha1.EmbeddingModelSpec, localPath string) *utils.EmbeddingModelConfig {
+	if model == nil {
+		return nil
+	}
+	config := &utils.EmbeddingModelConfig{Dimension: model.Dimension}
+	if model.Endpoint == nil {
+		config.Path = localPath
+		return config
+	}
+	config.URL = model.Endpoint.URL
+	config.Model = model.Endpoint.Model
+	if model.Endpoint.CredentialsSecretRef != nil && model.Endpoint.CredentialsSecretRef.Name != "" {
+		config.CredentialsPath = path.Join(utils.EmbeddingCredentialsMountRoot, model.Endpoint.CredentialsSecretRef.Name, utils.DefaultCredentialKey)
 	}
+	return config
 }
 
 // buildOLSConfig builds the main OLS configuration including conversation cache, TLS, proxy,
@@ -287,10 +465,17 @@ func buildOLSConfig(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha
 	referenceIndexes := []utils.ReferenceIndex{}
 	for i, index := range cr.Spec.OLSConfig.RAG {
 		referenceIndex := utils.ReferenceIndex{
-			ProductDocsIndexPath: filepath.Join(utils.RAGVolumeMountPath, fmt.Sprintf("rag-%d", i)),
+			ProductDocsIndexPath: ragIndexPath(i, index),
 			ProductDocsIndexId:   index.IndexID,
-			ProductDocsOrigin:    index.Image,
+			ProductDocsOrigin:    ragOrigin(index),
+			Weight:               index.Weight,
+			MaxChunks:            index.MaxChunks,
+			PreferredTopics:      index.PreferredTopics,
 		}
+		// A local embedding model of a RAG source is copied next to its index by the init container,
+		// or read inside the source mounted in place
+		localModelPath := ragEmbeddingModelPath(i, index)
+		referenceIndex.EmbeddingsModel = buildEmbeddingModelConfig(index.EmbeddingModel, localModelPath)
 		referenceIndexes = append(referenceIndexes, referenceIndex)
 	}
 
@@ -317,7 +502,7 @@ func buildOLSConfig(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha
 	if len(referenceIndexes) > 0 {
 		olsConfig.ReferenceContent = &utils.ReferenceContent{
 			Indexes:             referenceIndexes,
-			EmbeddingsModelPath: "/app-root/embeddings_model",
+			EmbeddingsModelPath: utils.AppServerEmbeddingModelPath,
 		}
 	}
 
@@ -341,15 +526,112 @@ func buildOLSConfig(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha
 		Ciphers:       utiltls.TLSCiphers(tlsProfileSpec),
 	}
 
+	routing, err := buildRoutingConfig(cr)
+	if err != nil {
+		return utils.OLSConfig{}, err
+	}
+	olsConfig.Routing = routing
+
 	olsConfig.Audit = buildServiceAuditConfig(cr, r.GetNamespace())
 
 	if !cr.Spec.OLSConfig.ByokRAGOnly {
-		olsConfig.SolrHybrid = buildSolrHybridSettings(r.GetNamespace())
+		olsConfig.SolrHybrid = buildSolrHybridSettings(cr, r.GetNamespace())
 	}
 
 	return olsConfig, nil
 }
 
+// failoverTriggers maps the CR failover triggers to the error classes of the service
+var failoverTriggers = map[olsv1alpha1.FailoverTrigger]string{
+	olsv1alpha1.FailoverOnServerError:   "server_error",
+	olsv1alpha1.FailoverOnTimeout:       "timeout",
+	olsv1alpha1.FailoverOnQuotaExceeded: "quota_exceeded",
+}
+
+// buildRoutingConfig maps spec.ols.routing into olsconfig.yaml. It returns an error when a
+// target references a provider or model that is not configured in spec.llm.providers.
+func buildRoutingConfig(cr *olsv1alpha1.OLSConfig) (*utils.RoutingConfig, error) {
+	routing := cr.Spec.OLSConfig.Routing
+	if routing == nil {
+		return nil, nil
+	}
+
+	target := func(field string, t olsv1alpha1.RoutingTarget) (utils.RoutingTargetConfig, error) {
+		if err := validateRoutingTarget(cr, t); err != nil {
+			return utils.RoutingTargetConfig{}, fmt.Errorf("spec.ols.routing.%s: %w", field, err)
+		}
+		return utils.RoutingTargetConfig{Provider: t.Provider, Model: t.Model}, nil
+	}
+
+	routingConfig := &utils.RoutingConfig{}
+	if routing.Failover != nil {
+		failover := &utils.FailoverConfig{CooldownSeconds: routing.Failover.CooldownSeconds}
+		for i, t := range routing.Failover.Chain {
+			chainTarget, err := target(fmt.Sprintf("failover.chain[%d]", i), t)
+			if err != nil {
+				return nil, err
+			}
+			failover.Chain = append(failover.Chain, chainTarget)
+		}
+		triggers := routing.Failover.On
+		if len(triggers) == 0 {
+			triggers = []olsv1alpha1.FailoverTrigger{olsv1alpha1.FailoverOnServerError, olsv1alpha1.FailoverOnTimeout, olsv1alpha1.FailoverOnQuotaExceeded}
+		}
+		for _, trigger := range triggers {
+			failover.On = append(failover.On, failoverTriggers[trigger])
+		}
+		routingConfig.Failover = failover
+	}
+	for i, t := range routing.WeightedTargets {
+		weighted, err := target(fmt.Sprintf("weightedTargets[%d]", i), t.RoutingTarget)
+		if err != nil {
+			return nil, err
+		}
+		routingConfig.WeightedTargets = append(routingConfig.WeightedTargets, utils.WeightedTargetConfig{
+			Provider: weighted.Provider,
+			Model:    weighted.Model,
+			Weight:   t.Weight,
+		})
+	}
+	for _, rule := range routing.Rules {
+		ruleTarget, err := target(fmt.Sprintf("rules[%s].target", rule.Name), rule.Target)
+		if err != nil {
+			return nil, err
+		}
+		routingConfig.Rules = append(routingConfig.Rules, utils.RoutingRuleConfig{
+			Name: rule.Name,
+			Match: utils.RoutingMatchConfig{
+				HasAttachments: rule.Match.HasAttachments,
+				MinQueryLength: rule.Match.MinQueryLength,
+				MediaTypes:     rule.Match.MediaTypes,
+				UserGroups:     rule.Match.UserGroups,
+			},
+			Target: ruleTarget,
+		})
+	}
+	return routingConfig, nil
+}
+
+// validateRoutingTarget checks that a routing target names a configured provider and one of its models
+func validateRoutingTarget(cr *olsv1alpha1.OLSConfig, target olsv1alpha1.RoutingTarget) error {
+	for _, provider := range cr.Spec.LLMConfig.Providers {
+		if provider.Name != target.Provider {
+			continue
+		}
+		// Models discovered from InferenceServices are only known once they are resolved
+		if ref := provider.InferenceServiceRef; ref != nil && (ref.Selector != nil || (len(provider.Models) == 0 && ref.Name == target.Model)) {
+			return nil
+		}
+		for _, model := range provider.Models {
+			if model.Name == target.Model || model.Alias == target.Model {
+				return nil
+			}
+		}
+		return fmt.Errorf("model %q is not configured for provider %q", target.Model, target.Provider)
+	}
+	return fmt.Errorf("provider %q is not configured in spec.llm.providers", target.Provider)
+}
+
 // buildServiceAuditConfig maps OLS service audit settings into olsconfig.yaml.
 // spec.audit on the CR is collector-only; stdout audit uses spec.ols.auditEventsEnabled.
 func buildServiceAuditConfig(cr *olsv1alpha1.OLSConfig, namespace string) *utils.AuditYAMLConfig {
@@ -371,15 +653,67 @@ func buildServiceAuditConfig(cr *olsv1alpha1.OLSConfig, namespace string) *utils
 	}
 }
 
-func buildSolrHybridSettings(namespace string) *utils.SolrHybridSettings {
-	return &utils.SolrHybridSettings{
-		SolrHTTPBase:             utils.RHOKPServiceURL(namespace),
+// buildSolrHybridSettings builds the OKP Solr hybrid retrieval settings. Fields left unset in
+// spec.ols.okp (zero values) use the operator defaults.
+func buildSolrHybridSettings(cr *olsv1alpha1.OLSConfig, namespace string) *utils.SolrHybridSettings {
+	settings := &utils.SolrHybridSettings{
+		SolrHTTPBase:             utils.OKPServiceURL(cr, namespace),
 		MaxResults:               utils.SolrHybridMaxResultsDefault,
 		HybridVectorBoost:        utils.SolrHybridVectorBoostDefault,
 		HybridPoolDocs:           utils.SolrHybridPoolDocsDefault,
 		HybridScoreThreshold:     utils.SolrHybridScoreThresholdDefault,
 		HybridSolrTimeoutSeconds: utils.SolrHybridSolrTimeoutSecondsDefault,
 	}
+	if utils.RHOKPAccessKeyRotation(cr) != nil {
+		settings.SolrCredentialsPath = path.Join(utils.OKPCredentialsMountRoot, utils.RHOOKPAccessKeySecretName)
+	}
+	okp := cr.Spec.OLSConfig.OKP
+	if okp == nil {
+		return settings
+	}
+	if external := okp.External; external != nil && external.CredentialsSecretRef != nil && external.CredentialsSecretRef.Name != "" {
+		settings.SolrCredentialsPath = path.Join(utils.OKPCredentialsMountRoot, external.CredentialsSecretRef.Name)
+	}
+	if okp.MaxResults > 0 {
+		settings.MaxResults = okp.MaxResults
+	}
+	if okp.HybridVectorBoost > 0 {
+		settings.HybridVectorBoost = okp.HybridVectorBoost
+	}
+	if okp.HybridPoolDocs > 0 {
+		settings.HybridPoolDocs = okp.HybridPoolDocs
+	}
+	if okp.HybridScoreThreshold > 0 {
+		settings.HybridScoreThreshold = okp.HybridScoreThreshold
+	}
+	if okp.SolrTimeoutSeconds > 0 {
+		settings.HybridSolrTimeoutSeconds = float64(okp.SolrTimeoutSeconds)
+	}
+	settings.ChunkFilterQuery = okp.ChunkFilterQuery
+	settings.Weight = okp.Weight
+	for _, scope := range okp.DocumentationScopes {
+		settings.ChunkFilterScopes = append(settings.ChunkFilterScopes, utils.SolrChunkFilterScope{
+			Name:             scope.Name,
+			ChunkFilterQuery: documentationScopeFilterQuery(scope),
+		})
+	}
+	return settings
+}
+
+// documentationScopeFilterQuery returns the Solr filter query of a documentation scope: its own
+// chunkFilterQuery, or the conjunction of its version and product.
+func documentationScopeFilterQuery(scope olsv1alpha1.OKPDocumentationScope) string {
+	if scope.ChunkFilterQuery != "" {
+		return scope.ChunkFilterQuery
+	}
+	clauses := []string{}
+	if scope.Version != "" {
+		clauses = append(clauses, utils.SolrProductVersionField+":"+scope.Version)
+	}
+	if scope.Product != "" {
+		clauses = append(clauses, utils.SolrProductField+":"+scope.Product)
+	}
+	return strings.Join(clauses, " AND ")
 }
 
 // generateMCPServerConfigs builds MCP (Model Context Protocol) server configurations.
@@ -484,8 +818,18 @@ func generateMCPServerConfigs(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig
 }
 
 func GenerateOLSConfigMap(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) (*corev1.ConfigMap, error) {
+	// Resolve the endpoints of KServe InferenceServices referenced by providers
+	inferenceServices := map[string][]utils.InferenceServiceEndpoint{}
+	for _, provider := range cr.Spec.LLMConfig.Providers {
+		endpoints, err := utils.ResolveInferenceServices(r, ctx, provider)
+		if err != nil {
+			return nil, err
+		}
+		inferenceServices[provider.Name] = endpoints
+	}
+
 	// Build provider configurations (Azure, fake providers, standard providers)
-	providerConfigs, err := buildProviderConfigs(cr)
+	providerConfigs, err := buildProviderConfigs(cr, inferenceServices)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build provider configurations: %w", err)
 	}
@@ -546,8 +890,8 @@ func GenerateOLSConfigMap(r reconciler.Reconciler, ctx context.Context, cr *olsv
 		))
 	}
 
-	// Trust the standalone RHOKP service-ca cert when OKP is enabled
-	if !cr.Spec.OLSConfig.ByokRAGOnly {
+	// Trust the standalone RHOKP service-ca cert, or the CA of the external knowledge portal, when OKP is enabled
+	if utils.IsOKPCATrusted(cr) {
 		olsConfig.ExtraCAs = append(olsConfig.ExtraCAs, path.Join(
 			utils.OLSAppCertsMountRoot,
 			utils.AppRHOKPCACertDir,
@@ -555,6 +899,15 @@ func GenerateOLSConfigMap(r reconciler.Reconciler, ctx context.Context, cr *olsv
 		))
 	}
 
+	// Trust the service CA signing the serving certificates of KServe InferenceServices
+	if utils.HasInferenceServiceProviders(cr) {
+		olsConfig.ExtraCAs = append(olsConfig.ExtraCAs, path.Join(
+			utils.OLSAppCertsMountRoot,
+			utils.AppInferenceServiceCACertDir,
+			utils.AppInferenceServiceCACertFile,
+		))
+	}
+
 	// Append user-provided additional CA certificates if configured
 	if cr.Spec.OLSConfig.AdditionalCAConfigMapRef != nil {
 		extraCAs, err := addAdditionalCAFileNames(r, ctx, cr.Spec.OLSConfig.AdditionalCAConfigMapRef, utils.UserCACertDir)
@@ -770,7 +1123,7 @@ func GenerateService(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (*corev
 					TargetPort: intstr.Parse("https"),
 				},
 			},
-			Selector: utils.GenerateAppServerSelectorLabels(),
+			Selector: utils.GenerateAppServerServiceSelectorLabels(),
 		},
 	}
 	if err := controllerutil.SetControllerReference(cr, &service, r.GetScheme()); err != nil {
@@ -885,6 +1238,10 @@ func GeneratePrometheusRule(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig)
 		},
 	}
 
+	if alerts := generateAlertingRuleGroup(r, cr); alerts != nil {
+		rule.Spec.Groups = append(rule.Spec.Groups, *alerts)
+	}
+
 	if err := controllerutil.SetControllerReference(cr, &rule, r.GetScheme()); err != nil {
 		return nil, err
 	}
@@ -901,7 +1258,7 @@ func GenerateAppServerNetworkPolicy(r reconciler.Reconciler, cr *olsv1alpha1.OLS
 		},
 		Spec: networkingv1.NetworkPolicySpec{
 			PodSelector: metav1.LabelSelector{
-				MatchLabels: utils.GenerateAppServerSelectorLabels(),
+				MatchLabels: utils.GenerateAppServerServiceSelectorLabels(),
 			},
 			Ingress: []networkingv1.NetworkPolicyIngressRule{
 				{
@@ -1052,9 +1409,12 @@ func getQueryFilters(cr *olsv1alpha1.OLSConfig) []utils.QueryFilters {
 // Each entry copies the cluster service-ca PEM into a dedicated Secret so that
 // consumers (app-server, sandbox) can mount it independently.
 type clientCAConfig struct {
-	SecretName  string
-	DataKey     string
-	Enabled     func(*olsv1alpha1.OLSConfig) bool
+	SecretName string
+	DataKey    string
+	Enabled    func(*olsv1alpha1.OLSConfig) bool
+	// Source overrides the ConfigMap, the key and the error message of the copied PEM,
+	// the cluster service-ca ConfigMap is used when nil
+	Source      func(*olsv1alpha1.OLSConfig) (configMap string, key string, errSource string)
 	ErrSource   string
 	ErrOwnerRef string
 	ErrCreate   string
@@ -1090,9 +1450,16 @@ var clientCASecrets = []clientCAConfig{
 		ErrDelete:   utils.ErrDeleteAgenticMCPCASecret,
 	},
 	{
-		SecretName:  utils.AgenticRHOKPCASecretName,
-		DataKey:     utils.AgenticRHOKPCASecretDataKey,
-		Enabled:     func(cr *olsv1alpha1.OLSConfig) bool { return !cr.Spec.OLSConfig.ByokRAGOnly },
+		SecretName: utils.AgenticRHOKPCASecretName,
+		DataKey:    utils.AgenticRHOKPCASecretDataKey,
+		Enabled:    utils.IsOKPCATrusted,
+		// An external knowledge portal is trusted through its own CA instead of the cluster service-ca
+		Source: func(cr *olsv1alpha1.OLSConfig) (string, string, string) {
+			if external := utils.OKPExternal(cr); external != nil {
+				return external.CAConfigMapRef.Name, utils.OKPExternalCACertKey, utils.ErrGetOKPExternalCAConfigMap
+			}
+			return utils.OLSCAConfigMap, utils.AppOtelCollectorCACertFile, utils.ErrGetAgenticRHOKPCASourceConfigMap
+		},
 		ErrSource:   utils.ErrGetAgenticRHOKPCASourceConfigMap,
 		ErrOwnerRef: utils.ErrSetAgenticRHOKPCASecretOwnerRef,
 		ErrCreate:   utils.ErrCreateAgenticRHOKPCASecret,
@@ -1107,14 +1474,18 @@ func generateClientCA(r reconciler.Reconciler, ctx context.Context, cr *olsv1alp
 		return nil, nil
 	}
 
+	sourceName, sourceKey, errSource := utils.OLSCAConfigMap, utils.AppOtelCollectorCACertFile, cfg.ErrSource
+	if cfg.Source != nil {
+		sourceName, sourceKey, errSource = cfg.Source(cr)
+	}
 	caCM := &corev1.ConfigMap{}
-	if err := r.Get(ctx, client.ObjectKey{Name: utils.OLSCAConfigMap, Namespace: r.GetNamespace()}, caCM); err != nil {
-		return nil, fmt.Errorf("%s: %w", cfg.ErrSource, err)
+	if err := r.Get(ctx, client.ObjectKey{Name: sourceName, Namespace: r.GetNamespace()}, caCM); err != nil {
+		return nil, fmt.Errorf("%s: %w", errSource, err)
 	}
-	caPEM, ok := caCM.Data[utils.AppOtelCollectorCACertFile]
+	caPEM, ok := caCM.Data[sourceKey]
 	if !ok || caPEM == "" {
 		return nil, fmt.Errorf("%s: key %q missing or empty in ConfigMap %s",
-			cfg.ErrSource, utils.AppOtelCollectorCACertFile, utils.OLSCAConfigMap)
+			errSource, sourceKey, sourceName)
 	}
 
 	secret := &corev1.Secret{
@@ -117,7 +117,7 @@ var _ = Describe("App server assets", func() {
 						MinTLSVersion: string(configv1.TLSProfiles[configv1.TLSProfileIntermediateType].MinTLSVersion),
 						Ciphers:       configv1.TLSProfiles[configv1.TLSProfileIntermediateType].Ciphers,
 					},
-					SolrHybrid: buildSolrHybridSettings(utils.OLSNamespaceDefault),
+					SolrHybrid: buildSolrHybridSettings(cr, utils.OLSNamespaceDefault),
 					UserDataCollection: utils.UserDataCollectionConfig{
 						FeedbackDisabled:    false,
 						FeedbackStorage:     "/app-root/ols-user-data/feedback",
@@ -502,6 +502,179 @@ var _ = Describe("App server assets", func() {
 			}))))
 		})
 
+		It("should generate configmap with native bedrock provider config", func() {
+			cr := utils.WithBedrockProvider(cr)
+			cr.Spec.LLMConfig.Providers[0].URL = ""
+			cr.Spec.LLMConfig.Providers[0].BedrockConfig = &olsv1alpha1.BedrockConfig{
+				Region:               "eu-central-1",
+				CrossRegionInference: true,
+				EndpointURL:          "https://vpce-0123.bedrock-runtime.eu-central-1.vpce.amazonaws.com",
+				RoleARN:              "arn:aws:iam::123456789012:role/Lightspeed",
+			}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var olsConfigMap map[string]interface{}
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &olsConfigMap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(olsConfigMap).To(HaveKeyWithValue("llm_providers", ContainElement(MatchAllKeys(Keys{
+				"name":             Equal("bedrock"),
+				"type":             Equal("bedrock"),
+				"credentials_path": Equal("/etc/apikeys/test-secret"),
+				"models":           HaveLen(1),
+				"bedrock_config": MatchAllKeys(Keys{
+					"region":                 Equal("eu-central-1"),
+					"cross_region_inference": BeTrue(),
+					"endpoint_url":           Equal("https://vpce-0123.bedrock-runtime.eu-central-1.vpce.amazonaws.com"),
+					"role_arn":               Equal("arn:aws:iam::123456789012:role/Lightspeed"),
+				}),
+			}))))
+		})
+
+		It("should return error when neither url nor bedrockConfig is specified for bedrock provider", func() {
+			cr := utils.WithBedrockProvider(cr)
+			cr.Spec.LLMConfig.Providers[0].URL = ""
+			_, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("either url or bedrockConfig is required for bedrock provider"))
+		})
+
+		It("should generate configmap with workload identity providers", func() {
+			cr.Spec.LLMConfig.Providers = []olsv1alpha1.ProviderSpec{
+				{
+					Name:                "azure",
+					Type:                utils.AzureOpenAIType,
+					URL:                 "https://example.openai.azure.com",
+					AzureDeploymentName: "gpt-4o",
+					Models:              []olsv1alpha1.ModelSpec{{Name: "gpt-4o"}},
+					WorkloadIdentity: &olsv1alpha1.WorkloadIdentitySpec{
+						ClientID: "client",
+						TenantID: "tenant",
+					},
+				},
+				{
+					Name:               "vertex",
+					Type:               utils.GoogleVertexType,
+					Models:             []olsv1alpha1.ModelSpec{{Name: "gemini-2.5-pro"}},
+					GoogleVertexConfig: &olsv1alpha1.VertexConfig{ProjectID: "project", Location: "us-central1"},
+					WorkloadIdentity: &olsv1alpha1.WorkloadIdentitySpec{
+						GCPWorkloadIdentityProvider: "projects/123/locations/global/workloadIdentityPools/pool/providers/ocp",
+						GCPServiceAccountEmail:      "lightspeed@project.iam.gserviceaccount.com",
+					},
+				},
+			}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var olsConfigMap map[string]interface{}
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &olsConfigMap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(olsConfigMap["llm_providers"]).To(ConsistOf(
+				SatisfyAll(Not(HaveKey("credentials_path")), MatchKeys(Options(IgnoreExtras), Keys{
+					"name": Equal("azure"),
+					"azure_openai_config": MatchAllKeys(Keys{
+						"url":             Equal("https://example.openai.azure.com"),
+						"deployment_name": Equal("gpt-4o"),
+					}),
+					"workload_identity": MatchAllKeys(Keys{
+						"token_path": Equal("/var/run/secrets/workload-identity/azure/token"),
+						"audience":   Equal("api://AzureADTokenExchange"),
+						"client_id":  Equal("client"),
+						"tenant_id":  Equal("tenant"),
+					}),
+				})),
+				SatisfyAll(Not(HaveKey("credentials_path")), MatchKeys(Options(IgnoreExtras), Keys{
+					"name": Equal("vertex"),
+					"workload_identity": MatchAllKeys(Keys{
+						"token_path":                 Equal("/var/run/secrets/workload-identity/vertex/token"),
+						"audience":                   Equal("//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/ocp"),
+						"workload_identity_provider": Equal("projects/123/locations/global/workloadIdentityPools/pool/providers/ocp"),
+						"service_account_email":      Equal("lightspeed@project.iam.gserviceaccount.com"),
+					}),
+				})),
+			))
+		})
+
+		It("should generate configmap with Secrets Store CSI credentials sources", func() {
+			cr.Spec.LLMConfig.Providers = []olsv1alpha1.ProviderSpec{
+				{
+					Name:              "openai",
+					Type:              "openai",
+					URL:               "https://api.openai.com/v1",
+					Models:            []olsv1alpha1.ModelSpec{{Name: "gpt-4o"}},
+					CredentialsSource: &olsv1alpha1.ProviderCredentialsSource{SecretProviderClass: "vault-openai"},
+				},
+				{
+					Name:               "vertex",
+					Type:               utils.GoogleVertexType,
+					Models:             []olsv1alpha1.ModelSpec{{Name: "gemini-2.5-pro"}},
+					GoogleVertexConfig: &olsv1alpha1.VertexConfig{ProjectID: "project", Location: "us-central1"},
+					CredentialsSource:  &olsv1alpha1.ProviderCredentialsSource{SecretProviderClass: "gcp-sa"},
+					CredentialKey:      "sa.json",
+				},
+			}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var olsConfigMap map[string]interface{}
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &olsConfigMap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(olsConfigMap["llm_providers"]).To(ConsistOf(
+				MatchKeys(Options(IgnoreExtras), Keys{
+					"name":             Equal("openai"),
+					"credentials_path": Equal("/etc/apikeys/_csi/openai"),
+				}),
+				MatchKeys(Options(IgnoreExtras), Keys{
+					"name":             Equal("vertex"),
+					"credentials_path": Equal("/etc/apikeys/_csi/vertex/sa.json"),
+				}),
+			))
+		})
+
+		It("should generate configmap with provider headers, timeouts and retries", func() {
+			maxRetries := int32(5)
+			noRetries := int32(0)
+			cr.Spec.LLMConfig.Providers[0].Headers = []olsv1alpha1.ProviderHeader{
+				{
+					Name:      "X-Tenant-ID",
+					ValueFrom: olsv1alpha1.ProviderHeaderValueSource{Type: olsv1alpha1.ProviderHeaderSourceTypeValue, Value: "tenant-a"},
+				},
+				{
+					Name: "X-Gateway-Key",
+					ValueFrom: olsv1alpha1.ProviderHeaderValueSource{
+						Type:      olsv1alpha1.ProviderHeaderSourceTypeSecret,
+						SecretRef: &corev1.LocalObjectReference{Name: "gateway-key"},
+					},
+				},
+			}
+			cr.Spec.LLMConfig.Providers[0].Timeout = 600
+			cr.Spec.LLMConfig.Providers[0].Retries = &olsv1alpha1.RetryPolicySpec{MaxRetries: &maxRetries, BackoffSeconds: 2, MaxBackoffSeconds: 30}
+			cr.Spec.LLMConfig.Providers[0].Models[0].Timeout = 1800
+			cr.Spec.LLMConfig.Providers[0].Models[0].Retries = &olsv1alpha1.RetryPolicySpec{MaxRetries: &noRetries}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var olsConfigMap map[string]interface{}
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &olsConfigMap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(olsConfigMap["llm_providers"]).To(ConsistOf(MatchKeys(Options(IgnoreExtras), Keys{
+				"headers": Equal([]interface{}{
+					map[string]interface{}{"name": "X-Tenant-ID", "value": "tenant-a"},
+					map[string]interface{}{"name": "X-Gateway-Key", "value_path": "/etc/llm/headers/gateway-key/header"},
+				}),
+				"timeout": BeNumerically("==", 600),
+				"retries": MatchAllKeys(Keys{
+					"max_retries":         BeNumerically("==", 5),
+					"backoff_seconds":     BeNumerically("==", 2),
+					"max_backoff_seconds": BeNumerically("==", 30),
+				}),
+				"models": ConsistOf(MatchKeys(Options(IgnoreExtras), Keys{
+					"timeout": BeNumerically("==", 1800),
+					"retries": MatchAllKeys(Keys{"max_retries": BeNumerically("==", 0)}),
+				})),
+			})))
+		})
+
 		It("should generate configmap with googleVertex provider", func() {
 			cr := utils.WithGoogleVertexProvider(cr)
 			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
@@ -556,6 +729,200 @@ var _ = Describe("App server assets", func() {
 			Expect(err.Error()).To(ContainSubstring("googleVertexAnthropicConfig is required for google_vertex_anthropic provider"))
 		})
 
+		It("should generate configmap with model capabilities, aliases and deprecation", func() {
+			cr.Spec.LLMConfig.Providers[0].Models = []olsv1alpha1.ModelSpec{
+				{
+					Name:  "granite-3.3",
+					Alias: "prod-default",
+					Capabilities: &olsv1alpha1.ModelCapabilitiesSpec{
+						Vision:          true,
+						MaxOutputTokens: 8192,
+					},
+				},
+				{
+					Name:         "granite-3.1",
+					Deprecated:   true,
+					Capabilities: &olsv1alpha1.ModelCapabilitiesSpec{ToolCalling: utils.BoolPtr(false), Reasoning: true},
+				},
+				{Name: "testModel"},
+			}
+			cr.Spec.OLSConfig.Routing = &olsv1alpha1.RoutingSpec{
+				Failover: &olsv1alpha1.FailoverSpec{
+					Chain: []olsv1alpha1.RoutingTarget{{Provider: "testProvider", Model: "prod-default"}},
+				},
+			}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var olsConfigMap map[string]interface{}
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &olsConfigMap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(olsConfigMap["llm_providers"]).To(ConsistOf(MatchKeys(Options(IgnoreExtras), Keys{
+				"models": ConsistOf(
+					MatchKeys(Options(IgnoreExtras), Keys{
+						"name":  Equal("granite-3.3"),
+						"alias": Equal("prod-default"),
+						"capabilities": Equal(map[string]interface{}{
+							"tool_calling": true, "vision": true, "reasoning": false, "max_output_tokens": float64(8192),
+						}),
+					}),
+					MatchKeys(Options(IgnoreExtras), Keys{
+						"name":       Equal("granite-3.1"),
+						"deprecated": BeTrue(),
+						"capabilities": Equal(map[string]interface{}{
+							"tool_calling": false, "vision": false, "reasoning": true,
+						}),
+					}),
+					SatisfyAll(Not(HaveKey("capabilities")), Not(HaveKey("alias")), HaveKeyWithValue("name", "testModel")),
+				),
+			})))
+		})
+
+		It("should return error when a model alias is used twice", func() {
+			cr.Spec.LLMConfig.Providers[0].Models[0].Alias = "prod-default"
+			cr.Spec.LLMConfig.Providers = append(cr.Spec.LLMConfig.Providers, olsv1alpha1.ProviderSpec{
+				Name:                 "secondary",
+				Type:                 "openai",
+				Models:               []olsv1alpha1.ModelSpec{{Name: "gpt-4o", Alias: "prod-default"}},
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: "test-secret"},
+			})
+			_, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).To(MatchError(ContainSubstring(`alias "prod-default" of model secondary/gpt-4o is already used by model testProvider/testModel`)))
+		})
+
+		It("should generate configmap with query routing", func() {
+			cr.Spec.LLMConfig.Providers = append(cr.Spec.LLMConfig.Providers, olsv1alpha1.ProviderSpec{
+				Name:                 "secondary",
+				Type:                 "openai",
+				Models:               []olsv1alpha1.ModelSpec{{Name: "gpt-4o"}, {Name: "gpt-4o-mini"}},
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: "test-secret"},
+			})
+			cr.Spec.OLSConfig.Routing = &olsv1alpha1.RoutingSpec{
+				Failover: &olsv1alpha1.FailoverSpec{
+					Chain:           []olsv1alpha1.RoutingTarget{{Provider: "secondary", Model: "gpt-4o"}},
+					CooldownSeconds: 30,
+				},
+				WeightedTargets: []olsv1alpha1.WeightedTarget{
+					{RoutingTarget: olsv1alpha1.RoutingTarget{Provider: "testProvider", Model: "testModel"}, Weight: 90},
+					{RoutingTarget: olsv1alpha1.RoutingTarget{Provider: "secondary", Model: "gpt-4o"}, Weight: 10},
+				},
+				Rules: []olsv1alpha1.RoutingRule{{
+					Name:   "attachments",
+					Match:  olsv1alpha1.RoutingMatch{HasAttachments: utils.BoolPtr(true), UserGroups: []string{"sre"}},
+					Target: olsv1alpha1.RoutingTarget{Provider: "secondary", Model: "gpt-4o-mini"},
+				}},
+			}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var olsConfigMap map[string]interface{}
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &olsConfigMap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(olsConfigMap).To(HaveKeyWithValue("ols_config", HaveKeyWithValue("routing", MatchAllKeys(Keys{
+				"failover": MatchAllKeys(Keys{
+					"chain":            ConsistOf(map[string]interface{}{"provider": "secondary", "model": "gpt-4o"}),
+					"on":               Equal([]interface{}{"server_error", "timeout", "quota_exceeded"}),
+					"cooldown_seconds": BeNumerically("==", 30),
+				}),
+				"weighted_targets": HaveLen(2),
+				"rules": ConsistOf(MatchAllKeys(Keys{
+					"name":   Equal("attachments"),
+					"match":  Equal(map[string]interface{}{"has_attachments": true, "user_groups": []interface{}{"sre"}}),
+					"target": Equal(map[string]interface{}{"provider": "secondary", "model": "gpt-4o-mini"}),
+				})),
+			}))))
+		})
+
+		It("should return error when a routing target is not configured", func() {
+			cr.Spec.OLSConfig.Routing = &olsv1alpha1.RoutingSpec{
+				Failover: &olsv1alpha1.FailoverSpec{
+					Chain: []olsv1alpha1.RoutingTarget{{Provider: "testProvider", Model: "missingModel"}},
+				},
+			}
+			_, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).To(MatchError(ContainSubstring(`spec.ols.routing.failover.chain[0]: model "missingModel" is not configured for provider "testProvider"`)))
+
+			minQueryLength := int32(2000)
+			cr.Spec.OLSConfig.Routing = &olsv1alpha1.RoutingSpec{
+				Rules: []olsv1alpha1.RoutingRule{{
+					Name:   "large",
+					Match:  olsv1alpha1.RoutingMatch{MinQueryLength: &minQueryLength},
+					Target: olsv1alpha1.RoutingTarget{Provider: "missing", Model: "testModel"},
+				}},
+			}
+			_, err = GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).To(MatchError(ContainSubstring(`spec.ols.routing.rules[large].target: provider "missing" is not configured`)))
+		})
+
+		It("should render InferenceService providers from the resolved endpoints", func() {
+			cr.Spec.LLMConfig.Providers = []olsv1alpha1.ProviderSpec{
+				{
+					Name:                 "granite",
+					Type:                 utils.RHOAIVLLMType,
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "test-secret"},
+					InferenceServiceRef:  &olsv1alpha1.InferenceServiceReference{Name: "granite", Namespace: "models"},
+				},
+				{
+					Name:                 "shared",
+					Type:                 utils.RHOAIVLLMType,
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "test-secret"},
+					Models:               []olsv1alpha1.ModelSpec{{Name: "llama", ContextWindowSize: 32768}},
+					InferenceServiceRef: &olsv1alpha1.InferenceServiceReference{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"lightspeed": "true"}},
+					},
+				},
+			}
+			providers, err := buildProviderConfigs(cr, map[string][]utils.InferenceServiceEndpoint{
+				"granite": {{Name: "granite", Namespace: "models", URL: "https://granite-predictor.models.svc.cluster.local/v1"}},
+				"shared": {
+					{Name: "llama", Namespace: "team-a", URL: "https://llama-predictor.team-a.svc.cluster.local/v1"},
+					{Name: "mistral", Namespace: "team-b", URL: "https://mistral-predictor.team-b.svc.cluster.local/v1"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providers).To(HaveLen(2))
+			Expect(providers[0].URL).To(Equal("https://granite-predictor.models.svc.cluster.local/v1"))
+			Expect(providers[0].Models).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name": Equal("granite"),
+				"URL":  BeEmpty(),
+			})))
+			Expect(providers[1].URL).To(BeEmpty())
+			Expect(providers[1].Models).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"Name":              Equal("llama"),
+					"URL":               Equal("https://llama-predictor.team-a.svc.cluster.local/v1"),
+					"ContextWindowSize": BeEquivalentTo(32768),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Name": Equal("mistral"),
+					"URL":  Equal("https://mistral-predictor.team-b.svc.cluster.local/v1"),
+				}),
+			))
+		})
+
+		It("should return error when InferenceServices of a provider serve the same model name", func() {
+			cr.Spec.LLMConfig.Providers[0].Type = utils.RHOAIVLLMType
+			cr.Spec.LLMConfig.Providers[0].URL = ""
+			cr.Spec.LLMConfig.Providers[0].InferenceServiceRef = &olsv1alpha1.InferenceServiceReference{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"lightspeed": "true"}},
+			}
+			_, err := buildProviderConfigs(cr, map[string][]utils.InferenceServiceEndpoint{
+				"testProvider": {
+					{Name: "granite", Namespace: "team-a", URL: "https://granite-predictor.team-a.svc.cluster.local/v1"},
+					{Name: "granite", Namespace: "team-b", URL: "https://granite-predictor.team-b.svc.cluster.local/v1"},
+				},
+			})
+			Expect(err).To(MatchError(ContainSubstring("InferenceServices team-a/granite and team-b/granite of provider testProvider serve the same model name")))
+		})
+
+		It("should return error when the referenced InferenceService cannot be read", func() {
+			cr.Spec.LLMConfig.Providers[0].Type = utils.RHOAIVLLMType
+			cr.Spec.LLMConfig.Providers[0].URL = ""
+			cr.Spec.LLMConfig.Providers[0].InferenceServiceRef = &olsv1alpha1.InferenceServiceReference{Name: "granite"}
+			_, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).To(MatchError(ContainSubstring(utils.ErrGetInferenceService + " " + utils.OLSNamespaceDefault + "/granite of provider testProvider")))
+		})
+
 		It("should generate configmap with introspectionEnabled", func() {
 			cr.Spec.OLSConfig.IntrospectionEnabled = utils.BoolPtr(true)
 			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
@@ -595,6 +962,84 @@ var _ = Describe("App server assets", func() {
 			Expect(cm.Data[utils.OLSConfigFilename]).NotTo(ContainSubstring("solr_direct_rag"))
 		})
 
+		It("should override the solr_hybrid defaults with spec.ols.okp", func() {
+			cr.Spec.OLSConfig.OKP = &olsv1alpha1.OKPSpec{
+				MaxResults:         8,
+				HybridVectorBoost:  4.5,
+				HybridPoolDocs:     200,
+				SolrTimeoutSeconds: 30,
+				ChunkFilterQuery:   "product_version:4.18",
+			}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var appSrvConfigFile utils.AppSrvConfigFile
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &appSrvConfigFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(appSrvConfigFile.OLSConfig.SolrHybrid).To(Equal(&utils.SolrHybridSettings{
+				SolrHTTPBase:             utils.RHOKPServiceURL(utils.OLSNamespaceDefault),
+				MaxResults:               8,
+				ChunkFilterQuery:         "product_version:4.18",
+				HybridVectorBoost:        4.5,
+				HybridPoolDocs:           200,
+				HybridScoreThreshold:     utils.SolrHybridScoreThresholdDefault,
+				HybridSolrTimeoutSeconds: 30,
+			}))
+		})
+
+		It("should render the documentation scopes into solr_hybrid", func() {
+			cr.Spec.OLSConfig.OKP = &olsv1alpha1.OKPSpec{DocumentationScopes: []olsv1alpha1.OKPDocumentationScope{
+				{Name: "upgrade", Version: "4.19"},
+				{Name: "virt", Version: "4.18", Product: "openshift_virtualization"},
+				{Name: "custom", Version: "4.17", ChunkFilterQuery: "product_version:(4.17 OR 4.16)"},
+			}}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var appSrvConfigFile utils.AppSrvConfigFile
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &appSrvConfigFile)
+			Expect(err).NotTo(HaveOccurred())
+			// the cluster version stays the default scope
+			Expect(appSrvConfigFile.OLSConfig.SolrHybrid.ChunkFilterQuery).To(BeEmpty())
+			Expect(appSrvConfigFile.OLSConfig.SolrHybrid.ChunkFilterScopes).To(Equal([]utils.SolrChunkFilterScope{
+				{Name: "upgrade", ChunkFilterQuery: "product_version:4.19"},
+				{Name: "virt", ChunkFilterQuery: "product_version:4.18 AND product:openshift_virtualization"},
+				{Name: "custom", ChunkFilterQuery: "product_version:(4.17 OR 4.16)"},
+			}))
+		})
+
+		It("should point solr_hybrid to the external knowledge portal", func() {
+			cr.Spec.OLSConfig.OKP = &olsv1alpha1.OKPSpec{External: &olsv1alpha1.OKPExternalSpec{
+				URL:                  "https://okp.example.com/",
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: "okp-token"},
+			}}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var appSrvConfigFile utils.AppSrvConfigFile
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &appSrvConfigFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(appSrvConfigFile.OLSConfig.SolrHybrid.SolrHTTPBase).To(Equal("https://okp.example.com"))
+			Expect(appSrvConfigFile.OLSConfig.SolrHybrid.SolrCredentialsPath).To(Equal(
+				path.Join(utils.OKPCredentialsMountRoot, "okp-token")))
+			// without a CA ConfigMap the portal is trusted through the system roots only
+			Expect(appSrvConfigFile.OLSConfig.ExtraCAs).NotTo(ContainElement(
+				path.Join(utils.OLSAppCertsMountRoot, utils.AppRHOKPCACertDir, utils.AppRHOKPCACertFile)))
+		})
+
+		It("should authenticate solr_hybrid with the RHOKP access key rotated by the operator", func() {
+			cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.AccessKeyRotation = &olsv1alpha1.RHOKPAccessKeyRotation{IntervalDays: 7}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var appSrvConfigFile utils.AppSrvConfigFile
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &appSrvConfigFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(appSrvConfigFile.OLSConfig.SolrHybrid.SolrHTTPBase).To(Equal(utils.RHOKPServiceURL(utils.OLSNamespaceDefault)))
+			Expect(appSrvConfigFile.OLSConfig.SolrHybrid.SolrCredentialsPath).To(Equal(
+				path.Join(utils.OKPCredentialsMountRoot, utils.RHOOKPAccessKeySecretName)))
+		})
+
 		It("should omit solr_hybrid from configmap when byokRAGOnly is true", func() {
 			cr.Spec.OLSConfig.ByokRAGOnly = true
 
@@ -931,7 +1376,7 @@ var _ = Describe("App server assets", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(service.Name).To(Equal(utils.OLSAppServerServiceName))
 			Expect(service.Namespace).To(Equal(utils.OLSNamespaceDefault))
-			Expect(service.Spec.Selector).To(Equal(utils.GenerateAppServerSelectorLabels()))
+			Expect(service.Spec.Selector).To(Equal(utils.GenerateAppServerServiceSelectorLabels()))
 			Expect(service.Spec.Ports).To(Equal([]corev1.ServicePort{
 				{
 					Name:       "https",
@@ -1159,28 +1604,34 @@ var _ = Describe("App server assets", func() {
 			Expect(deployment.Spec.Template.Spec.InitContainers).To(ConsistOf(
 				utils.GeneratePostgresWaitInitContainer(testReconcilerInstance.GetPostgresImage()),
 				corev1.Container{
-					Name:    "rag-0",
-					Image:   "rag-ocp-product-docs:4.19",
-					Command: []string{"sh", "-c", fmt.Sprintf("mkdir -p %s/rag-0 && cp -a /rag/vector_db/ocp_product_docs/4.19/. %s/rag-0", utils.RAGVolumeMountPath, utils.RAGVolumeMountPath)},
+					Name:  "rag-0",
+					Image: "rag-ocp-product-docs:4.19",
+					Command: []string{"sh", "-c", ragIndexCheckCommand("/rag/vector_db/ocp_product_docs/4.19", "rag-ocp-product-docs:4.19") +
+						fmt.Sprintf(" && mkdir -p %s/rag-0 && cp -a /rag/vector_db/ocp_product_docs/4.19/. %s/rag-0 && ", utils.RAGVolumeMountPath, utils.RAGVolumeMountPath) +
+						ragIndexReportCommand(utils.RAGVolumeMountPath+"/rag-0")},
 					VolumeMounts: []corev1.VolumeMount{
 						{
 							Name:      utils.RAGVolumeName,
 							MountPath: utils.RAGVolumeMountPath,
 						},
 					},
-					ImagePullPolicy: corev1.PullAlways,
+					ImagePullPolicy:          corev1.PullAlways,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 				},
 				corev1.Container{
-					Name:    "rag-1",
-					Image:   "rag-ansible-docs:2.18",
-					Command: []string{"sh", "-c", fmt.Sprintf("mkdir -p %s/rag-1 && cp -a /rag/vector_db/ansible_docs/2.18/. %s/rag-1", utils.RAGVolumeMountPath, utils.RAGVolumeMountPath)},
+					Name:  "rag-1",
+					Image: "rag-ansible-docs:2.18",
+					Command: []string{"sh", "-c", ragIndexCheckCommand("/rag/vector_db/ansible_docs/2.18", "rag-ansible-docs:2.18") +
+						fmt.Sprintf(" && mkdir -p %s/rag-1 && cp -a /rag/vector_db/ansible_docs/2.18/. %s/rag-1 && ", utils.RAGVolumeMountPath, utils.RAGVolumeMountPath) +
+						ragIndexReportCommand(utils.RAGVolumeMountPath+"/rag-1")},
 					VolumeMounts: []corev1.VolumeMount{
 						{
 							Name:      utils.RAGVolumeName,
 							MountPath: utils.RAGVolumeMountPath,
 						},
 					},
-					ImagePullPolicy: corev1.PullAlways,
+					ImagePullPolicy:          corev1.PullAlways,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 				},
 			))
 		})
@@ -1323,6 +1774,129 @@ var _ = Describe("App server assets", func() {
 			}))
 		})
 
+		It("should point the reference indexes at the RAG sources mounted in place", func() {
+			cr.Spec.OLSConfig.RAG = []olsv1alpha1.RAGSpec{
+				{
+					IndexPath:             "/index",
+					IndexID:               "pvc-index",
+					PersistentVolumeClaim: &olsv1alpha1.RAGPersistentVolumeClaimSource{ClaimName: "rag-pvc"},
+				},
+				{
+					IndexID:   "configmap-index",
+					ConfigMap: &corev1.LocalObjectReference{Name: "rag-configmap"},
+				},
+			}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+			olsconfigGenerated := utils.AppSrvConfigFile{}
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &olsconfigGenerated)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(olsconfigGenerated.OLSConfig.ReferenceContent.Indexes).To(Equal([]utils.ReferenceIndex{
+				{
+					ProductDocsIndexId:   "pvc-index",
+					ProductDocsIndexPath: utils.RAGSourceMountRoot + "/rag-0/index",
+					ProductDocsOrigin:    "pvc/rag-pvc",
+				},
+				{
+					ProductDocsIndexId:   "configmap-index",
+					ProductDocsIndexPath: utils.RAGSourceMountRoot + "/rag-1",
+					ProductDocsOrigin:    "configmap/rag-configmap",
+				},
+			}))
+		})
+
+		It("should render the weighting of the BYOK RAG indexes and the OKP documentation", func() {
+			cr.Spec.OLSConfig.RAG = []olsv1alpha1.RAGSpec{
+				{
+					IndexPath:       "/rag/vector_db/runbooks",
+					IndexID:         "runbooks",
+					Image:           "rag-runbooks:latest",
+					Weight:          5,
+					MaxChunks:       3,
+					PreferredTopics: []string{"acme-db-operator", "acme-backup-operator"},
+				},
+				{
+					IndexPath: "/rag/vector_db/ansible_docs/2.18",
+					IndexID:   "ansible-docs-2_18",
+					Image:     "rag-ansible-docs:2.18",
+				},
+			}
+			cr.Spec.OLSConfig.OKP = &olsv1alpha1.OKPSpec{Weight: 2}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+			olsconfigGenerated := utils.AppSrvConfigFile{}
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &olsconfigGenerated)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(olsconfigGenerated.OLSConfig.ReferenceContent.Indexes).To(Equal([]utils.ReferenceIndex{
+				{
+					ProductDocsIndexId:   "runbooks",
+					ProductDocsIndexPath: utils.RAGVolumeMountPath + "/rag-0",
+					ProductDocsOrigin:    "rag-runbooks:latest",
+					Weight:               5,
+					MaxChunks:            3,
+					PreferredTopics:      []string{"acme-db-operator", "acme-backup-operator"},
+				},
+				{
+					ProductDocsIndexId:   "ansible-docs-2_18",
+					ProductDocsIndexPath: utils.RAGVolumeMountPath + "/rag-1",
+					ProductDocsOrigin:    "rag-ansible-docs:2.18",
+				},
+			}))
+			Expect(olsconfigGenerated.OLSConfig.SolrHybrid.Weight).To(Equal(int32(2)))
+		})
+
+		It("should render the embedding models of BYOK RAG indexes", func() {
+			cr.Spec.OLSConfig.RAG = []olsv1alpha1.RAGSpec{
+				{
+					IndexPath:      "/rag/vector_db/ansible_docs/2.18",
+					IndexID:        "ansible-docs-2_18",
+					Image:          "rag-ansible-docs:2.18",
+					EmbeddingModel: &olsv1alpha1.EmbeddingModelSpec{Path: "/rag/embeddings_model", Dimension: 384},
+				},
+				{
+					IndexPath: "/rag/vector_db/rhel_docs",
+					IndexID:   "rhel-docs",
+					Image:     "rag-rhel-docs:latest",
+					EmbeddingModel: &olsv1alpha1.EmbeddingModelSpec{Endpoint: &olsv1alpha1.EmbeddingEndpointSpec{
+						URL:                  "https://embeddings.example.com/v1",
+						Model:                "text-embedding-3-small",
+						CredentialsSecretRef: &corev1.LocalObjectReference{Name: "embeddings-key"},
+					}},
+				},
+			}
+			cm, err := GenerateOLSConfigMap(testReconcilerInstance, context.TODO(), cr)
+			Expect(err).NotTo(HaveOccurred())
+			olsconfigGenerated := utils.AppSrvConfigFile{}
+			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &olsconfigGenerated)
+			Expect(err).NotTo(HaveOccurred())
+
+			indexes := olsconfigGenerated.OLSConfig.ReferenceContent.Indexes
+			Expect(indexes).To(HaveLen(2))
+			Expect(indexes[0].EmbeddingsModel).To(Equal(&utils.EmbeddingModelConfig{
+				Path:      utils.RAGVolumeMountPath + "/rag-0-embeddings",
+				Dimension: 384,
+			}))
+			Expect(indexes[1].EmbeddingsModel).To(Equal(&utils.EmbeddingModelConfig{
+				URL:             "https://embeddings.example.com/v1",
+				Model:           "text-embedding-3-small",
+				CredentialsPath: "/etc/embeddings/embeddings-key/apitoken",
+			}))
+			Expect(olsconfigGenerated.OLSConfig.ReferenceContent.EmbeddingsModelPath).To(Equal("/app-root/embeddings_model"))
+		})
+
+		It("should render the embedding model of tool filtering", func() {
+			cr.Spec.FeatureGates = []olsv1alpha1.FeatureGate{utils.FeatureGateToolFiltering}
+			cr.Spec.OLSConfig.ToolFilteringConfig = &olsv1alpha1.ToolFilteringConfig{}
+			mcpServers := []utils.MCPServerConfig{{Name: "tools", URL: "https://tools.example.com/mcp"}}
+
+			config := buildToolFilteringConfig(cr, mcpServers, testReconcilerInstance)
+			Expect(config.EmbeddingsModel).To(BeNil())
+
+			cr.Spec.OLSConfig.ToolFilteringConfig.EmbeddingModel = &olsv1alpha1.EmbeddingModelSpec{Path: "/app-root/tools_embeddings_model", Dimension: 768}
+			config = buildToolFilteringConfig(cr, mcpServers, testReconcilerInstance)
+			Expect(config.EmbeddingsModel).To(Equal(&utils.EmbeddingModelConfig{Path: "/app-root/tools_embeddings_model", Dimension: 768}))
+		})
+
 		It("should mount openshift-mcp-server CA when introspectionEnabled is true", func() {
 			utils.CreateTelemetryPullSecret(ctx, k8sClient, true)
 			defer utils.DeleteTelemetryPullSecret(ctx, k8sClient)
@@ -1495,7 +2069,7 @@ var _ = Describe("App server assets", func() {
 			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &olsconfigGenerated)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(olsconfigGenerated.LLMProviders).To(BeEmpty())
-			Expect(olsconfigGenerated.OLSConfig.SolrHybrid).To(Equal(buildSolrHybridSettings(utils.OLSNamespaceDefault)))
+			Expect(olsconfigGenerated.OLSConfig.SolrHybrid).To(Equal(buildSolrHybridSettings(cr, utils.OLSNamespaceDefault)))
 			Expect(cm.Data[utils.OLSConfigFilename]).NotTo(ContainSubstring("reference_content:"))
 			Expect(olsconfigGenerated.OLSConfig.Audit).To(Equal(buildServiceAuditConfig(cr, utils.OLSNamespaceDefault)))
 			Expect(olsconfigGenerated.OLSConfig.UserDataCollection.FeedbackDisabled).To(BeFalse())
@@ -1518,7 +2092,7 @@ var _ = Describe("App server assets", func() {
 			var olsconfigGenerated utils.AppSrvConfigFile
 			err = yaml.Unmarshal([]byte(cm.Data[utils.OLSConfigFilename]), &olsconfigGenerated)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(olsconfigGenerated.OLSConfig.SolrHybrid).To(Equal(buildSolrHybridSettings(utils.OLSNamespaceDefault)))
+			Expect(olsconfigGenerated.OLSConfig.SolrHybrid).To(Equal(buildSolrHybridSettings(cr, utils.OLSNamespaceDefault)))
 			Expect(cm.Data[utils.OLSConfigFilename]).NotTo(ContainSubstring("reference_content:"))
 			Expect(olsconfigGenerated.OLSConfig.UserDataCollection.FeedbackDisabled).To(BeTrue())
 			Expect(olsconfigGenerated.OLSConfig.UserDataCollection.TranscriptsDisabled).To(BeTrue())
@@ -1533,7 +2107,7 @@ var _ = Describe("App server assets", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(service.Name).To(Equal(utils.OLSAppServerServiceName))
 			Expect(service.Namespace).To(Equal(utils.OLSNamespaceDefault))
-			Expect(service.Spec.Selector).To(Equal(utils.GenerateAppServerSelectorLabels()))
+			Expect(service.Spec.Selector).To(Equal(utils.GenerateAppServerServiceSelectorLabels()))
 			Expect(service.Annotations[utils.ServingCertSecretAnnotationKey]).To(Equal(utils.OLSCertsSecretName))
 			Expect(service.Spec.Ports).To(Equal([]corev1.ServicePort{
 				{
@@ -21,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/ragbuild"
 	"github.com/openshift/lightspeed-operator/internal/controller/utils"
 )
 
@@ -74,6 +75,40 @@ func appServerEnv(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) []corev1.E
 	return env
 }
 
+// externalSecretVolumes returns a read-only Secret volume and mount per Secret of the ForEachExternalSecret
+// sources starting with sourcePrefix, mounted at mountRoot/<secret name>. A Secret referenced by several
+// sources is mounted once. Without items, all the keys of the Secret are mounted.
+func externalSecretVolumes(cr *olsv1alpha1.OLSConfig, sourcePrefix, volumePrefix, mountRoot string, items ...corev1.KeyToPath) ([]corev1.Volume, []corev1.VolumeMount) {
+	volumeDefaultMode := utils.VolumeDefaultMode
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	mounted := map[string]bool{}
+	// Note: Callback never returns an error, using ForEach for convenient iteration
+	_ = utils.ForEachExternalSecret(cr, func(name, source string) error {
+		if !strings.HasPrefix(source, sourcePrefix) || mounted[name] {
+			return nil
+		}
+		mounted[name] = true
+		volumes = append(volumes, corev1.Volume{
+			Name: volumePrefix + name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  name,
+					DefaultMode: &volumeDefaultMode,
+					Items:       items,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volumePrefix + name,
+			MountPath: path.Join(mountRoot, name),
+			ReadOnly:  true,
+		})
+		return nil
+	})
+	return volumes, volumeMounts
+}
+
 func GenerateOLSDeployment(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (*appsv1.Deployment, error) {
 	ctx := context.Background()
 	const OLSConfigVolumeName = "cm-olsconfig"
@@ -104,32 +139,67 @@ func GenerateOLSDeployment(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (
 	volumes := []corev1.Volume{}
 	volumeMounts := []corev1.VolumeMount{}
 
-	// Add external LLM provider secrets - create both volumes and volume mounts in single pass
-	// Note: Callback never returns an error, using ForEach for convenient iteration
-	_ = utils.ForEachExternalSecret(cr, func(name, source string) error {
-		if !strings.HasPrefix(source, "llm-provider-") {
-			// TLS and MCP header secrets are handled separately below
-			return nil
+	// Add external LLM provider secrets, TLS and header secrets are handled separately below
+	providerVolumes, providerVolumeMounts := externalSecretVolumes(cr, "llm-provider-", "secret-", utils.APIKeyMountRoot)
+	volumes = append(volumes, providerVolumes...)
+	volumeMounts = append(volumeMounts, providerVolumeMounts...)
+
+	// Secrets Store CSI credentials, mounted by the driver from the external key store of each provider
+	for _, provider := range cr.Spec.LLMConfig.Providers {
+		if provider.CredentialsSource == nil {
+			continue
 		}
+		volumeName := utils.CredentialsSourceVolumePrefix + provider.Name
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				CSI: &corev1.CSIVolumeSource{
+					Driver:   utils.SecretsStoreCSIDriverName,
+					ReadOnly: utils.BoolPtr(true),
+					VolumeAttributes: map[string]string{
+						utils.SecretProviderClassVolumeAttribute: provider.CredentialsSource.SecretProviderClass,
+					},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: credentialsDir(provider),
+			ReadOnly:  true,
+		})
+	}
 
-		mountPath := path.Join(utils.APIKeyMountRoot, name)
+	// Projected ServiceAccount tokens of workload identity providers, one audience-scoped token per provider
+	var workloadIdentityTokens []corev1.VolumeProjection
+	workloadIdentityTokenExpiration := int64(utils.WorkloadIdentityTokenExpirationSeconds)
+	for _, provider := range cr.Spec.LLMConfig.Providers {
+		if provider.WorkloadIdentity == nil {
+			continue
+		}
+		workloadIdentityTokens = append(workloadIdentityTokens, corev1.VolumeProjection{
+			ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+				Audience:          workloadIdentityAudience(provider),
+				ExpirationSeconds: &workloadIdentityTokenExpiration,
+				Path:              path.Join(provider.Name, utils.WorkloadIdentityTokenFileName),
+			},
+		})
+	}
+	if len(workloadIdentityTokens) > 0 {
 		volumes = append(volumes, corev1.Volume{
-			Name: "secret-" + name,
+			Name: utils.WorkloadIdentityVolumeName,
 			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName:  name,
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources:     workloadIdentityTokens,
 					DefaultMode: &volumeDefaultMode,
 				},
 			},
 		})
-
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      "secret-" + name,
-			MountPath: mountPath,
+			Name:      utils.WorkloadIdentityVolumeName,
+			MountPath: utils.WorkloadIdentityMountRoot,
 			ReadOnly:  true,
 		})
-		return nil
-	})
+	}
 
 	// Postgres secret volume and mount (operator-owned, not external)
 	postgresCredentialsMountPath := path.Join(utils.CredentialsMountRoot, utils.PostgresSecretName)
@@ -318,11 +388,16 @@ func GenerateOLSDeployment(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (
 		return nil
 	})
 
-	// RAG volume
-	if len(cr.Spec.OLSConfig.RAG) > 0 {
+	// RAG volume, hosting the copied indexes, and the RAG sources mounted in place
+	if err := checkRAGImageVolumes(r, cr); err != nil {
+		return nil, err
+	}
+	if hasCopiedRAG(cr) {
 		ragVolume := generateRAGVolume()
 		volumes = append(volumes, ragVolume)
 	}
+	ragSourceVolumes, ragSourceVolumeMounts := generateRAGSourceVolumes(cr)
+	volumes = append(volumes, ragSourceVolumes...)
 
 	// Postgres CA volume
 	volumes = append(volumes, utils.GetPostgresCAConfigVolume())
@@ -361,7 +436,7 @@ func GenerateOLSDeployment(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (
 		})
 	}
 
-	if !cr.Spec.OLSConfig.ByokRAGOnly {
+	if utils.IsOKPCATrusted(cr) {
 		volumes = append(volumes, corev1.Volume{
 			Name: utils.AppRHOKPCACertVolumeName,
 			VolumeSource: corev1.VolumeSource{
@@ -379,6 +454,26 @@ func GenerateOLSDeployment(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (
 		})
 	}
 
+	if utils.HasInferenceServiceProviders(cr) {
+		volumes = append(volumes, corev1.Volume{
+			Name: utils.AppInferenceServiceCACertVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: utils.OLSCAConfigMap,
+					},
+					DefaultMode: &volumeDefaultMode,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  utils.AppInferenceServiceCACertFile,
+							Path: utils.AppInferenceServiceCACertFile,
+						},
+					},
+				},
+			},
+		})
+	}
+
 	volumes = append(volumes,
 		corev1.Volume{
 			Name: utils.TmpVolumeName,
@@ -388,10 +483,11 @@ func GenerateOLSDeployment(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (
 		},
 	)
 
-	if len(cr.Spec.OLSConfig.RAG) > 0 {
+	if hasCopiedRAG(cr) {
 		ragVolumeMounts := generateRAGVolumeMount()
 		volumeMounts = append(volumeMounts, ragVolumeMounts)
 	}
+	volumeMounts = append(volumeMounts, ragSourceVolumeMounts...)
 
 	volumeMounts = append(volumeMounts,
 		utils.GetPostgresCAVolumeMount(path.Join(utils.OLSAppCertsMountRoot, "postgres-ca")),
@@ -408,44 +504,55 @@ func GenerateOLSDeployment(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (
 			ReadOnly:  true,
 		})
 	}
-	if !cr.Spec.OLSConfig.ByokRAGOnly {
+	if utils.IsOKPCATrusted(cr) {
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      utils.AppRHOKPCACertVolumeName,
 			MountPath: path.Join(utils.OLSAppCertsMountRoot, utils.AppRHOKPCACertDir),
 			ReadOnly:  true,
 		})
 	}
+	if utils.HasInferenceServiceProviders(cr) {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      utils.AppInferenceServiceCACertVolumeName,
+			MountPath: path.Join(utils.OLSAppCertsMountRoot, utils.AppInferenceServiceCACertDir),
+			ReadOnly:  true,
+		})
+	}
 	volumeMounts = append(volumeMounts, corev1.VolumeMount{
 		Name:      utils.TmpVolumeName,
 		MountPath: utils.TmpVolumeMountPath,
 	})
 
 	// mount the volumes and add Volume mounts for the MCP server headers
-	// Note: Callback never returns an error, using ForEach for convenient iteration
-	_ = utils.ForEachExternalSecret(cr, func(name, source string) error {
-		if strings.HasPrefix(source, "mcp-") {
-			volumes = append(volumes, corev1.Volume{
-				Name: "header-" + name,
-				VolumeSource: corev1.VolumeSource{
-					Secret: &corev1.SecretVolumeSource{
-						SecretName:  name,
-						DefaultMode: &volumeDefaultMode,
-					},
-				},
-			})
-			volumeMounts = append(volumeMounts, corev1.VolumeMount{
-				Name:      "header-" + name,
-				MountPath: path.Join(utils.MCPHeadersMountRoot, name),
-				ReadOnly:  true,
-			})
-		}
-		return nil
-	})
+	mcpHeaderVolumes, mcpHeaderVolumeMounts := externalSecretVolumes(cr, "mcp-", "header-", utils.MCPHeadersMountRoot)
+	volumes = append(volumes, mcpHeaderVolumes...)
+	volumeMounts = append(volumeMounts, mcpHeaderVolumeMounts...)
+
+	// mount the secrets of the LLM provider headers
+	providerHeaderVolumes, providerHeaderVolumeMounts := externalSecretVolumes(cr, "llm-header-", "llm-header-", utils.LLMProviderHeadersMountRoot)
+	volumes = append(volumes, providerHeaderVolumes...)
+	volumeMounts = append(volumeMounts, providerHeaderVolumeMounts...)
+
+	// mount the API keys of the remote embedding endpoints
+	embeddingVolumes, embeddingVolumeMounts := externalSecretVolumes(cr, "embedding-", "embedding-", utils.EmbeddingCredentialsMountRoot)
+	volumes = append(volumes, embeddingVolumes...)
+	volumeMounts = append(volumeMounts, embeddingVolumeMounts...)
+
+	// mount the credentials of the external knowledge portal
+	okpVolumes, okpVolumeMounts := externalSecretVolumes(cr, "okp-external", "okp-credentials-", utils.OKPCredentialsMountRoot)
+	volumes = append(volumes, okpVolumes...)
+	volumeMounts = append(volumeMounts, okpVolumeMounts...)
+
+	// mount the RHOKP access key rotated by the operator, exposed under the api token key of the portal credentials
+	accessKeyVolumes, accessKeyVolumeMounts := externalSecretVolumes(cr, "rhokp-access-key", "okp-credentials-", utils.OKPCredentialsMountRoot,
+		corev1.KeyToPath{Key: utils.RHOOKPAccessKeySecretKey, Path: utils.DefaultCredentialKey})
+	volumes = append(volumes, accessKeyVolumes...)
+	volumeMounts = append(volumeMounts, accessKeyVolumeMounts...)
 
 	initContainers := []corev1.Container{}
 	initContainers = append(initContainers, utils.GeneratePostgresWaitInitContainer(r.GetPostgresImage()))
 	if len(cr.Spec.OLSConfig.RAG) > 0 {
-		ragInitContainers := GenerateRAGInitContainers(cr)
+		ragInitContainers := GenerateRAGInitContainers(r, cr)
 		initContainers = append(initContainers, ragInitContainers...)
 	}
 
@@ -465,9 +572,15 @@ func GenerateOLSDeployment(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (
 		return nil, fmt.Errorf("failed to get Proxy CA certificate hash: %w", err)
 	}
 
+	ragBuildCompletion, err := ragbuild.CompletionHash(r, ctx, cr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get RAG index build completion: %w", err)
+	}
+
 	annotations := map[string]string{
 		utils.OLSConfigMapResourceVersionAnnotation: configMapResourceVersion,
 		utils.RAGSpecHashAnnotation:                 ragSpecHash(cr),
+		utils.RAGBuildCompletionAnnotation:          ragBuildCompletion,
 		utils.ProxyCACertHashAnnotation:             proxyCACMResourceVersion,
 	}
 
@@ -541,11 +654,19 @@ func GenerateOLSDeployment(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (
 		if cr.Spec.OLSConfig.ImagePullSecrets != nil {
 			deployment.Spec.Template.Spec.ImagePullSecrets = cr.Spec.OLSConfig.ImagePullSecrets
 		}
-		triggers, err := generateImageStreamTriggers(cr)
-		if err != nil {
-			return nil, err
+		if ragUpdateManaged(cr) {
+			if err := applyRAGUpdate(r, ctx, cr, &deployment); err != nil {
+				return nil, err
+			}
+		} else {
+			triggers, err := generateImageStreamTriggers(cr)
+			if err != nil {
+				return nil, err
+			}
+			if triggers != "" {
+				deployment.Annotations[utils.OLSAppServerImageStreamTriggerAnnotation] = triggers
+			}
 		}
-		deployment.Annotations[utils.OLSAppServerImageStreamTriggerAnnotation] = triggers
 	}
 
 	if err := controllerutil.SetControllerReference(cr, &deployment, r.GetScheme()); err != nil {
@@ -625,6 +746,19 @@ func updateOLSDeployment(r reconciler.Reconciler, ctx context.Context, cr *olsv1
 		changed = true
 	}
 
+	// Step 5: Check if a RAG index read by the app server was rebuilt
+	if existingDeployment.Annotations[utils.RAGBuildCompletionAnnotation] != desiredDeployment.Annotations[utils.RAGBuildCompletionAnnotation] {
+		r.GetLogger().Info("RAG index rebuilt, updating deployment")
+		changed = true
+	}
+
+	// Step 6: Check if the RAG images rolled out under the update policy, or their ImageStream triggers, changed
+	if existingDeployment.Annotations[utils.RAGImageRevisionAnnotation] != desiredDeployment.Annotations[utils.RAGImageRevisionAnnotation] ||
+		existingDeployment.Annotations[utils.OLSAppServerImageStreamTriggerAnnotation] != desiredDeployment.Annotations[utils.OLSAppServerImageStreamTriggerAnnotation] {
+		r.GetLogger().Info("RAG images changed, updating deployment")
+		changed = true
+	}
+
 	// If nothing changed, skip update
 	if !changed {
 		return nil
@@ -640,7 +774,15 @@ func updateOLSDeployment(r reconciler.Reconciler, ctx context.Context, cr *olsv1
 
 	existingDeployment.Annotations[utils.OLSConfigMapResourceVersionAnnotation] = desiredDeployment.Annotations[utils.OLSConfigMapResourceVersionAnnotation]
 	existingDeployment.Annotations[utils.RAGSpecHashAnnotation] = currentRAGHash
+	existingDeployment.Annotations[utils.RAGBuildCompletionAnnotation] = desiredDeployment.Annotations[utils.RAGBuildCompletionAnnotation]
 	existingDeployment.Annotations[utils.ProxyCACertHashAnnotation] = currentProxyCACMHash
+	for _, key := range []string{utils.RAGImageRevisionAnnotation, utils.OLSAppServerImageStreamTriggerAnnotation} {
+		if value, ok := desiredDeployment.Annotations[key]; ok {
+			existingDeployment.Annotations[key] = value
+		} else {
+			delete(existingDeployment.Annotations, key)
+		}
+	}
 
 	r.GetLogger().Info("updating OLS deployment", "name", existingDeployment.Name)
 
@@ -127,6 +127,188 @@ var _ = Describe("App server deployment generation", func() {
 			Expect(deployment.Spec.Template.Spec.InitContainers).ToNot(BeEmpty())
 		})
 
+		It("should mount an audience-scoped projected token for workload identity providers", func() {
+			cr.Spec.LLMConfig.Providers = append(cr.Spec.LLMConfig.Providers, olsv1alpha1.ProviderSpec{
+				Name:   "azure",
+				Type:   utils.AzureOpenAIType,
+				URL:    "https://example.openai.azure.com",
+				Models: []olsv1alpha1.ModelSpec{{Name: "gpt-4o"}},
+				WorkloadIdentity: &olsv1alpha1.WorkloadIdentitySpec{
+					ClientID: "00000000-0000-0000-0000-000000000001",
+					TenantID: "00000000-0000-0000-0000-000000000002",
+				},
+			})
+			deployment, err := GenerateOLSDeployment(testReconcilerInstance, cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var volume corev1.Volume
+			Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", utils.WorkloadIdentityVolumeName), &volume))
+			Expect(volume.Projected).NotTo(BeNil())
+			Expect(volume.Projected.Sources).To(HaveLen(1))
+			token := volume.Projected.Sources[0].ServiceAccountToken
+			Expect(token.Audience).To(Equal(utils.AzureWorkloadIdentityAudience))
+			Expect(token.Path).To(Equal("azure/token"))
+			Expect(*token.ExpirationSeconds).To(Equal(int64(utils.WorkloadIdentityTokenExpirationSeconds)))
+			Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      utils.WorkloadIdentityVolumeName,
+				MountPath: utils.WorkloadIdentityMountRoot,
+				ReadOnly:  true,
+			}))
+		})
+
+		It("should mount Secrets Store CSI credentials sources at the provider directory", func() {
+			cr.Spec.LLMConfig.Providers = append(cr.Spec.LLMConfig.Providers, olsv1alpha1.ProviderSpec{
+				Name:              "vault",
+				Type:              "openai",
+				Models:            []olsv1alpha1.ModelSpec{{Name: "gpt-4o"}},
+				CredentialsSource: &olsv1alpha1.ProviderCredentialsSource{SecretProviderClass: "vault-openai"},
+			})
+			deployment, err := GenerateOLSDeployment(testReconcilerInstance, cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var volume corev1.Volume
+			Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", "csi-vault"), &volume))
+			Expect(volume.CSI).NotTo(BeNil())
+			Expect(volume.CSI.Driver).To(Equal(utils.SecretsStoreCSIDriverName))
+			Expect(*volume.CSI.ReadOnly).To(BeTrue())
+			Expect(volume.CSI.VolumeAttributes).To(Equal(map[string]string{"secretProviderClass": "vault-openai"}))
+			Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      "csi-vault",
+				MountPath: "/etc/apikeys/_csi/vault",
+				ReadOnly:  true,
+			}))
+		})
+
+		It("should mount the service CA for InferenceService providers", func() {
+			deployment, err := GenerateOLSDeployment(testReconcilerInstance, cr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployment.Spec.Template.Spec.Volumes).NotTo(ContainElement(HaveField("Name", utils.AppInferenceServiceCACertVolumeName)))
+
+			cr.Spec.LLMConfig.Providers = append(cr.Spec.LLMConfig.Providers, olsv1alpha1.ProviderSpec{
+				Name:                 "granite",
+				Type:                 utils.RHOAIVLLMType,
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: "test-secret"},
+				InferenceServiceRef:  &olsv1alpha1.InferenceServiceReference{Name: "granite"},
+			})
+			deployment, err = GenerateOLSDeployment(testReconcilerInstance, cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var volume corev1.Volume
+			Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", utils.AppInferenceServiceCACertVolumeName), &volume))
+			Expect(volume.ConfigMap).NotTo(BeNil())
+			Expect(volume.ConfigMap.Name).To(Equal(utils.OLSCAConfigMap))
+			Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      utils.AppInferenceServiceCACertVolumeName,
+				MountPath: path.Join(utils.OLSAppCertsMountRoot, utils.AppInferenceServiceCACertDir),
+				ReadOnly:  true,
+			}))
+		})
+
+		It("should mount the secrets of LLM provider headers once", func() {
+			header := olsv1alpha1.ProviderHeader{
+				Name: "X-Gateway-Key",
+				ValueFrom: olsv1alpha1.ProviderHeaderValueSource{
+					Type:      olsv1alpha1.ProviderHeaderSourceTypeSecret,
+					SecretRef: &corev1.LocalObjectReference{Name: "gateway-key"},
+				},
+			}
+			cr.Spec.LLMConfig.Providers[0].Headers = []olsv1alpha1.ProviderHeader{header}
+			cr.Spec.LLMConfig.Providers = append(cr.Spec.LLMConfig.Providers, olsv1alpha1.ProviderSpec{
+				Name:                 "second",
+				Type:                 "openai",
+				URL:                  "https://gateway.example.com/v1",
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: "test-secret"},
+				Models:               []olsv1alpha1.ModelSpec{{Name: "gpt-4o"}},
+				Headers:              []olsv1alpha1.ProviderHeader{header},
+			})
+			deployment, err := GenerateOLSDeployment(testReconcilerInstance, cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", "llm-header-gateway-key")))
+			Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      "llm-header-gateway-key",
+				MountPath: path.Join(utils.LLMProviderHeadersMountRoot, "gateway-key"),
+				ReadOnly:  true,
+			}))
+			headerVolumes := 0
+			for _, volume := range deployment.Spec.Template.Spec.Volumes {
+				if volume.Name == "llm-header-gateway-key" {
+					headerVolumes++
+				}
+			}
+			Expect(headerVolumes).To(Equal(1))
+		})
+
+		It("should mount the secrets of remote embedding endpoints once", func() {
+			embeddingModel := &olsv1alpha1.EmbeddingModelSpec{Endpoint: &olsv1alpha1.EmbeddingEndpointSpec{
+				URL:                  "https://embeddings.example.com/v1",
+				Model:                "embed",
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: "embeddings-key"},
+			}}
+			cr.Spec.OLSConfig.RAG = []olsv1alpha1.RAGSpec{
+				{Image: "rag-image-1", IndexPath: "/path/to/index-1", EmbeddingModel: embeddingModel},
+				{Image: "rag-image-2", IndexPath: "/path/to/index-2", EmbeddingModel: embeddingModel},
+			}
+			cr.Spec.OLSConfig.ToolFilteringConfig = &olsv1alpha1.ToolFilteringConfig{EmbeddingModel: embeddingModel}
+			deployment, err := GenerateOLSDeployment(testReconcilerInstance, cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      "embedding-embeddings-key",
+				MountPath: path.Join(utils.EmbeddingCredentialsMountRoot, "embeddings-key"),
+				ReadOnly:  true,
+			}))
+			embeddingVolumes := 0
+			for _, volume := range deployment.Spec.Template.Spec.Volumes {
+				if volume.Name == "embedding-embeddings-key" {
+					embeddingVolumes++
+				}
+			}
+			Expect(embeddingVolumes).To(Equal(1))
+		})
+
+		It("should mount the external knowledge portal credentials instead of the RHOKP CA", func() {
+			cr.Spec.OLSConfig.OKP = &olsv1alpha1.OKPSpec{External: &olsv1alpha1.OKPExternalSpec{
+				URL:                  "https://okp.example.com",
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: "okp-token"},
+			}}
+			deployment, err := GenerateOLSDeployment(testReconcilerInstance, cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      "okp-credentials-okp-token",
+				MountPath: path.Join(utils.OKPCredentialsMountRoot, "okp-token"),
+				ReadOnly:  true,
+			}))
+			Expect(deployment.Spec.Template.Spec.Volumes).NotTo(ContainElement(
+				HaveField("Name", utils.AppRHOKPCACertVolumeName)))
+		})
+
+		It("should mount the RHOKP access key rotated by the operator as the portal api token", func() {
+			cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.AccessKeyRotation = &olsv1alpha1.RHOKPAccessKeyRotation{IntervalDays: 30}
+			deployment, err := GenerateOLSDeployment(testReconcilerInstance, cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			volumeName := "okp-credentials-" + utils.RHOOKPAccessKeySecretName
+			Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: path.Join(utils.OKPCredentialsMountRoot, utils.RHOOKPAccessKeySecretName),
+				ReadOnly:  true,
+			}))
+			var accessKeyVolume *corev1.Volume
+			for i := range deployment.Spec.Template.Spec.Volumes {
+				if deployment.Spec.Template.Spec.Volumes[i].Name == volumeName {
+					accessKeyVolume = &deployment.Spec.Template.Spec.Volumes[i]
+				}
+			}
+			Expect(accessKeyVolume).NotTo(BeNil())
+			Expect(accessKeyVolume.Secret.SecretName).To(Equal(utils.RHOOKPAccessKeySecretName))
+			Expect(accessKeyVolume.Secret.Items).To(ConsistOf(corev1.KeyToPath{
+				Key:  utils.RHOOKPAccessKeySecretKey,
+				Path: utils.DefaultCredentialKey,
+			}))
+		})
+
 		It("should mount openshift-mcp-server CA when introspectionEnabled is true", func() {
 			utils.CreateTelemetryPullSecret(ctx, k8sClient, true)
 			defer utils.DeleteTelemetryPullSecret(ctx, k8sClient)
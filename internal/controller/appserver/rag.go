@@ -1,13 +1,19 @@
 package appserver
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"path"
+	"sort"
+	"strings"
 
+	imagev1 "github.com/openshift/api/image/v1"
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
 	"github.com/openshift/lightspeed-operator/internal/controller/utils"
 )
 
@@ -32,26 +38,234 @@ func generateRAGVolume() corev1.Volume {
 	}
 }
 
-func GenerateRAGInitContainers(cr *olsv1alpha1.OLSConfig) []corev1.Container {
+// ragImageVolumes checks if OCI artifact RAG sources can be mounted as image volumes on the cluster
+func ragImageVolumes(r reconciler.Reconciler) bool {
+	return utils.ImageVolumesSupported(r.GetOpenShiftMajor(), r.GetOpenshiftMinor())
+}
+
+// checkRAGImageVolumes fails when an OCI artifact RAG source is configured on a cluster without image
+// volumes. An OCI artifact is not a runnable image, it can only be mounted as an image volume.
+func checkRAGImageVolumes(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) error {
+	if ragImageVolumes(r) {
+		return nil
+	}
+	for idx, rag := range cr.Spec.OLSConfig.RAG {
+		if rag.OCIArtifact != nil {
+			return fmt.Errorf("%s: spec.ols.rag[%d] %s needs OpenShift %d.%d or later", utils.ErrRAGOCIArtifactUnsupported,
+				idx, rag.OCIArtifact.Reference, utils.ImageVolumeMinOpenShiftMajor, utils.ImageVolumeMinOpenShiftMinor)
+		}
+	}
+	return nil
+}
+
+// ragCopied checks if the index of a RAG source is copied into the RAG volume by an init container:
+// container images. The other sources are mounted in place.
+func ragCopied(rag olsv1alpha1.RAGSpec) bool {
+	return rag.Image != ""
+}
+
+// hasCopiedRAG checks if any RAG source is copied into the RAG volume
+func hasCopiedRAG(cr *olsv1alpha1.OLSConfig) bool {
+	for _, rag := range cr.Spec.OLSConfig.RAG {
+		if ragCopied(rag) {
+			return true
+		}
+	}
+	return false
+}
+
+// ragOrigin identifies the source of a RAG index in olsconfig.yaml and in the init container messages
+func ragOrigin(rag olsv1alpha1.RAGSpec) string {
+	switch {
+	case rag.PersistentVolumeClaim != nil:
+		return "pvc/" + rag.PersistentVolumeClaim.ClaimName
+	case rag.OCIArtifact != nil:
+		return rag.OCIArtifact.Reference
+	case rag.ConfigMap != nil:
+		return "configmap/" + rag.ConfigMap.Name
+	default:
+		return rag.Image
+	}
+}
+
+// ragIndexPath returns the directory of the index of the RAG source idx in the app server container
+func ragIndexPath(idx int, rag olsv1alpha1.RAGSpec) string {
+	ragName := fmt.Sprintf("rag-%d", idx)
+	switch {
+	case ragCopied(rag):
+		return path.Join(utils.RAGVolumeMountPath, ragName)
+	case rag.ConfigMap != nil:
+		return path.Join(utils.RAGSourceMountRoot, ragName)
+	default:
+		return path.Join(utils.RAGSourceMountRoot, ragName, rag.IndexPath)
+	}
+}
+
+// ragEmbeddingModelPath returns the directory of the local embedding model of the RAG source idx in the
+// app server container: next to the copied index, or inside the source mounted in place
+func ragEmbeddingModelPath(idx int, rag olsv1alpha1.RAGSpec) string {
+	ragName := fmt.Sprintf("rag-%d", idx)
+	if ragCopied(rag) || rag.EmbeddingModel == nil {
+		return path.Join(utils.RAGVolumeMountPath, ragName+utils.RAGEmbeddingModelDirSuffix)
+	}
+	return path.Join(utils.RAGSourceMountRoot, ragName, rag.EmbeddingModel.Path)
+}
+
+// generateRAGSourceVolumes returns the volumes and the read-only mounts of the RAG sources mounted in place:
+// PVCs, OCI artifacts as image volumes, and ConfigMaps
+func generateRAGSourceVolumes(cr *olsv1alpha1.OLSConfig) ([]corev1.Volume, []corev1.VolumeMount) {
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	volumeDefaultMode := utils.VolumeDefaultMode
+	for idx, rag := range cr.Spec.OLSConfig.RAG {
+		if ragCopied(rag) {
+			continue
+		}
+		volume := corev1.Volume{Name: fmt.Sprintf("%s%d", utils.RAGSourceVolumePrefix, idx)}
+		switch {
+		case rag.PersistentVolumeClaim != nil:
+			volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: rag.PersistentVolumeClaim.ClaimName,
+				ReadOnly:  true,
+			}
+		case rag.OCIArtifact != nil:
+			volume.Image = &corev1.ImageVolumeSource{
+				Reference:  rag.OCIArtifact.Reference,
+				PullPolicy: ragOCIArtifactPullPolicy(rag.OCIArtifact),
+			}
+		case rag.ConfigMap != nil:
+			volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: *rag.ConfigMap,
+				DefaultMode:          &volumeDefaultMode,
+			}
+		default:
+			continue
+		}
+		volumes = append(volumes, volume)
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: path.Join(utils.RAGSourceMountRoot, fmt.Sprintf("rag-%d", idx)),
+			ReadOnly:  true,
+		})
+	}
+	return volumes, volumeMounts
+}
+
+// ragOCIArtifactPullPolicy returns the pull policy of an OCI artifact, defaulted the way the API server
+// defaults image volumes so that the generated volume compares equal to the stored one:
+// Always for the latest tag, IfNotPresent otherwise
+func ragOCIArtifactPullPolicy(artifact *olsv1alpha1.RAGOCIArtifactSource) corev1.PullPolicy {
+	if artifact.PullPolicy != "" {
+		return artifact.PullPolicy
+	}
+	if strings.Contains(artifact.Reference, "@") {
+		return corev1.PullIfNotPresent
+	}
+	name := artifact.Reference[strings.LastIndex(artifact.Reference, "/")+1:]
+	if _, tag, tagged := strings.Cut(name, ":"); tagged && tag != "latest" {
+		return corev1.PullIfNotPresent
+	}
+	return corev1.PullAlways
+}
+
+// GenerateRAGInitContainers returns an init container per RAG source: copying the index of container images
+// into the RAG volume, or only checking the index, and the embedding dimension when set, of the sources mounted
+// in place, with the app server image. The init containers report the index check in their termination message,
+// read back by RAGStatuses.
+func GenerateRAGInitContainers(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) []corev1.Container {
+	_, sourceVolumeMounts := generateRAGSourceVolumes(cr)
+	sourceMounts := map[string]corev1.VolumeMount{}
+	for _, mount := range sourceVolumeMounts {
+		sourceMounts[mount.MountPath] = mount
+	}
+
 	var initContainers []corev1.Container
 	for idx, rag := range cr.Spec.OLSConfig.RAG {
 		ragName := fmt.Sprintf("rag-%d", idx)
-		initContainers = append(initContainers, corev1.Container{
-			Name:            ragName,
-			Image:           rag.Image,
-			ImagePullPolicy: corev1.PullAlways,
-			Command:         []string{"sh", "-c", fmt.Sprintf("mkdir -p %s && cp -a %s/. %s", path.Join(utils.RAGVolumeMountPath, ragName), rag.IndexPath, path.Join(utils.RAGVolumeMountPath, ragName))},
-			VolumeMounts: []corev1.VolumeMount{
-				{
-					Name:      utils.RAGVolumeName,
-					MountPath: utils.RAGVolumeMountPath,
+		if ragCopied(rag) {
+			initContainers = append(initContainers, corev1.Container{
+				Name:            ragName,
+				Image:           rag.Image,
+				ImagePullPolicy: corev1.PullAlways,
+				Command:         []string{"sh", "-c", ragInitCommand(ragName, rag)},
+				VolumeMounts: []corev1.VolumeMount{
+					{
+						Name:      utils.RAGVolumeName,
+						MountPath: utils.RAGVolumeMountPath,
+					},
 				},
-			},
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			})
+			continue
+		}
+		mount, ok := sourceMounts[path.Join(utils.RAGSourceMountRoot, ragName)]
+		if !ok {
+			continue
+		}
+		indexDir := ragIndexPath(idx, rag)
+		command := ragIndexCheckCommand(indexDir, ragOrigin(rag))
+		if rag.EmbeddingModel != nil && rag.EmbeddingModel.Dimension > 0 {
+			command += " && " + ragDimensionCheckCommand(indexDir, rag.EmbeddingModel.Dimension, ragOrigin(rag))
+		}
+		initContainers = append(initContainers, corev1.Container{
+			Name:                     ragName,
+			Image:                    r.GetAppServerImage(),
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Command:                  []string{"sh", "-c", command + " && " + ragIndexReportCommand(indexDir)},
+			VolumeMounts:             []corev1.VolumeMount{mount},
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		})
 	}
 	return initContainers
 }
 
+// ragInitCommand returns the shell command copying a RAG index, and its local embedding model if any,
+// into the RAG volume. An empty indexPath fails the init container instead of copying nothing. When the
+// embedding dimension is set it is checked against the dimension recorded in the index metadata so that
+// a mismatching index fails the init container instead of the queries.
+func ragInitCommand(ragName string, rag olsv1alpha1.RAGSpec) string {
+	indexDir := path.Join(utils.RAGVolumeMountPath, ragName)
+	command := ragIndexCheckCommand(rag.IndexPath, ragOrigin(rag)) +
+		fmt.Sprintf(" && mkdir -p %s && cp -a %s/. %s", indexDir, rag.IndexPath, indexDir)
+	if rag.EmbeddingModel != nil && rag.EmbeddingModel.Path != "" {
+		modelDir := indexDir + utils.RAGEmbeddingModelDirSuffix
+		command += fmt.Sprintf(" && mkdir -p %s && cp -a %s/. %s", modelDir, rag.EmbeddingModel.Path, modelDir)
+	}
+	if rag.EmbeddingModel != nil && rag.EmbeddingModel.Dimension > 0 {
+		command += " && " + ragDimensionCheckCommand(indexDir, rag.EmbeddingModel.Dimension, ragOrigin(rag))
+	}
+	return command + " && " + ragIndexReportCommand(indexDir)
+}
+
+// ragIndexCheckCommand returns the shell command failing when indexDir holds no file, with the reason
+// on stderr and in the termination message
+func ragIndexCheckCommand(indexDir, origin string) string {
+	message := fmt.Sprintf("no files found at indexPath %s of %s", indexDir, origin)
+	return fmt.Sprintf(`if [ -z "$(ls -A %[1]s 2>/dev/null)" ]; then echo "%[2]s" >&2;`+
+		` echo '{"indexFound":false,"message":"%[2]s"}' > %[3]s; exit 1; fi`,
+		indexDir, message, corev1.TerminationMessagePathDefault)
+}
+
+// ragIndexReportCommand returns the shell command writing the index ID of the llama-index store in indexDir
+// to the termination message. It never fails, an index without store reports an empty ID.
+func ragIndexReportCommand(indexDir string) string {
+	return fmt.Sprintf(`{ id=$(tr -d ' \t\n' 2>/dev/null < %[1]s | grep -o '"index_store/data":{"[^"]*"' | cut -d'"' -f4);`+
+		` echo "{\"indexFound\":true,\"indexID\":\"$id\"}" > %[2]s || true; }`,
+		path.Join(indexDir, utils.RAGIndexStoreFile), corev1.TerminationMessagePathDefault)
+}
+
+// ragDimensionCheckCommand returns the shell command failing when the index metadata in indexDir records
+// an embedding dimension different from dimension. A missing metadata file skips the check.
+func ragDimensionCheckCommand(indexDir string, dimension int32, origin string) string {
+	metadata := path.Join(indexDir, utils.RAGIndexMetadataFile)
+	return fmt.Sprintf("if [ -f %[1]s ]; then"+
+		" d=$(tr -d ' \\t\\n' < %[1]s | grep -o '\"embedding[_-]dimension\":[0-9]*' | cut -d: -f2);"+
+		" if [ -n \"$d\" ] && [ \"$d\" != \"%[2]d\" ]; then"+
+		" echo \"the RAG index of %[3]s was built with embedding dimension $d, embeddingModel.dimension is %[2]d\" >&2; exit 1; fi;"+
+		" else echo \"%[1]s not found, skipping the embedding dimension check\"; fi",
+		metadata, dimension, origin)
+}
+
 func generateRAGVolumeMount() corev1.VolumeMount {
 	return corev1.VolumeMount{
 		Name:      utils.RAGVolumeName,
@@ -59,9 +273,14 @@ func generateRAGVolumeMount() corev1.VolumeMount {
 	}
 }
 
+// generateImageStreamTriggers returns the image triggers of the RAG container images, the other sources
+// are not tracked by ImageStreams. It returns an empty string when no RAG source is a container image.
 func generateImageStreamTriggers(cr *olsv1alpha1.OLSConfig) (string, error) {
 	var triggers []ImageTrigger
 	for idx, rag := range cr.Spec.OLSConfig.RAG {
+		if rag.Image == "" {
+			continue
+		}
 		isName := utils.ImageStreamNameFor(rag.Image)
 		initContainerName := fmt.Sprintf("rag-%d", idx)
 		triggers = append(triggers, ImageTrigger{
@@ -72,9 +291,100 @@ func generateImageStreamTriggers(cr *olsv1alpha1.OLSConfig) (string, error) {
 			FieldPath: fmt.Sprintf("spec.template.spec.initContainers[?(@.name==\"%s\")].image", initContainerName),
 		})
 	}
+	if len(triggers) == 0 {
+		return "", nil
+	}
 	data, err := json.Marshal(triggers)
 	if err != nil {
 		return "", fmt.Errorf("marshal image triggers: %w", err)
 	}
 	return string(data), nil
 }
+
+// ragInitContainerReport is the index check an init container writes to its termination message
+type ragInitContainerReport struct {
+	IndexFound *bool  `json:"indexFound"`
+	IndexID    string `json:"indexID"`
+	Message    string `json:"message"`
+}
+
+// RAGStatuses reports the state of each source of spec.ols.rag: the digest and the last import of the
+// ImageStream tracking an image, and the index check of the init container of the newest app server pod
+func RAGStatuses(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) []olsv1alpha1.RAGSourceStatus {
+	pods := &corev1.PodList{}
+	podsErr := r.List(ctx, pods, client.InNamespace(r.GetNamespace()), client.MatchingLabels(utils.GenerateAppServerSelectorLabels()))
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[j].CreationTimestamp.Before(&pods.Items[i].CreationTimestamp)
+	})
+
+	imageVolumes := ragImageVolumes(r)
+	var statuses []olsv1alpha1.RAGSourceStatus
+	for idx, rag := range cr.Spec.OLSConfig.RAG {
+		status := olsv1alpha1.RAGSourceStatus{Index: int32(idx), Origin: ragOrigin(rag)} // #nosec G115
+		switch {
+		case rag.Image != "":
+			is := &imagev1.ImageStream{}
+			err := r.Get(ctx, client.ObjectKey{Name: utils.ImageStreamNameFor(rag.Image), Namespace: r.GetNamespace()}, is)
+			if err != nil {
+				status.Message = fmt.Sprintf("%s: %v", utils.ErrGetRAGImageStream, err)
+				break
+			}
+			for _, tag := range is.Status.Tags {
+				if tag.Tag == "latest" && len(tag.Items) > 0 {
+					status.ImageDigest = tag.Items[0].Image
+					status.LastImportTime = tag.Items[0].Created.DeepCopy()
+				}
+			}
+		case rag.OCIArtifact != nil:
+			if _, digest, pinned := strings.Cut(rag.OCIArtifact.Reference, "@"); pinned {
+				status.ImageDigest = digest
+			}
+			if !imageVolumes {
+				status.Message = fmt.Sprintf("%s: needs OpenShift %d.%d or later", utils.ErrRAGOCIArtifactUnsupported,
+					utils.ImageVolumeMinOpenShiftMajor, utils.ImageVolumeMinOpenShiftMinor)
+				statuses = append(statuses, status)
+				continue
+			}
+		}
+		if podsErr != nil {
+			status.Message = fmt.Sprintf("%s: %v", utils.ErrListAppServerPods, podsErr)
+		} else {
+			readRAGInitContainerReport(pods.Items, fmt.Sprintf("rag-%d", idx), &status)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// readRAGInitContainerReport fills the status with the report of the init container name of the newest pod
+// that ran it. A termination message that is not a report, such as the logs of a failed dimension check,
+// becomes the status message.
+func readRAGInitContainerReport(pods []corev1.Pod, name string, status *olsv1alpha1.RAGSourceStatus) {
+	for _, pod := range pods {
+		for _, containerStatus := range pod.Status.InitContainerStatuses {
+			if containerStatus.Name != name {
+				continue
+			}
+			terminated := containerStatus.State.Terminated
+			if terminated == nil {
+				terminated = containerStatus.LastTerminationState.Terminated
+			}
+			if terminated == nil {
+				break
+			}
+			var report ragInitContainerReport
+			if err := json.Unmarshal([]byte(terminated.Message), &report); err != nil {
+				if terminated.ExitCode != 0 {
+					status.Message = strings.TrimSpace(terminated.Message)
+				}
+				return
+			}
+			status.IndexFound = report.IndexFound
+			status.IndexID = report.IndexID
+			if report.Message != "" {
+				status.Message = report.Message
+			}
+			return
+		}
+	}
+}
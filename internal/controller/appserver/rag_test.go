@@ -44,13 +44,14 @@ var _ = Describe("App server assets", func() {
 		})
 
 		It("should generate initContainer for each RAG", func() {
-			initContainers := GenerateRAGInitContainers(cr)
+			initContainers := GenerateRAGInitContainers(testReconcilerInstance, cr)
 			Expect(initContainers).To(HaveLen(2))
 			Expect(initContainers[0]).To(MatchFields(IgnoreExtras, Fields{
 				"Name":            Equal("rag-0"),
 				"Image":           Equal("rag-image-1"),
 				"ImagePullPolicy": Equal(corev1.PullAlways),
-				"Command":         Equal([]string{"sh", "-c", "mkdir -p /rag-data/rag-0 && cp -a /path/to/index-1/. /rag-data/rag-0"}),
+				"Command": Equal([]string{"sh", "-c", ragIndexCheckCommand("/path/to/index-1", "rag-image-1") +
+					" && mkdir -p /rag-data/rag-0 && cp -a /path/to/index-1/. /rag-data/rag-0 && " + ragIndexReportCommand("/rag-data/rag-0")}),
 				"VolumeMounts": ConsistOf(corev1.VolumeMount{
 					Name:      utils.RAGVolumeName,
 					MountPath: "/rag-data",
@@ -60,7 +61,8 @@ var _ = Describe("App server assets", func() {
 				"Name":            Equal("rag-1"),
 				"Image":           Equal("rag-image-2"),
 				"ImagePullPolicy": Equal(corev1.PullAlways),
-				"Command":         Equal([]string{"sh", "-c", "mkdir -p /rag-data/rag-1 && cp -a /path/to/index-2/. /rag-data/rag-1"}),
+				"Command": Equal([]string{"sh", "-c", ragIndexCheckCommand("/path/to/index-2", "rag-image-2") +
+					" && mkdir -p /rag-data/rag-1 && cp -a /path/to/index-2/. /rag-data/rag-1 && " + ragIndexReportCommand("/rag-data/rag-1")}),
 				"VolumeMounts": ConsistOf(corev1.VolumeMount{
 					Name:      utils.RAGVolumeName,
 					MountPath: "/rag-data",
@@ -68,6 +70,28 @@ var _ = Describe("App server assets", func() {
 			}))
 		})
 
+		It("should copy the local embedding model and check the embedding dimension", func() {
+			cr.Spec.OLSConfig.RAG[0].EmbeddingModel = &olsv1alpha1.EmbeddingModelSpec{Path: "/path/to/model", Dimension: 384}
+			cr.Spec.OLSConfig.RAG[1].EmbeddingModel = &olsv1alpha1.EmbeddingModelSpec{
+				Endpoint: &olsv1alpha1.EmbeddingEndpointSpec{URL: "https://embeddings.example.com/v1", Model: "embed"},
+			}
+			initContainers := GenerateRAGInitContainers(testReconcilerInstance, cr)
+			Expect(initContainers).To(HaveLen(2))
+
+			command := initContainers[0].Command[2]
+			Expect(command).To(HavePrefix(ragIndexCheckCommand("/path/to/index-1", "rag-image-1") +
+				" && mkdir -p /rag-data/rag-0 && cp -a /path/to/index-1/. /rag-data/rag-0" +
+				" && mkdir -p /rag-data/rag-0-embeddings && cp -a /path/to/model/. /rag-data/rag-0-embeddings && "))
+			Expect(command).To(ContainSubstring("if [ -f /rag-data/rag-0/metadata.json ]"))
+			Expect(command).To(ContainSubstring(`[ "$d" != "384" ]`))
+			Expect(command).To(ContainSubstring("exit 1"))
+
+			Expect(command).To(HaveSuffix(" && " + ragIndexReportCommand("/rag-data/rag-0")))
+
+			Expect(initContainers[1].Command).To(Equal([]string{"sh", "-c", ragIndexCheckCommand("/path/to/index-2", "rag-image-2") +
+				" && mkdir -p /rag-data/rag-1 && cp -a /path/to/index-2/. /rag-data/rag-1 && " + ragIndexReportCommand("/rag-data/rag-1")}))
+		})
+
 		It("should create an ImageStream for each RAG image", func() {
 			err := reconcileImageStreams(testReconcilerInstance, context.Background(), cr)
 			Expect(err).NotTo(HaveOccurred())
@@ -94,6 +118,155 @@ var _ = Describe("App server assets", func() {
 		})
 	})
 
+	Context("RAG sources mounted in place", func() {
+		BeforeEach(func() {
+			cr = utils.GetDefaultOLSConfigCR()
+			cr.Spec.OLSConfig.RAG = []olsv1alpha1.RAGSpec{
+				{
+					PersistentVolumeClaim: &olsv1alpha1.RAGPersistentVolumeClaimSource{ClaimName: "rag-pvc"},
+					IndexPath:             "/index",
+					EmbeddingModel:        &olsv1alpha1.EmbeddingModelSpec{Path: "/model", Dimension: 768},
+				},
+				{
+					OCIArtifact: &olsv1alpha1.RAGOCIArtifactSource{Reference: "quay.io/myorg/rag-index:1.0", PullPolicy: corev1.PullIfNotPresent},
+					IndexPath:   "/rag/vector_db",
+				},
+				{
+					ConfigMap: &corev1.LocalObjectReference{Name: "rag-configmap"},
+				},
+			}
+		})
+
+		It("should mount the PVC, the OCI artifact and the ConfigMap read-only", func() {
+			volumes, volumeMounts := generateRAGSourceVolumes(cr)
+			Expect(volumes).To(HaveLen(3))
+			Expect(volumes[0].PersistentVolumeClaim).To(Equal(&corev1.PersistentVolumeClaimVolumeSource{ClaimName: "rag-pvc", ReadOnly: true}))
+			Expect(volumes[1].Image).To(Equal(&corev1.ImageVolumeSource{Reference: "quay.io/myorg/rag-index:1.0", PullPolicy: corev1.PullIfNotPresent}))
+			Expect(volumes[2].ConfigMap.Name).To(Equal("rag-configmap"))
+			Expect(volumeMounts).To(ConsistOf(
+				corev1.VolumeMount{Name: utils.RAGSourceVolumePrefix + "0", MountPath: "/rag-sources/rag-0", ReadOnly: true},
+				corev1.VolumeMount{Name: utils.RAGSourceVolumePrefix + "1", MountPath: "/rag-sources/rag-1", ReadOnly: true},
+				corev1.VolumeMount{Name: utils.RAGSourceVolumePrefix + "2", MountPath: "/rag-sources/rag-2", ReadOnly: true},
+			))
+			Expect(hasCopiedRAG(cr)).To(BeFalse())
+
+			Expect(ragIndexPath(0, cr.Spec.OLSConfig.RAG[0])).To(Equal("/rag-sources/rag-0/index"))
+			Expect(ragIndexPath(1, cr.Spec.OLSConfig.RAG[1])).To(Equal("/rag-sources/rag-1/rag/vector_db"))
+			Expect(ragIndexPath(2, cr.Spec.OLSConfig.RAG[2])).To(Equal("/rag-sources/rag-2"))
+			Expect(ragEmbeddingModelPath(0, cr.Spec.OLSConfig.RAG[0])).To(Equal("/rag-sources/rag-0/model"))
+		})
+
+		It("should check the index of the sources mounted in place and the embedding dimension when set", func() {
+			initContainers := GenerateRAGInitContainers(testReconcilerInstance, cr)
+			Expect(initContainers).To(HaveLen(3))
+			Expect(initContainers[0].Name).To(Equal("rag-0"))
+			Expect(initContainers[0].Image).To(Equal(testReconcilerInstance.GetAppServerImage()))
+			Expect(initContainers[0].Command[2]).To(HavePrefix(ragIndexCheckCommand("/rag-sources/rag-0/index", "pvc/rag-pvc") +
+				" && if [ -f /rag-sources/rag-0/index/metadata.json ]"))
+			Expect(initContainers[0].Command[2]).To(HaveSuffix(" && " + ragIndexReportCommand("/rag-sources/rag-0/index")))
+			Expect(initContainers[0].Command[2]).To(ContainSubstring("the RAG index of pvc/rag-pvc"))
+			Expect(initContainers[0].VolumeMounts).To(ConsistOf(
+				corev1.VolumeMount{Name: utils.RAGSourceVolumePrefix + "0", MountPath: "/rag-sources/rag-0", ReadOnly: true},
+			))
+
+			Expect(initContainers[1].Name).To(Equal("rag-1"))
+			Expect(initContainers[1].Command).To(Equal([]string{"sh", "-c",
+				ragIndexCheckCommand("/rag-sources/rag-1/rag/vector_db", "quay.io/myorg/rag-index:1.0") +
+					" && " + ragIndexReportCommand("/rag-sources/rag-1/rag/vector_db")}))
+			Expect(initContainers[2].Name).To(Equal("rag-2"))
+			Expect(initContainers[2].Command).To(Equal([]string{"sh", "-c",
+				ragIndexCheckCommand("/rag-sources/rag-2", "configmap/rag-configmap") + " && " + ragIndexReportCommand("/rag-sources/rag-2")}))
+			Expect(initContainers[2].VolumeMounts).To(ConsistOf(
+				corev1.VolumeMount{Name: utils.RAGSourceVolumePrefix + "2", MountPath: "/rag-sources/rag-2", ReadOnly: true},
+			))
+		})
+
+		It("should fail with OCI artifacts on clusters without image volumes", func() {
+			tr, ok := testReconcilerInstance.(*utils.TestReconciler)
+			Expect(ok).To(BeTrue())
+			tr.SetOpenShiftVersion("4", "18")
+
+			_, err := GenerateOLSDeployment(tr, cr)
+			Expect(err).To(MatchError(ContainSubstring(utils.ErrRAGOCIArtifactUnsupported)))
+			Expect(err).To(MatchError(ContainSubstring("spec.ols.rag[1] quay.io/myorg/rag-index:1.0 needs OpenShift 4.20 or later")))
+
+			statuses := RAGStatuses(tr, ctx, cr)
+			Expect(statuses).To(HaveLen(3))
+			Expect(statuses[1].Message).To(HavePrefix(utils.ErrRAGOCIArtifactUnsupported))
+			Expect(statuses[0].Message).To(BeEmpty())
+
+			cr.Spec.OLSConfig.RAG = cr.Spec.OLSConfig.RAG[:1]
+			_, err = GenerateOLSDeployment(tr, cr)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not generate ImageStream triggers without RAG images", func() {
+			triggersJSON, err := generateImageStreamTriggers(cr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(triggersJSON).To(BeEmpty())
+		})
+	})
+
+	Context("RAG source status", func() {
+		terminatedPod := func(name string, message string, exitCode int32) corev1.Pod {
+			return corev1.Pod{Status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:  name,
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message, ExitCode: exitCode}},
+			}}}}
+		}
+
+		It("should read the index check of the newest pod that ran the init container", func() {
+			pods := []corev1.Pod{
+				{Status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{{Name: "rag-0"}}}},
+				terminatedPod("rag-0", `{"indexFound":true,"indexID":"ocp-4.19"}`, 0),
+				terminatedPod("rag-0", `{"indexFound":true,"indexID":"ocp-4.18"}`, 0),
+			}
+			status := olsv1alpha1.RAGSourceStatus{}
+			readRAGInitContainerReport(pods, "rag-0", &status)
+			Expect(status.IndexFound).To(HaveValue(BeTrue()))
+			Expect(status.IndexID).To(Equal("ocp-4.19"))
+		})
+
+		It("should report an empty index path", func() {
+			pod := corev1.Pod{Status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "rag-0",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Message:  `{"indexFound":false,"message":"no files found at indexPath /rag/vector_db of quay.io/myorg/rag:v1"}`,
+				}},
+			}}}}
+			status := olsv1alpha1.RAGSourceStatus{}
+			readRAGInitContainerReport([]corev1.Pod{pod}, "rag-0", &status)
+			Expect(status.IndexFound).To(HaveValue(BeFalse()))
+			Expect(status.Message).To(Equal("no files found at indexPath /rag/vector_db of quay.io/myorg/rag:v1"))
+		})
+
+		It("should report the logs of a failed dimension check", func() {
+			status := olsv1alpha1.RAGSourceStatus{}
+			readRAGInitContainerReport([]corev1.Pod{terminatedPod("rag-0", "the RAG index of pvc/rag-pvc was built with embedding dimension 384, embeddingModel.dimension is 768\n", 1)}, "rag-0", &status)
+			Expect(status.IndexFound).To(BeNil())
+			Expect(status.Message).To(Equal("the RAG index of pvc/rag-pvc was built with embedding dimension 384, embeddingModel.dimension is 768"))
+		})
+
+		It("should report the pinned digest of an OCI artifact and the origin of each source", func() {
+			cr = utils.GetDefaultOLSConfigCR()
+			cr.Spec.OLSConfig.RAG = []olsv1alpha1.RAGSpec{
+				{OCIArtifact: &olsv1alpha1.RAGOCIArtifactSource{Reference: "quay.io/myorg/rag-index@sha256:0123456789abcdef"}},
+				{PersistentVolumeClaim: &olsv1alpha1.RAGPersistentVolumeClaimSource{ClaimName: "rag-pvc"}},
+			}
+			statuses := RAGStatuses(testReconcilerInstance, ctx, cr)
+			Expect(statuses).To(HaveLen(2))
+			Expect(statuses[0].Index).To(BeEquivalentTo(0))
+			Expect(statuses[0].Origin).To(Equal("quay.io/myorg/rag-index@sha256:0123456789abcdef"))
+			Expect(statuses[0].ImageDigest).To(Equal("sha256:0123456789abcdef"))
+			Expect(statuses[1].Index).To(BeEquivalentTo(1))
+			Expect(statuses[1].Origin).To(Equal("pvc/rag-pvc"))
+			Expect(statuses[1].ImageDigest).To(BeEmpty())
+			Expect(statuses[1].IndexFound).To(BeNil())
+		})
+	})
+
 	Context("two RAGSpec entries with the same image", func() {
 		const sharedImage = "quay.io/myorg/rag-index:latest"
 
@@ -0,0 +1,348 @@
+package appserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	imagev1 "github.com/openshift/api/image/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+// ragUpdateManaged checks if the operator rolls out the new digests of the RAG container images itself,
+// instead of the ImageStream triggers: with the Manual and Window update policies, and with a canary
+func ragUpdateManaged(cr *olsv1alpha1.OLSConfig) bool {
+	policy := cr.Spec.OLSConfig.RAGUpdatePolicy
+	if policy == nil {
+		return false
+	}
+	hasImage := false
+	for _, rag := range cr.Spec.OLSConfig.RAG {
+		if rag.Image != "" {
+			hasImage = true
+			break
+		}
+	}
+	return hasImage && (policy.Canary || (policy.Type != "" && policy.Type != olsv1alpha1.RAGUpdatePolicyImmediate))
+}
+
+// ragUpdatePlan is the rollout state of the RAG container images under a managed update policy
+type ragUpdatePlan struct {
+	// current holds the images run by the app server and latest the images last imported by the
+	// ImageStreams, keyed by the name of their init container
+	current, latest map[string]string
+	// revisions identify the current and the latest images
+	currentRevision, latestRevision string
+	// allowed tells whether the policy lets the latest images roll out now
+	allowed bool
+	// nextWindow is the start of the next update window when the Window policy holds the latest images back
+	nextWindow *time.Time
+	// message explains why the policy cannot be evaluated
+	message string
+	// canary is the canary Deployment of the latest images, nil if none
+	canary *appsv1.Deployment
+}
+
+// pending checks if the latest images differ from the images run by the app server
+func (p *ragUpdatePlan) pending() bool {
+	return p.currentRevision != p.latestRevision
+}
+
+// canaryReady checks if the canary Deployment runs a ready pod of the latest images
+func (p *ragUpdatePlan) canaryReady() bool {
+	canary := p.canary
+	return canary != nil && canary.Status.ObservedGeneration >= canary.Generation &&
+		canary.Status.UpdatedReplicas >= 1 && canary.Status.Replicas == canary.Status.UpdatedReplicas &&
+		canary.Status.ReadyReplicas >= 1
+}
+
+// canaryFailure returns the reason the canary Deployment did not become ready within its progress deadline,
+// empty while it is progressing
+func (p *ragUpdatePlan) canaryFailure() string {
+	if p.canary == nil {
+		return ""
+	}
+	for _, condition := range p.canary.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse &&
+			condition.Reason == "ProgressDeadlineExceeded" {
+			return condition.Message
+		}
+	}
+	return ""
+}
+
+// images returns the RAG images the app server runs and their revision: the latest images once the policy
+// allows them and, with a canary, once the canary is ready
+func (p *ragUpdatePlan) images(canary bool) (map[string]string, string) {
+	if p.pending() && p.allowed && (!canary || p.canaryReady()) {
+		return p.latest, p.latestRevision
+	}
+	return p.current, p.currentRevision
+}
+
+// ragImageRevision returns a short hash of the RAG images, the revision shown in the status and set in the
+// approval annotation of the Manual policy
+func ragImageRevision(images map[string]string) string {
+	names := make([]string, 0, len(images))
+	for name := range images {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s=%s\n", name, images[name])
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(b.String())))[:12]
+}
+
+// latestImageReference returns the pullable reference of the digest last imported by the "latest" tag
+// of the ImageStream, empty before the first import
+func latestImageReference(is *imagev1.ImageStream) string {
+	for _, tag := range is.Status.Tags {
+		if tag.Tag == "latest" && len(tag.Items) > 0 {
+			return tag.Items[0].DockerImageReference
+		}
+	}
+	return ""
+}
+
+// planRAGUpdate compares the RAG images run by the app server with the images last imported by their
+// ImageStreams, and evaluates the update policy. A change of spec.ols.rag rolls out the latest images.
+func planRAGUpdate(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) (*ragUpdatePlan, error) {
+	policy := cr.Spec.OLSConfig.RAGUpdatePolicy
+	plan := &ragUpdatePlan{current: map[string]string{}, latest: map[string]string{}}
+
+	for idx, rag := range cr.Spec.OLSConfig.RAG {
+		if rag.Image == "" {
+			continue
+		}
+		name := fmt.Sprintf("rag-%d", idx)
+		plan.latest[name] = rag.Image
+		is := &imagev1.ImageStream{}
+		err := r.Get(ctx, client.ObjectKey{Name: utils.ImageStreamNameFor(rag.Image), Namespace: r.GetNamespace()}, is)
+		if client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("%s: %w", utils.ErrGetRAGImageStream, err)
+		}
+		if reference := latestImageReference(is); reference != "" {
+			plan.latest[name] = reference
+		}
+	}
+
+	running := map[string]string{}
+	existing := &appsv1.Deployment{}
+	err := r.Get(ctx, client.ObjectKey{Name: utils.OLSAppServerDeploymentName, Namespace: r.GetNamespace()}, existing)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("%s: %w", utils.ErrGetAPIDeployment, err)
+	}
+	if err == nil && existing.Annotations[utils.RAGSpecHashAnnotation] == ragSpecHash(cr) {
+		for _, container := range existing.Spec.Template.Spec.InitContainers {
+			running[container.Name] = container.Image
+		}
+	}
+	for name, image := range plan.latest {
+		plan.current[name] = image
+		if image, ok := running[name]; ok {
+			plan.current[name] = image
+		}
+	}
+	plan.currentRevision = ragImageRevision(plan.current)
+	plan.latestRevision = ragImageRevision(plan.latest)
+
+	switch policy.Type {
+	case olsv1alpha1.RAGUpdatePolicyManual:
+		plan.allowed = cr.Annotations[utils.RAGUpdateApprovalAnnotation] == plan.latestRevision
+	case olsv1alpha1.RAGUpdatePolicyWindow:
+		if policy.Window == nil {
+			break
+		}
+		schedule, err := utils.ParseCronSchedule(policy.Window.Schedule)
+		if err != nil {
+			plan.message = fmt.Sprintf("%s: %v", utils.ErrParseRAGUpdateWindowSchedule, err)
+			break
+		}
+		duration := time.Duration(policy.Window.DurationMinutes) * time.Minute
+		if duration <= 0 {
+			duration = utils.RAGUpdateWindowDurationDefault
+		}
+		now := time.Now()
+		plan.allowed = schedule.InWindow(now, duration)
+		if next, ok := schedule.Next(now); !ok {
+			plan.message = fmt.Sprintf("the update window schedule %q never matches", policy.Window.Schedule)
+		} else if !plan.allowed {
+			plan.nextWindow = &next
+		}
+	default:
+		plan.allowed = true
+	}
+
+	if policy.Canary {
+		canary := &appsv1.Deployment{}
+		err := r.Get(ctx, client.ObjectKey{Name: utils.RAGCanaryDeploymentName, Namespace: r.GetNamespace()}, canary)
+		if client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("%s: %w", utils.ErrGetRAGCanaryDeployment, err)
+		}
+		// a canary started in a window or after an approval completes the rollout of its images
+		if err == nil && canary.Annotations[utils.RAGImageRevisionAnnotation] == plan.latestRevision {
+			plan.canary = canary
+			plan.allowed = true
+		}
+	}
+	return plan, nil
+}
+
+// setRAGImages sets the images of the RAG init containers, keyed by container name
+func setRAGImages(deployment *appsv1.Deployment, images map[string]string) {
+	initContainers := deployment.Spec.Template.Spec.InitContainers
+	for i := range initContainers {
+		if image, ok := images[initContainers[i].Name]; ok {
+			initContainers[i].Image = image
+		}
+	}
+}
+
+// applyRAGUpdate sets the RAG images allowed by the update policy on the app server deployment,
+// in place of the ImageStream triggers
+func applyRAGUpdate(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig, deployment *appsv1.Deployment) error {
+	plan, err := planRAGUpdate(r, ctx, cr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrPlanRAGUpdate, err)
+	}
+	images, revision := plan.images(cr.Spec.OLSConfig.RAGUpdatePolicy.Canary)
+	setRAGImages(deployment, images)
+	deployment.Annotations[utils.RAGImageRevisionAnnotation] = revision
+	return nil
+}
+
+// generateRAGCanaryDeployment returns a single replica copy of the app server deployment running the latest
+// RAG images. Its pods have their own labels, outside the app server Deployment selector, and keep the labels
+// the app server Service selects so that it routes queries to them.
+func generateRAGCanaryDeployment(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig, plan *ragUpdatePlan) (*appsv1.Deployment, error) {
+	deployment, err := GenerateOLSDeployment(r, cr)
+	if err != nil {
+		return nil, err
+	}
+	replicas := int32(1)
+	deployment.Name = utils.RAGCanaryDeploymentName
+	deployment.Annotations = map[string]string{utils.RAGImageRevisionAnnotation: plan.latestRevision}
+	deployment.Spec.Replicas = &replicas
+	deployment.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+	deployment.Labels = utils.GenerateRAGCanarySelectorLabels()
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: utils.GenerateRAGCanarySelectorLabels()}
+	deployment.Spec.Template.Labels = utils.GenerateRAGCanarySelectorLabels()
+	setRAGImages(deployment, plan.latest)
+	return deployment, nil
+}
+
+// reconcileRAGCanary runs the latest RAG images in the canary Deployment while an update allowed by the
+// policy is pending, and deletes the canary once the app server runs them or the policy has no canary
+func reconcileRAGCanary(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	var plan *ragUpdatePlan
+	if ragUpdateManaged(cr) && cr.Spec.OLSConfig.RAGUpdatePolicy.Canary {
+		var err error
+		plan, err = planRAGUpdate(r, ctx, cr)
+		if err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrPlanRAGUpdate, err)
+		}
+	}
+
+	if plan == nil || !plan.pending() || !plan.allowed {
+		canary := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: utils.RAGCanaryDeploymentName, Namespace: r.GetNamespace()}}
+		if err := r.Delete(ctx, canary); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("%s: %w", utils.ErrDeleteRAGCanaryDeployment, err)
+		}
+		return nil
+	}
+	if plan.canary != nil {
+		return nil
+	}
+
+	desired, err := generateRAGCanaryDeployment(r, cr, plan)
+	if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrGenerateRAGCanaryDeployment, err)
+	}
+	existing := &appsv1.Deployment{}
+	err = r.Get(ctx, client.ObjectKey{Name: utils.RAGCanaryDeploymentName, Namespace: r.GetNamespace()}, existing)
+	if apierrors.IsNotFound(err) {
+		r.GetLogger().Info("creating the RAG canary deployment", "revision", plan.latestRevision)
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrCreateRAGCanaryDeployment, err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrGetRAGCanaryDeployment, err)
+	}
+
+	// the selector is immutable, a canary created with other labels is replaced
+	if !apiequality.Semantic.DeepEqual(existing.Spec.Selector, desired.Spec.Selector) {
+		r.GetLogger().Info("deleting the RAG canary deployment with an outdated selector")
+		if err := r.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("%s: %w", utils.ErrDeleteRAGCanaryDeployment, err)
+		}
+		return nil
+	}
+
+	r.GetLogger().Info("updating the RAG canary deployment", "revision", plan.latestRevision)
+	existing.Spec = desired.Spec
+	existing.Annotations = desired.Annotations
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrUpdateRAGCanaryDeployment, err)
+	}
+	return nil
+}
+
+// RAGUpdateStatus reports the rollout of the new digests of the RAG container images under a managed
+// update policy, nil when the ImageStream triggers roll them out
+func RAGUpdateStatus(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) *olsv1alpha1.RAGUpdateStatus {
+	if !ragUpdateManaged(cr) {
+		return nil
+	}
+	plan, err := planRAGUpdate(r, ctx, cr)
+	if err != nil {
+		return &olsv1alpha1.RAGUpdateStatus{
+			Phase:   olsv1alpha1.RAGUpdatePending,
+			Message: fmt.Sprintf("%s: %v", utils.ErrPlanRAGUpdate, err),
+		}
+	}
+
+	status := &olsv1alpha1.RAGUpdateStatus{Phase: olsv1alpha1.RAGUpdateUpToDate, CurrentRevision: plan.currentRevision}
+	if !plan.pending() {
+		return status
+	}
+	status.PendingRevision = plan.latestRevision
+	switch {
+	case plan.canary != nil && plan.canaryFailure() != "":
+		status.Phase = olsv1alpha1.RAGUpdateCanaryFailed
+		status.Message = plan.canaryFailure()
+	case plan.canary != nil:
+		status.Phase = olsv1alpha1.RAGUpdateCanary
+		status.Message = "waiting for the canary pod to become ready"
+	default:
+		status.Phase = olsv1alpha1.RAGUpdatePending
+		switch {
+		case plan.message != "":
+			status.Message = plan.message
+		case plan.allowed:
+			status.Message = "rolling out the pending revision"
+		case cr.Spec.OLSConfig.RAGUpdatePolicy.Type == olsv1alpha1.RAGUpdatePolicyManual:
+			status.Message = fmt.Sprintf("set the %s annotation of the OLSConfig to %s to roll out the pending revision",
+				utils.RAGUpdateApprovalAnnotation, plan.latestRevision)
+		case plan.nextWindow != nil:
+			next := metav1.NewTime(*plan.nextWindow)
+			status.NextWindowTime = &next
+			status.Message = "waiting for the next update window"
+		}
+	}
+	return status
+}
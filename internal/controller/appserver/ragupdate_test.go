@@ -0,0 +1,117 @@
+package appserver
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+var _ = Describe("RAG image update policy", func() {
+	var cr *olsv1alpha1.OLSConfig
+
+	BeforeEach(func() {
+		cr = utils.GetDefaultOLSConfigCR()
+		cr.Spec.OLSConfig.RAG = []olsv1alpha1.RAGSpec{
+			{Image: "quay.io/myorg/rag:v1", IndexPath: "/rag/vector_db"},
+			{PersistentVolumeClaim: &olsv1alpha1.RAGPersistentVolumeClaimSource{ClaimName: "rag-pvc"}, IndexPath: "/index"},
+		}
+	})
+
+	It("should only manage the rollout of RAG images with the Manual and Window policies or a canary", func() {
+		Expect(ragUpdateManaged(cr)).To(BeFalse())
+		cr.Spec.OLSConfig.RAGUpdatePolicy = &olsv1alpha1.RAGUpdatePolicy{Type: olsv1alpha1.RAGUpdatePolicyImmediate}
+		Expect(ragUpdateManaged(cr)).To(BeFalse())
+		cr.Spec.OLSConfig.RAGUpdatePolicy.Canary = true
+		Expect(ragUpdateManaged(cr)).To(BeTrue())
+		cr.Spec.OLSConfig.RAGUpdatePolicy = &olsv1alpha1.RAGUpdatePolicy{Type: olsv1alpha1.RAGUpdatePolicyManual}
+		Expect(ragUpdateManaged(cr)).To(BeTrue())
+
+		cr.Spec.OLSConfig.RAG = cr.Spec.OLSConfig.RAG[1:]
+		Expect(ragUpdateManaged(cr)).To(BeFalse(), "only container images are tracked by ImageStreams")
+	})
+
+	It("should identify the RAG images by a short revision", func() {
+		images := map[string]string{"rag-0": "quay.io/myorg/rag@sha256:1111", "rag-2": "quay.io/myorg/docs@sha256:2222"}
+		revision := ragImageRevision(images)
+		Expect(revision).To(HaveLen(12))
+		Expect(ragImageRevision(map[string]string{"rag-2": "quay.io/myorg/docs@sha256:2222", "rag-0": "quay.io/myorg/rag@sha256:1111"})).To(Equal(revision))
+		images["rag-0"] = "quay.io/myorg/rag@sha256:3333"
+		Expect(ragImageRevision(images)).NotTo(Equal(revision))
+	})
+
+	Context("rollout plan", func() {
+		var plan *ragUpdatePlan
+
+		BeforeEach(func() {
+			plan = &ragUpdatePlan{
+				current: map[string]string{"rag-0": "quay.io/myorg/rag@sha256:1111"},
+				latest:  map[string]string{"rag-0": "quay.io/myorg/rag@sha256:2222"},
+			}
+			plan.currentRevision = ragImageRevision(plan.current)
+			plan.latestRevision = ragImageRevision(plan.latest)
+		})
+
+		It("should keep the current images until the policy allows the latest ones", func() {
+			images, revision := plan.images(false)
+			Expect(images).To(Equal(plan.current))
+			Expect(revision).To(Equal(plan.currentRevision))
+
+			plan.allowed = true
+			images, revision = plan.images(false)
+			Expect(images).To(Equal(plan.latest))
+			Expect(revision).To(Equal(plan.latestRevision))
+		})
+
+		It("should wait for a ready canary pod", func() {
+			plan.allowed = true
+			images, _ := plan.images(true)
+			Expect(images).To(Equal(plan.current))
+
+			plan.canary = &appsv1.Deployment{}
+			plan.canary.Generation = 2
+			plan.canary.Status = appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 1, ReadyReplicas: 1}
+			images, _ = plan.images(true)
+			Expect(images).To(Equal(plan.current), "the pod of the previous revision is still running")
+
+			plan.canary.Status.Replicas = 1
+			images, _ = plan.images(true)
+			Expect(images).To(Equal(plan.latest))
+		})
+
+		It("should report a canary past its progress deadline", func() {
+			plan.canary = &appsv1.Deployment{}
+			Expect(plan.canaryFailure()).To(BeEmpty())
+			plan.canary.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: `ReplicaSet "lightspeed-app-server-rag-canary-7d4b9" has timed out progressing.`,
+			}}
+			Expect(plan.canaryFailure()).To(ContainSubstring("timed out progressing"))
+		})
+
+		It("should generate a single replica canary of the latest images", func() {
+			canary, err := generateRAGCanaryDeployment(testReconcilerInstance, cr, plan)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(canary.Name).To(Equal(utils.RAGCanaryDeploymentName))
+			Expect(canary.Annotations).To(Equal(map[string]string{utils.RAGImageRevisionAnnotation: plan.latestRevision}))
+			Expect(*canary.Spec.Replicas).To(Equal(int32(1)))
+			Expect(canary.Spec.Strategy.Type).To(Equal(appsv1.RecreateDeploymentStrategyType))
+			Expect(canary.Spec.Selector.MatchLabels).To(Equal(utils.GenerateRAGCanarySelectorLabels()))
+			Expect(canary.Spec.Template.Labels).To(Equal(utils.GenerateRAGCanarySelectorLabels()))
+			appServerSelector := labels.SelectorFromSet(utils.GenerateAppServerSelectorLabels())
+			Expect(appServerSelector.Matches(labels.Set(canary.Spec.Template.Labels))).To(BeFalse(),
+				"the app server Deployment does not select the canary pods")
+			serviceSelector := labels.SelectorFromSet(utils.GenerateAppServerServiceSelectorLabels())
+			Expect(serviceSelector.Matches(labels.Set(canary.Spec.Template.Labels))).To(BeTrue(),
+				"the app server Service routes queries to the canary pods")
+			Expect(canary.Spec.Template.Spec.InitContainers[0].Name).To(Equal("rag-0"))
+			Expect(canary.Spec.Template.Spec.InitContainers[0].Image).To(Equal("quay.io/myorg/rag@sha256:2222"))
+		})
+	})
+})
@@ -121,6 +121,10 @@ func ReconcileAppServerDeployment(r reconciler.Reconciler, ctx context.Context,
 			Name: "reconcile Exporter ConfigMap",
 			Task: reconcileExporterConfigMap,
 		},
+		{
+			Name: "reconcile RAG canary Deployment",
+			Task: reconcileRAGCanary,
+		},
 		{
 			Name: "reconcile App Deployment",
 			Task: reconcileDeployment,
@@ -540,7 +544,9 @@ func ReconcileAppServer(r reconciler.Reconciler, ctx context.Context, olsconfig
 func reconcileImageStreams(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
 	var ragImages []string
 	for _, rag := range cr.Spec.OLSConfig.RAG {
-		ragImages = append(ragImages, rag.Image)
+		if rag.Image != "" {
+			ragImages = append(ragImages, rag.Image)
+		}
 	}
 
 	desired := make(map[string]*imagev1.ImageStream, len(ragImages))
@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	imagev1 "github.com/openshift/api/image/v1"
 	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 
 	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
@@ -244,6 +245,35 @@ var _ = Describe("App server reconciliator", Ordered, func() {
 			Expect(dep.Spec.Template.Spec.NodeSelector).To(Equal(olsConfig.Spec.OLSConfig.DeploymentConfig.APIContainer.NodeSelector))
 		})
 
+		It("should not restart the deployment when reconciling an unchanged Secrets Store CSI credentials source", func() {
+			By("Reconcile with a Secrets Store CSI credentials source")
+			cr.Spec.LLMConfig.Providers = append(cr.Spec.LLMConfig.Providers, olsv1alpha1.ProviderSpec{
+				Name:              "vault",
+				Type:              "openai",
+				URL:               "https://api.openai.com/v1",
+				Models:            []olsv1alpha1.ModelSpec{{Name: "gpt-4o"}},
+				CredentialsSource: &olsv1alpha1.ProviderCredentialsSource{SecretProviderClass: "vault-openai"},
+			})
+			err := ReconcileAppServer(testReconcilerInstance, ctx, cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			dep := &appsv1.Deployment{}
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: utils.OLSAppServerDeploymentName, Namespace: utils.OLSNamespaceDefault}, dep)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dep.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", "csi-vault")))
+			generation := dep.Generation
+			templateAnnotations := dep.Spec.Template.Annotations
+
+			By("Reconcile again without changes")
+			err = ReconcileAppServer(testReconcilerInstance, ctx, cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: utils.OLSAppServerDeploymentName, Namespace: utils.OLSNamespaceDefault}, dep)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dep.Generation).To(Equal(generation))
+			Expect(dep.Spec.Template.Annotations).To(Equal(templateAnnotations))
+		})
+
 		// This is specific for hash based implementation. Now done by watcher
 		XIt("should trigger rolling update of the deployment when changing tls secret content", func() {
 
@@ -859,6 +889,40 @@ var _ = Describe("App server reconciliator", Ordered, func() {
 
 		})
 
+		It("should not restart the deployment when reconciling an unchanged OCI artifact source", func() {
+			By("Reconcile without RAG defined")
+			err := ReconcileAppServer(testReconcilerInstance, ctx, cr)
+			Expect(err).NotTo(HaveOccurred())
+			deployment := &appsv1.Deployment{}
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: utils.OLSAppServerDeploymentName, Namespace: utils.OLSNamespaceDefault}, deployment)
+			Expect(err).NotTo(HaveOccurred())
+			generation := deployment.Generation
+
+			By("Compare the deployment with an OCI artifact to its stored copy")
+			// the envtest API server predates image volumes, the stored copy is defaulted in memory instead
+			cr.Spec.OLSConfig.RAG = []olsv1alpha1.RAGSpec{
+				{
+					IndexPath:   "/rag/vector_db",
+					OCIArtifact: &olsv1alpha1.RAGOCIArtifactSource{Reference: "quay.io/myorg/rag-index:1.0"},
+				},
+			}
+			stored, err := GenerateOLSDeployment(testReconcilerInstance, cr)
+			Expect(err).NotTo(HaveOccurred())
+			utils.SetDefaults_Deployment(stored)
+			Expect(stored.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Image", &corev1.ImageVolumeSource{
+				Reference:  "quay.io/myorg/rag-index:1.0",
+				PullPolicy: corev1.PullIfNotPresent,
+			})))
+			desired, err := GenerateOLSDeployment(testReconcilerInstance, cr)
+			Expect(err).NotTo(HaveOccurred())
+			err = updateOLSDeployment(testReconcilerInstance, ctx, cr, stored, desired)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: utils.OLSAppServerDeploymentName, Namespace: utils.OLSNamespaceDefault}, deployment)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployment.Generation).To(Equal(generation))
+		})
+
 		It("should add RAG indexes into the configmap when RAG is defined", func() {
 			By("Reconcile with RAG defined")
 			cr.Spec.OLSConfig.RAG = []olsv1alpha1.RAGSpec{
@@ -888,6 +952,130 @@ var _ = Describe("App server reconciliator", Ordered, func() {
 			Expect(cm.Data[utils.OLSConfigFilename]).To(ContainSubstring("solr_hybrid:"))
 		})
 
+		Context("RAG update policy", func() {
+			const ragImage = "rag-ocp-product-docs:4.19"
+
+			importDigest := func(digest string) {
+				is := &imagev1.ImageStream{}
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: utils.ImageStreamNameFor(ragImage), Namespace: utils.OLSNamespaceDefault}, is)
+				Expect(err).NotTo(HaveOccurred())
+				is.Status = imagev1.ImageStreamStatus{
+					DockerImageRepository: "image-registry.openshift-image-registry.svc:5000/" + utils.OLSNamespaceDefault + "/" + is.Name,
+					Tags: []imagev1.NamedTagEventList{{
+						Tag: "latest",
+						Items: []imagev1.TagEvent{{
+							Created:              metav1.Now(),
+							DockerImageReference: "quay.io/myorg/rag-ocp-product-docs@" + digest,
+							Image:                digest,
+							Generation:           1,
+						}},
+					}},
+				}
+				Expect(k8sClient.Update(ctx, is)).To(Succeed())
+			}
+
+			ragInitImage := func(name string) string {
+				deployment := &appsv1.Deployment{}
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: utils.OLSNamespaceDefault}, deployment)
+				Expect(err).NotTo(HaveOccurred())
+				for _, container := range deployment.Spec.Template.Spec.InitContainers {
+					if container.Name == "rag-0" {
+						return container.Image
+					}
+				}
+				Fail("rag-0 init container not found in " + name)
+				return ""
+			}
+
+			BeforeEach(func() {
+				cr.Spec.OLSConfig.RAG = []olsv1alpha1.RAGSpec{
+					{
+						IndexPath: "/rag/vector_db/ocp_product_docs/4.19",
+						IndexID:   "ocp-product-docs-4_19",
+						Image:     ragImage,
+					},
+				}
+			})
+
+			It("should hold a new digest back until the Manual policy approves it", func() {
+				cr.Spec.OLSConfig.RAGUpdatePolicy = &olsv1alpha1.RAGUpdatePolicy{Type: olsv1alpha1.RAGUpdatePolicyManual}
+				Expect(ReconcileAppServer(testReconcilerInstance, ctx, cr)).To(Succeed())
+				deployment := &appsv1.Deployment{}
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: utils.OLSAppServerDeploymentName, Namespace: utils.OLSNamespaceDefault}, deployment)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment.Annotations).NotTo(HaveKey(utils.OLSAppServerImageStreamTriggerAnnotation))
+				Expect(deployment.Annotations).To(HaveKey(utils.RAGImageRevisionAnnotation))
+
+				By("Import a new digest")
+				importDigest("sha256:1111")
+				Expect(ReconcileAppServer(testReconcilerInstance, ctx, cr)).To(Succeed())
+				Expect(ragInitImage(utils.OLSAppServerDeploymentName)).To(Equal(ragImage))
+				status := RAGUpdateStatus(testReconcilerInstance, ctx, cr)
+				Expect(status.Phase).To(Equal(olsv1alpha1.RAGUpdatePending))
+				Expect(status.PendingRevision).NotTo(BeEmpty())
+				Expect(status.Message).To(ContainSubstring(utils.RAGUpdateApprovalAnnotation))
+
+				By("Approve the pending revision")
+				cr.Annotations = map[string]string{utils.RAGUpdateApprovalAnnotation: status.PendingRevision}
+				Expect(ReconcileAppServer(testReconcilerInstance, ctx, cr)).To(Succeed())
+				Expect(ragInitImage(utils.OLSAppServerDeploymentName)).To(Equal("quay.io/myorg/rag-ocp-product-docs@sha256:1111"))
+				status = RAGUpdateStatus(testReconcilerInstance, ctx, cr)
+				Expect(status.Phase).To(Equal(olsv1alpha1.RAGUpdateUpToDate))
+				Expect(status.PendingRevision).To(BeEmpty())
+			})
+
+			It("should report the next window of the Window policy", func() {
+				cr.Spec.OLSConfig.RAGUpdatePolicy = &olsv1alpha1.RAGUpdatePolicy{
+					Type:   olsv1alpha1.RAGUpdatePolicyWindow,
+					Window: &olsv1alpha1.RAGUpdateWindow{Schedule: "0 0 30 2 *", DurationMinutes: 60},
+				}
+				Expect(ReconcileAppServer(testReconcilerInstance, ctx, cr)).To(Succeed())
+				importDigest("sha256:2222")
+				Expect(ReconcileAppServer(testReconcilerInstance, ctx, cr)).To(Succeed())
+				Expect(ragInitImage(utils.OLSAppServerDeploymentName)).NotTo(HaveSuffix("sha256:2222"))
+				status := RAGUpdateStatus(testReconcilerInstance, ctx, cr)
+				Expect(status.Phase).To(Equal(olsv1alpha1.RAGUpdatePending))
+				Expect(status.NextWindowTime).To(BeNil())
+				Expect(status.Message).To(ContainSubstring("never matches"))
+
+				cr.Spec.OLSConfig.RAGUpdatePolicy.Window.Schedule = "* * * * *"
+				Expect(ReconcileAppServer(testReconcilerInstance, ctx, cr)).To(Succeed())
+				Expect(ragInitImage(utils.OLSAppServerDeploymentName)).To(HaveSuffix("sha256:2222"))
+			})
+
+			It("should roll a new digest out to the canary first", func() {
+				cr.Spec.OLSConfig.RAGUpdatePolicy = &olsv1alpha1.RAGUpdatePolicy{Canary: true}
+				Expect(ReconcileAppServer(testReconcilerInstance, ctx, cr)).To(Succeed())
+
+				By("Import a new digest")
+				importDigest("sha256:3333")
+				Expect(ReconcileAppServer(testReconcilerInstance, ctx, cr)).To(Succeed())
+				Expect(ragInitImage(utils.RAGCanaryDeploymentName)).To(HaveSuffix("sha256:3333"))
+				Expect(ragInitImage(utils.OLSAppServerDeploymentName)).NotTo(HaveSuffix("sha256:3333"))
+				Expect(RAGUpdateStatus(testReconcilerInstance, ctx, cr).Phase).To(Equal(olsv1alpha1.RAGUpdateCanary))
+
+				By("Report the canary pod ready")
+				canary := &appsv1.Deployment{}
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: utils.RAGCanaryDeploymentName, Namespace: utils.OLSNamespaceDefault}, canary)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*canary.Spec.Replicas).To(Equal(int32(1)))
+				Expect(canary.Spec.Selector.MatchLabels).To(HaveKeyWithValue(utils.RAGCanaryLabel, "true"))
+				canary.Status.ObservedGeneration = canary.Generation
+				canary.Status.Replicas = 1
+				canary.Status.UpdatedReplicas = 1
+				canary.Status.ReadyReplicas = 1
+				Expect(k8sClient.Status().Update(ctx, canary)).To(Succeed())
+
+				Expect(ReconcileAppServer(testReconcilerInstance, ctx, cr)).To(Succeed())
+				Expect(ragInitImage(utils.OLSAppServerDeploymentName)).To(HaveSuffix("sha256:3333"))
+
+				By("Delete the canary once the app server runs the new digest")
+				Expect(ReconcileAppServer(testReconcilerInstance, ctx, cr)).To(Succeed())
+				err = k8sClient.Get(ctx, types.NamespacedName{Name: utils.RAGCanaryDeploymentName, Namespace: utils.OLSNamespaceDefault}, canary)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				Expect(RAGUpdateStatus(testReconcilerInstance, ctx, cr).Phase).To(Equal(olsv1alpha1.RAGUpdateUpToDate))
+			})
+		})
 	})
 
 	Context("Proxy Settings", Ordered, func() {
@@ -200,6 +200,7 @@ var _ = BeforeSuite(func() {
 var _ = BeforeEach(func() {
 	if tr, ok := testReconcilerInstance.(*utils.TestReconciler); ok {
 		tr.SetRosaOKPProductEnv(nil)
+		tr.SetOpenShiftVersion("123", "456")
 	}
 	// Restore CA fixtures: reconcile paths may delete the MCP client Secret when
 	// introspection is disabled in a prior spec.
@@ -0,0 +1,208 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+// The console renders a subset of the Grafana dashboard schema: rows of graph panels
+// with Prometheus targets, queried through the cluster monitoring stack.
+type grafanaDashboard struct {
+	Title         string       `json:"title"`
+	UID           string       `json:"uid"`
+	Tags          []string     `json:"tags"`
+	Editable      bool         `json:"editable"`
+	SchemaVersion int          `json:"schemaVersion"`
+	Time          grafanaTime  `json:"time"`
+	Refresh       string       `json:"refresh"`
+	Rows          []grafanaRow `json:"rows"`
+}
+
+type grafanaTime struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type grafanaRow struct {
+	Title     string         `json:"title"`
+	Collapse  bool           `json:"collapse"`
+	ShowTitle bool           `json:"showTitle"`
+	Height    string         `json:"height"`
+	Panels    []grafanaPanel `json:"panels"`
+}
+
+type grafanaPanel struct {
+	ID      int             `json:"id"`
+	Title   string          `json:"title"`
+	Type    string          `json:"type"`
+	Span    int             `json:"span"`
+	Format  string          `json:"format,omitempty"`
+	Targets []grafanaTarget `json:"targets"`
+	YAxes   []grafanaYAxis  `json:"yaxes"`
+}
+
+type grafanaTarget struct {
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+	RefID        string `json:"refId"`
+}
+
+type grafanaYAxis struct {
+	Format string `json:"format"`
+	Show   bool   `json:"show"`
+}
+
+// panelBuilder assigns unique panel IDs and target reference IDs while the dashboard is assembled.
+type panelBuilder struct {
+	nextID           int
+	namespaceMatcher string
+}
+
+// graph returns a graph panel. The %[1]s verb in each query is replaced by the namespace label matcher.
+func (b *panelBuilder) graph(title, unit string, span int, queries ...[2]string) grafanaPanel {
+	b.nextID++
+	panel := grafanaPanel{
+		ID:    b.nextID,
+		Title: title,
+		Type:  "graph",
+		Span:  span,
+		YAxes: []grafanaYAxis{{Format: unit, Show: true}, {Format: "short", Show: false}},
+	}
+	for i, q := range queries {
+		panel.Targets = append(panel.Targets, grafanaTarget{
+			Expr:         fmt.Sprintf(q[0], b.namespaceMatcher),
+			LegendFormat: q[1],
+			RefID:        string(rune('A' + i)),
+		})
+	}
+	return panel
+}
+
+func row(title string, panels ...grafanaPanel) grafanaRow {
+	return grafanaRow{
+		Title:     title,
+		ShowTitle: true,
+		Height:    "250px",
+		Panels:    panels,
+	}
+}
+
+// generateDashboard builds the dashboard from the operands the CR deploys, so that every panel
+// queries metrics that are scraped by one of the operator's ServiceMonitors (or by the cluster
+// monitoring stack for kube-state-metrics and kubelet volume stats).
+func generateDashboard(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) grafanaDashboard {
+	b := &panelBuilder{namespaceMatcher: fmt.Sprintf("namespace=%q", r.GetNamespace())}
+
+	deployments := []string{utils.OLSAppServerDeploymentName, utils.PostgresDeploymentName}
+	introspection := utils.BoolDeref(cr.Spec.OLSConfig.IntrospectionEnabled, true)
+	if introspection {
+		deployments = append(deployments, utils.OpenShiftMCPServerDeploymentName)
+	}
+	if utils.IsRHOKPDeployed(cr) {
+		deployments = append(deployments, utils.RHOKPDeploymentName)
+	}
+	otel := r.GetOtelCollectorImage() != ""
+	if otel {
+		deployments = append(deployments, utils.OtelCollectorDeploymentName)
+	}
+
+	rows := []grafanaRow{
+		row("Queries",
+			b.graph("Query rate by provider/model", "reqps", 6,
+				[2]string{`sum by (provider, model) (rate(ols_llm_calls_total{%[1]s}[5m]))`, "{{provider}}/{{model}}"}),
+			b.graph("Streaming query latency", "s", 6,
+				[2]string{`histogram_quantile(0.5, sum by (le) (rate(ols_response_duration_seconds_bucket{%[1]s,path="/v1/streaming_query"}[5m])))`, "p50"},
+				[2]string{`histogram_quantile(0.95, sum by (le) (rate(ols_response_duration_seconds_bucket{%[1]s,path="/v1/streaming_query"}[5m])))`, "p95"},
+				[2]string{`histogram_quantile(0.99, sum by (le) (rate(ols_response_duration_seconds_bucket{%[1]s,path="/v1/streaming_query"}[5m])))`, "p99"}),
+		),
+		row("Errors",
+			b.graph("Streaming query responses by status code", "reqps", 6,
+				[2]string{`sum by (status_code) (rate(ols_rest_api_calls_total{%[1]s,path="/v1/streaming_query"}[5m]))`, "{{status_code}}"}),
+			b.graph("LLM call failures", "reqps", 6,
+				[2]string{`sum(rate(ols_llm_calls_failures_total{%[1]s}[5m]))`, "failures"},
+				[2]string{`sum(rate(ols_llm_validation_errors_total{%[1]s}[5m]))`, "validation errors"}),
+		),
+		row("Token usage",
+			b.graph("Tokens sent by provider/model", "short", 6,
+				[2]string{`sum by (provider, model) (rate(ols_llm_token_sent_total{%[1]s}[5m]))`, "{{provider}}/{{model}}"}),
+			b.graph("Tokens received by provider/model", "short", 6,
+				[2]string{`sum by (provider, model) (rate(ols_llm_token_received_total{%[1]s}[5m]))`, "{{provider}}/{{model}}"}),
+		),
+	}
+
+	// Without spec.ols.storage the cache lives in an emptyDir that has no volume metrics.
+	if cr.Spec.OLSConfig.Storage != nil {
+		rows = append(rows, row("Conversation cache",
+			b.graph("Conversation cache volume usage", "bytes", 12,
+				[2]string{fmt.Sprintf(`kubelet_volume_stats_used_bytes{%%[1]s,persistentvolumeclaim=%q}`, utils.PostgresPVCName), "used"},
+				[2]string{fmt.Sprintf(`kubelet_volume_stats_capacity_bytes{%%[1]s,persistentvolumeclaim=%q}`, utils.PostgresPVCName), "capacity"}),
+		))
+	}
+
+	if introspection {
+		rows = append(rows, row("MCP tools",
+			b.graph("MCP tool calls", "reqps", 12,
+				[2]string{`sum by (tool_name) (rate(k8s_mcp_tool_calls_total{%[1]s}[5m]))`, "{{tool_name}}"}),
+		))
+	}
+
+	if otel {
+		rows = append(rows, row("OTEL pipeline",
+			b.graph("Received telemetry", "short", 4,
+				[2]string{`sum by (receiver) (rate({__name__=~"otelcol_receiver_accepted_(spans|log_records|metric_points)(_total)?",%[1]s}[5m]))`, "accepted {{receiver}}"},
+				[2]string{`sum by (receiver) (rate({__name__=~"otelcol_receiver_refused_(spans|log_records|metric_points)(_total)?",%[1]s}[5m]))`, "refused {{receiver}}"}),
+			b.graph("Exported telemetry", "short", 4,
+				[2]string{`sum by (exporter) (rate({__name__=~"otelcol_exporter_sent_(spans|log_records|metric_points)(_total)?",%[1]s}[5m]))`, "sent {{exporter}}"},
+				[2]string{`sum by (exporter) (rate({__name__=~"otelcol_exporter_send_failed_(spans|log_records|metric_points)(_total)?",%[1]s}[5m]))`, "failed {{exporter}}"}),
+			b.graph("Exporter queue usage", "percentunit", 4,
+				[2]string{`max by (exporter) (otelcol_exporter_queue_size{%[1]s} / otelcol_exporter_queue_capacity{%[1]s})`, "{{exporter}}"}),
+		))
+	}
+
+	rows = append(rows, row("Availability",
+		b.graph("Available replicas", "short", 12,
+			[2]string{fmt.Sprintf(`kube_deployment_status_replicas_available{%%[1]s,deployment=~%q}`, strings.Join(deployments, "|")), "{{deployment}}"}),
+	))
+
+	return grafanaDashboard{
+		Title:         "OpenShift Lightspeed",
+		UID:           "openshift-lightspeed",
+		Tags:          []string{"openshift-lightspeed"},
+		SchemaVersion: 16,
+		Time:          grafanaTime{From: "now-1h", To: "now"},
+		Refresh:       "30s",
+		Rows:          rows,
+	}
+}
+
+// GenerateDashboardConfigMap returns the console monitoring dashboard ConfigMap.
+// It lives in openshift-config-managed, so it carries no owner reference and is removed by the finalizer.
+func GenerateDashboardConfigMap(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (*corev1.ConfigMap, error) {
+	dashboardJSON, err := json.MarshalIndent(generateDashboard(r, cr), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dashboard: %w", err)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.ConsoleDashboardConfigMapName,
+			Namespace: utils.ConsoleDashboardNamespace,
+			Labels: map[string]string{
+				utils.ConsoleDashboardLabel:    "true",
+				"app.kubernetes.io/managed-by": "lightspeed-operator",
+				"app.kubernetes.io/part-of":    "openshift-lightspeed",
+			},
+		},
+		Data: map[string]string{
+			utils.ConsoleDashboardDataKey: string(dashboardJSON),
+		},
+	}, nil
+}
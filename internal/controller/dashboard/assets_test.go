@@ -0,0 +1,83 @@
+package dashboard
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+var _ = Describe("Console dashboard assets", func() {
+	var cr *olsv1alpha1.OLSConfig
+	var tr *utils.TestReconciler
+	var savedOtelImage string
+
+	rowTitles := func(d grafanaDashboard) []string {
+		titles := []string{}
+		for _, row := range d.Rows {
+			titles = append(titles, row.Title)
+		}
+		return titles
+	}
+
+	BeforeEach(func() {
+		cr = utils.GetDefaultOLSConfigCR()
+		tr = testReconcilerInstance.(*utils.TestReconciler)
+		savedOtelImage = tr.OtelCollectorImage
+		tr.OtelCollectorImage = "quay.io/test/lightspeed-otel-collector:test"
+	})
+
+	AfterEach(func() {
+		tr.OtelCollectorImage = savedOtelImage
+	})
+
+	It("should generate the dashboard ConfigMap for the console", func() {
+		cm, err := GenerateDashboardConfigMap(testReconcilerInstance, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cm.Name).To(Equal(utils.ConsoleDashboardConfigMapName))
+		Expect(cm.Namespace).To(Equal(utils.ConsoleDashboardNamespace))
+		Expect(cm.Labels).To(HaveKeyWithValue(utils.ConsoleDashboardLabel, "true"))
+		Expect(cm.OwnerReferences).To(BeEmpty())
+		Expect(cm.Data).To(HaveKey(utils.ConsoleDashboardDataKey))
+
+		var d grafanaDashboard
+		Expect(json.Unmarshal([]byte(cm.Data[utils.ConsoleDashboardDataKey]), &d)).To(Succeed())
+		Expect(d.Title).To(Equal("OpenShift Lightspeed"))
+	})
+
+	It("should only add panels for deployed operands", func() {
+		cr.Spec.OLSConfig.IntrospectionEnabled = utils.BoolPtr(true)
+		d := generateDashboard(testReconcilerInstance, cr)
+		Expect(rowTitles(d)).To(Equal([]string{"Queries", "Errors", "Token usage", "MCP tools", "OTEL pipeline", "Availability"}))
+
+		cr.Spec.OLSConfig.IntrospectionEnabled = utils.BoolPtr(false)
+		cr.Spec.OLSConfig.ByokRAGOnly = true
+		cr.Spec.OLSConfig.Storage = &olsv1alpha1.Storage{}
+		tr.OtelCollectorImage = ""
+		d = generateDashboard(testReconcilerInstance, cr)
+		Expect(rowTitles(d)).To(Equal([]string{"Queries", "Errors", "Token usage", "Conversation cache", "Availability"}))
+
+		availability := d.Rows[len(d.Rows)-1].Panels[0].Targets[0].Expr
+		Expect(availability).To(ContainSubstring(utils.OLSAppServerDeploymentName + "|" + utils.PostgresDeploymentName))
+		Expect(availability).NotTo(ContainSubstring(utils.RHOKPDeploymentName))
+		Expect(availability).NotTo(ContainSubstring(utils.OpenShiftMCPServerDeploymentName))
+	})
+
+	It("should scope every query to the operator namespace with unique panel IDs", func() {
+		d := generateDashboard(testReconcilerInstance, cr)
+		ids := map[int]bool{}
+		for _, row := range d.Rows {
+			for _, panel := range row.Panels {
+				Expect(ids).NotTo(HaveKey(panel.ID))
+				ids[panel.ID] = true
+				for _, target := range panel.Targets {
+					Expect(target.Expr).To(ContainSubstring(`namespace="` + utils.OLSNamespaceDefault + `"`))
+					Expect(target.Expr).NotTo(ContainSubstring("%!"))
+				}
+			}
+		}
+	})
+})
@@ -0,0 +1,85 @@
+// Package dashboard provides reconciliation logic for the OpenShift Lightspeed monitoring
+// dashboard shown under Observe > Dashboards in the OpenShift console.
+//
+// The console loads dashboards from ConfigMaps labelled console.openshift.io/dashboard=true
+// in the openshift-config-managed namespace. The operator generates the dashboard from the
+// operands it deploys, so that each panel queries metrics scraped by its ServiceMonitors.
+// The dashboard is only reconciled when the Prometheus Operator is available. Because the
+// ConfigMap lives outside the operator namespace it has no owner reference; Remove deletes it
+// during finalization.
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+// ReconcileDashboard creates or updates the console dashboard ConfigMap.
+func ReconcileDashboard(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	if !r.IsPrometheusAvailable() {
+		r.GetLogger().Info("Prometheus Operator not available, skipping console dashboard reconciliation")
+		return nil
+	}
+
+	cm, err := GenerateDashboardConfigMap(r, cr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrGenerateConsoleDashboard, err)
+	}
+
+	foundCm := &corev1.ConfigMap{}
+	err = r.Get(ctx, client.ObjectKey{Name: cm.Name, Namespace: cm.Namespace}, foundCm)
+	if err != nil && errors.IsNotFound(err) {
+		r.GetLogger().Info("creating console dashboard configmap", "configmap", cm.Name)
+		if err := r.Create(ctx, cm); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrCreateConsoleDashboard, err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrGetConsoleDashboard, err)
+	}
+
+	if utils.ConfigMapEqual(foundCm, cm) && reflect.DeepEqual(foundCm.Labels, cm.Labels) {
+		r.GetLogger().Info("console dashboard configmap unchanged, reconciliation skipped", "configmap", cm.Name)
+		return nil
+	}
+
+	foundCm.Data = cm.Data
+	foundCm.Labels = cm.Labels
+	if err := r.Update(ctx, foundCm); err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrUpdateConsoleDashboard, err)
+	}
+	r.GetLogger().Info("console dashboard configmap reconciled", "configmap", cm.Name)
+	return nil
+}
+
+// Remove deletes the console dashboard ConfigMap.
+func Remove(r reconciler.Reconciler, ctx context.Context) error {
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Name: utils.ConsoleDashboardConfigMapName, Namespace: utils.ConsoleDashboardNamespace}, cm)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.GetLogger().Info("console dashboard configmap not found, skip deletion")
+			return nil
+		}
+		return fmt.Errorf("%s: %w", utils.ErrGetConsoleDashboard, err)
+	}
+
+	if err := r.Delete(ctx, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", utils.ErrDeleteConsoleDashboard, err)
+	}
+
+	r.GetLogger().Info("console dashboard configmap deleted")
+	return nil
+}
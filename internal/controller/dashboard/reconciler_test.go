@@ -0,0 +1,69 @@
+package dashboard
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+var _ = Describe("Console dashboard reconciler", Ordered, func() {
+	var cr *olsv1alpha1.OLSConfig
+	var tr *utils.TestReconciler
+	dashboardKey := types.NamespacedName{Name: utils.ConsoleDashboardConfigMapName, Namespace: utils.ConsoleDashboardNamespace}
+
+	BeforeAll(func() {
+		cr = utils.GetDefaultOLSConfigCR()
+		tr = testReconcilerInstance.(*utils.TestReconciler)
+	})
+
+	AfterEach(func() {
+		tr.PrometheusAvailable = true
+	})
+
+	It("should skip the dashboard when Prometheus is not available", func() {
+		tr.PrometheusAvailable = false
+		Expect(ReconcileDashboard(testReconcilerInstance, ctx, cr)).To(Succeed())
+		err := k8sClient.Get(ctx, dashboardKey, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should create the dashboard ConfigMap", func() {
+		Expect(ReconcileDashboard(testReconcilerInstance, ctx, cr)).To(Succeed())
+		cm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, dashboardKey, cm)).To(Succeed())
+		Expect(cm.Labels).To(HaveKeyWithValue(utils.ConsoleDashboardLabel, "true"))
+		Expect(cm.Data[utils.ConsoleDashboardDataKey]).NotTo(ContainSubstring("Conversation cache"))
+	})
+
+	It("should update the dashboard when the CR changes", func() {
+		cr.Spec.OLSConfig.Storage = &olsv1alpha1.Storage{}
+		Expect(ReconcileDashboard(testReconcilerInstance, ctx, cr)).To(Succeed())
+		cm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, dashboardKey, cm)).To(Succeed())
+		Expect(cm.Data[utils.ConsoleDashboardDataKey]).To(ContainSubstring("Conversation cache"))
+	})
+
+	It("should restore the dashboard label", func() {
+		cm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, dashboardKey, cm)).To(Succeed())
+		delete(cm.Labels, utils.ConsoleDashboardLabel)
+		Expect(k8sClient.Update(ctx, cm)).To(Succeed())
+
+		Expect(ReconcileDashboard(testReconcilerInstance, ctx, cr)).To(Succeed())
+		Expect(k8sClient.Get(ctx, dashboardKey, cm)).To(Succeed())
+		Expect(cm.Labels).To(HaveKeyWithValue(utils.ConsoleDashboardLabel, "true"))
+	})
+
+	It("should delete the dashboard ConfigMap on removal", func() {
+		Expect(Remove(testReconcilerInstance, ctx)).To(Succeed())
+		err := k8sClient.Get(ctx, dashboardKey, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(Remove(testReconcilerInstance, ctx)).To(Succeed())
+	})
+})
@@ -25,6 +25,7 @@ limitations under the License.
 //   - olsconfig_controller.go: Core type definition, Reconcile(), and SetupWithManager()
 //   - olsconfig_helpers.go: Interface implementations, status management, and annotation logic
 //   - olsconfig_watchers.go: Watcher predicate helpers for secrets and configmaps
+//   - olsconfig_diagnostics.go: Log and Event snapshots of failed components
 //   - operator_assets.go: Operator infrastructure resources (ServiceMonitor, NetworkPolicy)
 //
 // Key Responsibilities:
@@ -61,12 +62,18 @@ import (
 	imagev1 "github.com/openshift/api/image/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -82,12 +89,16 @@ import (
 	"github.com/openshift/lightspeed-operator/internal/controller/alertsadapter"
 	"github.com/openshift/lightspeed-operator/internal/controller/appserver"
 	"github.com/openshift/lightspeed-operator/internal/controller/console"
+	"github.com/openshift/lightspeed-operator/internal/controller/dashboard"
 	"github.com/openshift/lightspeed-operator/internal/controller/ocpmcp"
 	"github.com/openshift/lightspeed-operator/internal/controller/otelcollector"
 	"github.com/openshift/lightspeed-operator/internal/controller/postgres"
+	"github.com/openshift/lightspeed-operator/internal/controller/preflight"
+	"github.com/openshift/lightspeed-operator/internal/controller/ragbuild"
 	"github.com/openshift/lightspeed-operator/internal/controller/rhokp"
 	"github.com/openshift/lightspeed-operator/internal/controller/utils"
 	"github.com/openshift/lightspeed-operator/internal/controller/watchers"
+	"github.com/openshift/lightspeed-operator/internal/metrics"
 	utiltls "github.com/openshift/lightspeed-operator/internal/tls"
 )
 
@@ -98,20 +109,36 @@ import (
 //   - External resources (Secrets, ConfigMaps) via Watches() with custom predicates
 //
 // Controller-runtime handles error retries with exponential backoff.
+//
+// Recorder is optional; when nil (e.g. in tests) no Kubernetes Events are emitted.
+// KubeClient is optional; it serves the pod log and Event APIs used to capture failure
+// diagnostics, and when nil no log tails are captured.
+// ProviderPreflight is optional; when nil status.providers is never populated.
+// OKPExternalChecker is optional; when nil the external knowledge portal is checked on every reconciliation.
 type OLSConfigReconciler struct {
 	client.Client
-	Logger        logr.Logger
-	Options       utils.OLSConfigReconcilerOptions
-	WatcherConfig *utils.WatcherConfig
+	Logger             logr.Logger
+	Options            utils.OLSConfigReconcilerOptions
+	WatcherConfig      *utils.WatcherConfig
+	Recorder           events.EventRecorder
+	KubeClient         kubernetes.Interface
+	ProviderPreflight  *preflight.Prober
+	OKPExternalChecker *rhokp.ExternalChecker
 }
 
 // +kubebuilder:rbac:groups=ols.openshift.io,resources=olsconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ols.openshift.io,resources=olsconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ols.openshift.io,resources=olsconfigs/finalizers,verbs=update
+// Events for operand lifecycle transitions (regarding the cluster-scoped OLSConfig CR)
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 // RBAC for managing deployments of OLS application server
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // RBAC for reading pod status for diagnostics
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// Container logs and pod Events captured into the diagnostics ConfigMap when a component fails
+// +kubebuilder:rbac:groups="",namespace=system,resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",namespace=system,resources=pods/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",namespace=system,resources=events,verbs=get;list
 // Service for exposing lightspeed service API endpoints
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // ServiceAccount to run OLS application server
@@ -162,6 +189,9 @@ type OLSConfigReconciler struct {
 // NetworkPolicy scoped to operator namespace (OLS-3886: was cluster-wide)
 // +kubebuilder:rbac:groups=networking.k8s.io,namespace=system,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
 
+// PodDisruptionBudget of the RHOKP replicas
+// +kubebuilder:rbac:groups=policy,namespace=system,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
 // PVC access for the Postgres PVC
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
@@ -169,6 +199,15 @@ type OLSConfigReconciler struct {
 // ImageStream access
 // +kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams,verbs=get;list;watch;create;update;patch;delete
 
+// BYOK RAG index build Jobs and CronJobs
+// +kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch;create;update;patch;delete
+
+// KServe InferenceServices referenced by LLM providers, in any namespace
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices,verbs=get;list;watch
+
+// Secrets Store CSI driver pod statuses, to restart the app server when provider credentials rotate
+// +kubebuilder:rbac:groups=secrets-store.csi.x-k8s.io,resources=secretproviderclasspodstatuses,verbs=get;list;watch
+
 // getAndValidateCR fetches and validates the OLSConfig CR.
 // Returns (cr, nil) on success.
 // Returns (nil, nil) if CR doesn't exist or has wrong name (expected, no retry needed).
@@ -209,10 +248,14 @@ func (r *OLSConfigReconciler) handleFinalizer(ctx context.Context, req ctrl.Requ
 	if !olsconfig.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(olsconfig, utils.OLSConfigFinalizer) {
 			r.Logger.Info("OLSConfig CR is being deleted, running finalizer cleanup")
+			utils.RecordEvent(r, olsconfig, corev1.EventTypeNormal, utils.EventReasonFinalizing, utils.EventActionFinalize,
+				"Cleaning up OpenShift Lightspeed resources")
 
 			// Run finalizer cleanup logic
 			if err := r.finalizeOLSConfig(ctx, olsconfig); err != nil {
 				r.Logger.Error(err, "Failed to finalize OLSConfig CR")
+				utils.RecordEvent(r, olsconfig, corev1.EventTypeWarning, utils.EventReasonCleanupFailed, utils.EventActionFinalize,
+					"Failed to finalize OLSConfig: %v", err)
 				return &ctrl.Result{}, fmt.Errorf("failed to finalize OLSConfig CR: %w", err)
 			}
 
@@ -244,6 +287,8 @@ func (r *OLSConfigReconciler) handleFinalizer(ctx context.Context, req ctrl.Requ
 				r.Logger.Error(err, "Failed to remove finalizer from OLSConfig CR")
 				return &ctrl.Result{}, fmt.Errorf("failed to remove finalizer from OLSConfig CR: %w", err)
 			}
+			utils.RecordEvent(r, olsconfig, corev1.EventTypeNormal, utils.EventReasonFinalized, utils.EventActionFinalize,
+				"Cleanup completed, finalizer %s removed", utils.OLSConfigFinalizer)
 		}
 		// CR is being deleted and finalizer is removed (or never existed), nothing to do
 		return &ctrl.Result{}, nil
@@ -258,6 +303,8 @@ func (r *OLSConfigReconciler) handleFinalizer(ctx context.Context, req ctrl.Requ
 			return &ctrl.Result{}, fmt.Errorf("failed to add finalizer to OLSConfig CR: %w", err)
 		}
 		r.Logger.Info("Finalizer added to OLSConfig CR")
+		utils.RecordEvent(r, olsconfig, corev1.EventTypeNormal, utils.EventReasonFinalizerAdded, utils.EventActionFinalize,
+			"Added finalizer %s", utils.OLSConfigFinalizer)
 		// Return here to ensure finalizer is persisted before proceeding
 		// Controller-runtime will requeue automatically
 		return &ctrl.Result{}, nil
@@ -303,7 +350,7 @@ func (r *OLSConfigReconciler) reconcileOperatorResources(ctx context.Context) er
 
 // reconcileIndependentResources reconciles Phase 1: independent resources for all components.
 // This phase creates ConfigMaps, Secrets, ServiceAccounts, Roles, NetworkPolicies, etc.
-// Order: postgres → console → agentic console → alerts adapter → otel → ocpmcp → appserver.
+// Order: postgres → console → agentic console → alerts adapter → otel → ocpmcp → RAG index builds → appserver.
 // Agentic integration handoff runs at the end of Phase 2 (after Services/TLS).
 // Uses continue-on-error to reconcile as many resources as possible, even if some fail.
 func (r *OLSConfigReconciler) reconcileIndependentResources(ctx context.Context, olsconfig *olsv1alpha1.OLSConfig) error {
@@ -317,6 +364,9 @@ func (r *OLSConfigReconciler) reconcileIndependentResources(ctx context.Context,
 		{Name: "postgres resources", Fn: func(ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
 			return postgres.ReconcilePostgresResources(r, ctx, cr)
 		}},
+		{Name: "console dashboard", Fn: func(ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+			return dashboard.ReconcileDashboard(r, ctx, cr)
+		}},
 	}
 
 	// Optional operands — gated by CR fields or image flags.
@@ -335,14 +385,14 @@ func (r *OLSConfigReconciler) reconcileIndependentResources(ctx context.Context,
 		}
 	}
 
-	if !olsconfig.Spec.OLSConfig.ByokRAGOnly {
+	if utils.IsRHOKPDeployed(olsconfig) {
 		resourceSteps = append(resourceSteps, utils.ReconcileSteps{
 			Name: "RHOKP resources",
 			Fn: func(ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
 				return rhokp.ReconcileResources(r, ctx, cr)
 			},
 		})
-	} else if wasComponentEnabled(olsconfig, utils.TypeRHOKPReady) {
+	} else if wasRHOKPDeployed(olsconfig) {
 		if err := rhokp.Remove(r, ctx); err != nil {
 			resourceFailures["RHOKP cleanup"] = fmt.Errorf("%s: %w", utils.ErrRemoveRHOKPResources, err)
 		}
@@ -384,6 +434,19 @@ func (r *OLSConfigReconciler) reconcileIndependentResources(ctx context.Context,
 		})
 	}
 
+	if len(olsconfig.Spec.OLSConfig.RAGBuilds) > 0 {
+		resourceSteps = append(resourceSteps, utils.ReconcileSteps{
+			Name: "RAG index build resources",
+			Fn: func(ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+				return ragbuild.ReconcileResources(r, ctx, cr)
+			},
+		})
+	} else if len(olsconfig.Status.RAGBuilds) > 0 {
+		if err := ragbuild.Remove(r, ctx); err != nil {
+			resourceFailures["RAG index build cleanup"] = fmt.Errorf("%s: %w", utils.ErrRemoveRAGBuildResources, err)
+		}
+	}
+
 	resourceSteps = append(resourceSteps, utils.ReconcileSteps{
 		Name: "application server resources",
 		Fn: func(ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
@@ -393,7 +456,10 @@ func (r *OLSConfigReconciler) reconcileIndependentResources(ctx context.Context,
 
 	// Reconcile all independent resources (continue on error to reconcile as many as possible)
 	for _, step := range resourceSteps {
-		if err := step.Fn(ctx, olsconfig); err != nil {
+		start := time.Now()
+		err := step.Fn(ctx, olsconfig)
+		metrics.ObserveReconcileStep(step.Name, start, err)
+		if err != nil {
 			r.Logger.Error(err, "Resource reconciliation failed", "resource", step.Name)
 			resourceFailures[step.Name] = err
 		}
@@ -471,7 +537,7 @@ func (r *OLSConfigReconciler) reconcileDeploymentsAndStatus(ctx context.Context,
 		})
 	}
 
-	if !olsconfig.Spec.OLSConfig.ByokRAGOnly {
+	if utils.IsRHOKPDeployed(olsconfig) {
 		deploymentSteps = append(deploymentSteps, utils.ReconcileSteps{
 			Name: "RHOKP deployment",
 			Fn: func(ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
@@ -521,6 +587,24 @@ func (r *OLSConfigReconciler) reconcileDeploymentsAndStatus(ctx context.Context,
 			Message:            "RHOKP is disabled; spec.ols.byokRAGOnly is true",
 			LastTransitionTime: metav1.Now(),
 		})
+	} else if external := utils.OKPExternal(olsconfig); external != nil {
+		// No RHOKP Deployment to watch, the readiness is the outcome of pinging the external portal
+		condition := metav1.Condition{
+			Type:               utils.TypeRHOKPReady,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: olsconfig.Generation,
+			Reason:             utils.ReasonOKPExternal,
+			Message:            fmt.Sprintf("External knowledge portal %s is reachable", external.URL),
+			LastTransitionTime: metav1.Now(),
+		}
+		if err := r.checkOKPExternal(ctx, olsconfig); err != nil {
+			r.Logger.Error(err, "External knowledge portal is not reachable", "url", external.URL)
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = utils.ReasonOKPExternalUnreachable
+			condition.Message = fmt.Sprintf("Failed: %v", err)
+			newStatus.OverallStatus = olsv1alpha1.OverallStatusNotReady
+		}
+		newStatus.Conditions = append(newStatus.Conditions, condition)
 	}
 
 	if r.Options.OtelCollectorImage != "" {
@@ -603,8 +687,11 @@ func (r *OLSConfigReconciler) reconcileDeploymentsAndStatus(ctx context.Context,
 		})
 	}
 
+	var failedDiagnostics []olsv1alpha1.PodDiagnostic
 	for _, step := range deploymentSteps {
+		start := time.Now()
 		err := step.Fn(ctx, olsconfig)
+		metrics.ObserveReconcileStep(step.Name, start, err)
 		if err != nil {
 			wrappedErr := fmt.Errorf("failed to reconcile %s: %w", step.Name, err)
 			r.Logger.Error(wrappedErr, fmt.Sprintf("Failed to reconcile %s", step.Name))
@@ -642,6 +729,9 @@ func (r *OLSConfigReconciler) reconcileDeploymentsAndStatus(ctx context.Context,
 				status, diagnostics, err := r.checkDeploymentStatus(ctx, deployment, step.ConditionType)
 				// Append diagnostics from this deployment (will be empty for Ready/Progressing)
 				newStatus.DiagnosticInfo = append(newStatus.DiagnosticInfo, diagnostics...)
+				if status == string(olsv1alpha1.DeploymentStatusFailed) {
+					failedDiagnostics = append(failedDiagnostics, diagnostics...)
+				}
 
 				switch status {
 				case string(olsv1alpha1.DeploymentStatusReady):
@@ -651,7 +741,7 @@ func (r *OLSConfigReconciler) reconcileDeploymentsAndStatus(ctx context.Context,
 						Status:             metav1.ConditionTrue,
 						ObservedGeneration: olsconfig.Generation,
 						Reason:             "Available",
-						Message:            "Ready",
+						Message:            deploymentReadyMessage(step.ConditionType, deployment, "Ready"),
 						LastTransitionTime: metav1.Now(),
 					}
 					newStatus.Conditions = append(newStatus.Conditions, condition)
@@ -663,7 +753,7 @@ func (r *OLSConfigReconciler) reconcileDeploymentsAndStatus(ctx context.Context,
 						Status:             metav1.ConditionFalse,
 						ObservedGeneration: olsconfig.Generation,
 						Reason:             "Progressing",
-						Message:            status,
+						Message:            deploymentReadyMessage(step.ConditionType, deployment, status),
 						LastTransitionTime: metav1.Now(),
 					}
 					newStatus.Conditions = append(newStatus.Conditions, condition)
@@ -699,11 +789,47 @@ func (r *OLSConfigReconciler) reconcileDeploymentsAndStatus(ctx context.Context,
 		newStatus.OverallStatus = olsv1alpha1.OverallStatusNotReady
 	}
 
+	// Snapshot log tails and Events of failed components; capture problems must not block the status update
+	diagnosticsRef, err := r.reconcileDiagnostics(ctx, olsconfig, failedDiagnostics)
+	if err != nil {
+		r.Logger.Error(err, "Failed to capture failure diagnostics")
+	}
+	newStatus.DiagnosticsRef = diagnosticsRef
+
+	// Probe the LLM providers; the result is informational and does not change the overall status
+	if r.ProviderPreflight != nil {
+		newStatus.Providers = r.ProviderPreflight.ProbeProviders(r, ctx, olsconfig)
+	}
+
+	// Report the BYOK RAG sources; informational only
+	if len(olsconfig.Spec.OLSConfig.RAG) > 0 {
+		newStatus.RAG = appserver.RAGStatuses(r, ctx, olsconfig)
+	}
+
+	// Report the rollout of new BYOK RAG image digests under a managed update policy
+	newStatus.RAGUpdate = appserver.RAGUpdateStatus(r, ctx, olsconfig)
+
+	// Report the RAG index builds; informational only
+	if len(olsconfig.Spec.OLSConfig.RAGBuilds) > 0 {
+		newStatus.RAGBuilds = ragbuild.Statuses(r, ctx, olsconfig)
+	}
+
+	// Warn when tools are configured for a default model declared without tool calling; informational only
+	newStatus.Conditions = append(newStatus.Conditions, toolCallingCondition(olsconfig))
+
+	// Warn ahead of the expiry of referenced credentials; informational only.
+	// Re-evaluate the credentials expiry when it next changes, Secret events may never come
+	credentialsCondition, requeueAfter := r.credentialsExpiringCondition(ctx, olsconfig)
+	newStatus.Conditions = append(newStatus.Conditions, credentialsCondition)
+
 	// Update status once, regardless of outcome (with retry on conflict)
 	if updateErr := r.UpdateStatusCondition(ctx, olsconfig, newStatus); updateErr != nil {
 		r.Logger.Error(updateErr, "Failed to update status")
 		return ctrl.Result{}, fmt.Errorf("failed to update OLSConfig status: %w", updateErr)
 	}
+	r.recordComponentTransitions(olsconfig, newStatus)
+	metrics.SetComponentReadiness(newStatus.Conditions)
+	metrics.SetPodDiagnostics(newStatus.DiagnosticInfo)
 
 	// Determine reconciliation result based on deployment status
 	var reconcileErr error
@@ -717,6 +843,14 @@ func (r *OLSConfigReconciler) reconcileDeploymentsAndStatus(ctx context.Context,
 			len(newStatus.DiagnosticInfo))
 	} else if newStatus.OverallStatus == olsv1alpha1.OverallStatusReady {
 		r.Logger.Info("reconciliation done", "olsconfig generation", olsconfig.Generation)
+	} else if rhokp.WarmUpPending(r, ctx, olsconfig) {
+		// Query the RHOKP pods waiting for their warm-up gate again shortly, no event reports the warm-up
+		r.Logger.Info("waiting for the RHOKP pods to warm up", "requeueAfter", utils.RHOKPWarmUpRecheckInterval)
+		return ctrl.Result{RequeueAfter: utils.RHOKPWarmUpRecheckInterval}, nil
+	} else if okp := meta.FindStatusCondition(newStatus.Conditions, utils.TypeRHOKPReady); okp != nil && okp.Reason == utils.ReasonOKPExternalUnreachable {
+		// Ping the external knowledge portal again periodically, nothing in the cluster reports its recovery
+		r.Logger.Info("waiting for the external knowledge portal to become reachable", "requeueAfter", utils.OKPExternalRecheckInterval)
+		return ctrl.Result{RequeueAfter: utils.OKPExternalRecheckInterval}, nil
 	} else {
 		// Deployments are progressing - return error to trigger retry
 		// This allows early detection of issues rather than waiting for deployment watch
@@ -726,6 +860,45 @@ func (r *OLSConfigReconciler) reconcileDeploymentsAndStatus(ctx context.Context,
 		reconcileErr = fmt.Errorf("deployments not ready yet, retrying")
 	}
 
+	if reconcileErr == nil {
+		metrics.SetLastSuccessfulReconcile(time.Now())
+		// Re-check the external knowledge portal periodically, nothing in the cluster reports its outages
+		if utils.OKPExternal(olsconfig) != nil && (requeueAfter <= 0 || requeueAfter > utils.OKPExternalRecheckInterval) {
+			requeueAfter = utils.OKPExternalRecheckInterval
+		}
+		// Roll out a RAG image update held back by the Window policy when the next window opens
+		if ragUpdate := newStatus.RAGUpdate; ragUpdate != nil && ragUpdate.NextWindowTime != nil {
+			untilWindow := max(time.Until(ragUpdate.NextWindowTime.Time), time.Second)
+			if requeueAfter <= 0 || untilWindow < requeueAfter {
+				requeueAfter = untilWindow
+			}
+		}
+		// Retry a failed RAG index build when its backoff elapses
+		for _, ragBuild := range newStatus.RAGBuilds {
+			if ragBuild.NextRetryTime == nil {
+				continue
+			}
+			untilRetry := max(time.Until(ragBuild.NextRetryTime.Time), time.Second)
+			if requeueAfter <= 0 || untilRetry < requeueAfter {
+				requeueAfter = untilRetry
+			}
+		}
+		// Warm up a RHOKP pod started while the Deployment stays ready, e.g. during a rolling update
+		if rhokp.WarmUpPending(r, ctx, olsconfig) && (requeueAfter <= 0 || requeueAfter > utils.RHOKPWarmUpRecheckInterval) {
+			requeueAfter = utils.RHOKPWarmUpRecheckInterval
+		}
+		// Rotate the RHOKP access key when its interval elapses
+		if nextRotation := rhokp.NextAccessKeyRotation(r, ctx, olsconfig); !nextRotation.IsZero() {
+			untilRotation := max(time.Until(nextRotation), time.Second)
+			if requeueAfter <= 0 || untilRotation < requeueAfter {
+				requeueAfter = untilRotation
+			}
+		}
+		if requeueAfter > 0 {
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+
 	return ctrl.Result{}, reconcileErr
 }
 
@@ -798,6 +971,8 @@ func (r *OLSConfigReconciler) finalizeOLSConfig(ctx context.Context, cr *olsv1al
 	r.Logger.V(1).Info("Removing Console UI during finalization")
 	if err := console.RemoveConsoleUI(r, ctx); err != nil {
 		r.Logger.Error(err, "Failed to remove Console UI during finalization")
+		utils.RecordEvent(r, cr, corev1.EventTypeWarning, utils.EventReasonCleanupFailed, utils.EventActionFinalize,
+			"Failed to remove Console UI: %v", err)
 		// Log the error but don't block finalization
 		// We still want the finalizer to complete to avoid CR being stuck in Terminating
 		// The Console removal functions handle NotFound errors gracefully
@@ -808,27 +983,44 @@ func (r *OLSConfigReconciler) finalizeOLSConfig(ctx context.Context, cr *olsv1al
 	r.Logger.V(1).Info("Removing Agentic Console UI during finalization")
 	if err := agenticconsole.RemoveAgenticConsole(r, ctx); err != nil {
 		r.Logger.Error(err, "Failed to remove Agentic Console UI during finalization")
+		utils.RecordEvent(r, cr, corev1.EventTypeWarning, utils.EventReasonCleanupFailed, utils.EventActionFinalize,
+			"Failed to remove Agentic Console UI: %v", err)
 		r.Logger.V(1).Info("Proceeding with finalization despite Agentic Console UI removal error")
 	}
 
 	r.Logger.V(1).Info("Removing alerts adapter operand during finalization")
 	if err := alertsadapter.RemoveAlertsAdapter(r, ctx); err != nil {
 		r.Logger.Error(err, "Failed to remove alerts adapter during finalization")
+		utils.RecordEvent(r, cr, corev1.EventTypeWarning, utils.EventReasonCleanupFailed, utils.EventActionFinalize,
+			"Failed to remove alerts adapter: %v", err)
 		r.Logger.V(1).Info("Proceeding with finalization despite alerts adapter removal error")
 	}
 
 	r.Logger.V(1).Info("Removing openshift-mcp-server operand during finalization")
 	if err := ocpmcp.Remove(r, ctx); err != nil {
 		r.Logger.Error(err, "Failed to remove openshift-mcp-server during finalization")
+		utils.RecordEvent(r, cr, corev1.EventTypeWarning, utils.EventReasonCleanupFailed, utils.EventActionFinalize,
+			"Failed to remove openshift-mcp-server: %v", err)
 		r.Logger.V(1).Info("Proceeding with finalization despite openshift-mcp-server removal error")
 	}
 
 	r.Logger.V(1).Info("Removing RHOKP operand during finalization")
 	if err := rhokp.Remove(r, ctx); err != nil {
 		r.Logger.Error(err, "Failed to remove RHOKP during finalization")
+		utils.RecordEvent(r, cr, corev1.EventTypeWarning, utils.EventReasonCleanupFailed, utils.EventActionFinalize,
+			"Failed to remove RHOKP: %v", err)
 		r.Logger.V(1).Info("Proceeding with finalization despite RHOKP removal error")
 	}
 
+	// Step 1c: Remove the console dashboard (lives in openshift-config-managed, no owner reference)
+	r.Logger.V(1).Info("Removing console dashboard during finalization")
+	if err := dashboard.Remove(r, ctx); err != nil {
+		r.Logger.Error(err, "Failed to remove console dashboard during finalization")
+		utils.RecordEvent(r, cr, corev1.EventTypeWarning, utils.EventReasonCleanupFailed, utils.EventActionFinalize,
+			"Failed to remove console dashboard: %v", err)
+		r.Logger.V(1).Info("Proceeding with finalization despite console dashboard removal error")
+	}
+
 	// Step 2: List all owned resources once (avoids duplicate API calls)
 	r.Logger.V(1).Info("Listing owned resources for cleanup")
 	resourceGroups, err := r.listOwnedResources(ctx, cr)
@@ -848,8 +1040,12 @@ func (r *OLSConfigReconciler) finalizeOLSConfig(ctx context.Context, cr *olsv1al
 	}
 
 	r.Logger.Info("Deleting owned resources", "total", totalResources, "counts", resourceCounts)
+	utils.RecordEvent(r, cr, corev1.EventTypeNormal, utils.EventReasonFinalizing, utils.EventActionFinalize,
+		"Deleting %d owned resources", totalResources)
 	if err := r.deleteOwnedResources(ctx, resourceGroups); err != nil {
 		r.Logger.Error(err, "Error deleting owned resources")
+		utils.RecordEvent(r, cr, corev1.EventTypeWarning, utils.EventReasonCleanupFailed, utils.EventActionFinalize,
+			"Failed to delete owned resources: %v", err)
 		// Continue anyway - wait logic will handle remaining resources
 	}
 
@@ -858,6 +1054,8 @@ func (r *OLSConfigReconciler) finalizeOLSConfig(ctx context.Context, cr *olsv1al
 	r.Logger.V(1).Info("Waiting for owned resources to be deleted")
 	if err := r.waitForOwnedResourcesDeletion(ctx, cr); err != nil {
 		r.Logger.Error(err, "Timeout or error waiting for owned resources deletion")
+		utils.RecordEvent(r, cr, corev1.EventTypeWarning, utils.EventReasonCleanupFailed, utils.EventActionFinalize,
+			"Owned resources were not deleted in time: %v", err)
 		// Don't return error here - we want to remove the finalizer anyway after timeout
 		// This prevents the CR from being stuck in Terminating state forever
 		r.Logger.V(1).Info("Proceeding with finalizer removal despite cleanup timeout")
@@ -1120,11 +1318,31 @@ func wasComponentEnabled(cr *olsv1alpha1.OLSConfig, conditionType string) bool {
 	return false
 }
 
+// checkOKPExternal checks the external knowledge portal, through OKPExternalChecker when set so that
+// the portal is only pinged on the periodic recheck or when its settings change
+func (r *OLSConfigReconciler) checkOKPExternal(ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	if r.OKPExternalChecker != nil {
+		return r.OKPExternalChecker.Check(r, ctx, cr)
+	}
+	return rhokp.CheckExternal(r, ctx, cr)
+}
+
+// wasRHOKPDeployed returns true if the RHOKP condition reports an operator-managed deployment,
+// so switching to byokRAGOnly or an external portal removes the RHOKP operand once.
+func wasRHOKPDeployed(cr *olsv1alpha1.OLSConfig) bool {
+	for _, c := range cr.Status.Conditions {
+		if c.Type == utils.TypeRHOKPReady {
+			return c.Reason != "Disabled" && c.Reason != utils.ReasonOKPExternal && c.Reason != utils.ReasonOKPExternalUnreachable
+		}
+	}
+	return false
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *OLSConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Logger = ctrl.Log.WithName("Reconciler")
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&olsv1alpha1.OLSConfig{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.ServiceAccount{}).
@@ -1134,6 +1352,9 @@ func (r *OLSConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&batchv1.Job{}).
+		Owns(&batchv1.CronJob{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Watches(&corev1.Secret{},
 			&watchers.SecretUpdateHandler{Reconciler: r},
 			builder.WithPredicates(predicate.Funcs{
@@ -1191,6 +1412,32 @@ func (r *OLSConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&consolev1.ConsolePlugin{}).
 		Owns(&monitoringv1.ServiceMonitor{}).
 		Owns(&monitoringv1.PrometheusRule{}).
-		Owns(&imagev1.ImageStream{}).
-		Complete(r)
+		Owns(&imagev1.ImageStream{})
+
+	// Regenerate the app server configuration when a served model endpoint changes
+	if r.Options.KServeAvailable {
+		b = b.Watches(utils.NewInferenceService(),
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: utils.OLSConfigName}}}
+			}),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldISVC, oldOK := e.ObjectOld.(*unstructured.Unstructured)
+					newISVC, newOK := e.ObjectNew.(*unstructured.Unstructured)
+					if !oldOK || !newOK {
+						return false
+					}
+					return utils.InferenceServiceURL(oldISVC) != utils.InferenceServiceURL(newISVC) ||
+						!reflect.DeepEqual(oldISVC.GetLabels(), newISVC.GetLabels())
+				},
+				GenericFunc: func(_ event.GenericEvent) bool { return false },
+			}))
+	}
+
+	// Restart the app server when the Secrets Store CSI driver rotates mounted provider credentials
+	if r.Options.SecretsStoreCSIAvailable {
+		b = b.Watches(utils.NewSecretProviderClassPodStatus(), &watchers.SecretProviderClassPodStatusHandler{Reconciler: r})
+	}
+
+	return b.Complete(r)
 }
@@ -0,0 +1,331 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+// Failure diagnostics
+//
+// status.diagnosticInfo only carries the reason, message and exit code of a failing container.
+// When a component is Failed, the operator additionally snapshots the tail of the failing
+// container's logs (the previous instance when it has restarted) and the Events of its pod into
+// the lightspeed-diagnostics ConfigMap, so that e.g. a CrashLoopBackOff shows the stack trace that
+// caused it. The ConfigMap is size-bounded: the oldest snapshots are rotated out once
+// DiagnosticsMaxSnapshots or DiagnosticsMaxTotalBytes is exceeded.
+
+// diagnosticsSnapshot is an entry of the index.json key of the diagnostics ConfigMap
+type diagnosticsSnapshot struct {
+	Key          string      `json:"key"`
+	Component    string      `json:"component"`
+	Pod          string      `json:"pod"`
+	Container    string      `json:"container"`
+	Reason       string      `json:"reason"`
+	RestartCount int32       `json:"restartCount"`
+	CapturedAt   metav1.Time `json:"capturedAt"`
+}
+
+// reconcileDiagnostics captures a snapshot for every failing container in failures that has not
+// been captured at its current restart count, stores it in the diagnostics ConfigMap and returns
+// the reference to publish in status. Capture needs KubeClient for the pod log and Event APIs;
+// without it the existing ConfigMap is only referenced.
+func (r *OLSConfigReconciler) reconcileDiagnostics(ctx context.Context, cr *olsv1alpha1.OLSConfig, failures []olsv1alpha1.PodDiagnostic) (*olsv1alpha1.DiagnosticsReference, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Name: utils.DiagnosticsConfigMapName, Namespace: r.Options.Namespace}, cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("%s: %w", utils.ErrGetDiagnosticsConfigMap, err)
+	}
+	exists := err == nil
+
+	index := readDiagnosticsIndex(cm)
+	captured := false
+	if r.KubeClient != nil {
+		for _, failure := range failingContainers(failures) {
+			snapshot, data, ok := r.captureContainerDiagnostics(ctx, failure, index)
+			if !ok {
+				continue
+			}
+			if cm.Data == nil {
+				cm.Data = map[string]string{}
+			}
+			cm.Data[snapshot.Key] = data
+			index = append([]diagnosticsSnapshot{snapshot}, removeSnapshot(index, snapshot.Key)...)
+			captured = true
+		}
+	}
+
+	if !captured {
+		if !exists || len(index) == 0 {
+			return nil, nil
+		}
+		return diagnosticsReference(cm, index), nil
+	}
+
+	index = rotateDiagnostics(cm, index)
+	indexJSON, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.ErrUpdateDiagnosticsConfigMap, err)
+	}
+	cm.Data[utils.DiagnosticsIndexKey] = string(indexJSON)
+
+	if !exists {
+		cm.Name = utils.DiagnosticsConfigMapName
+		cm.Namespace = r.Options.Namespace
+		cm.Labels = map[string]string{
+			"app.kubernetes.io/managed-by": "lightspeed-operator",
+			"app.kubernetes.io/part-of":    "openshift-lightspeed",
+		}
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme()); err != nil {
+			return nil, fmt.Errorf("%s: %w", utils.ErrCreateDiagnosticsConfigMap, err)
+		}
+		if err := r.Create(ctx, cm); err != nil {
+			return nil, fmt.Errorf("%s: %w", utils.ErrCreateDiagnosticsConfigMap, err)
+		}
+	} else if err := r.Update(ctx, cm); err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.ErrUpdateDiagnosticsConfigMap, err)
+	}
+	r.Logger.Info("failure diagnostics captured", "configmap", cm.Name, "latest", index[0].Key)
+	return diagnosticsReference(cm, index), nil
+}
+
+// captureContainerDiagnostics returns the snapshot of one failing container, or false when the
+// container is gone or was already captured at its current restart count.
+func (r *OLSConfigReconciler) captureContainerDiagnostics(ctx context.Context, failure olsv1alpha1.PodDiagnostic, index []diagnosticsSnapshot) (diagnosticsSnapshot, string, bool) {
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKey{Name: failure.PodName, Namespace: r.Options.Namespace}, pod); err != nil {
+		r.Logger.V(1).Info("skipping diagnostics capture, pod not found", "pod", failure.PodName, "error", err)
+		return diagnosticsSnapshot{}, "", false
+	}
+
+	container, isInit := strings.CutPrefix(failure.ContainerName, "init/")
+	statuses := pod.Status.ContainerStatuses
+	if isInit {
+		statuses = pod.Status.InitContainerStatuses
+	}
+	var containerStatus *corev1.ContainerStatus
+	for i := range statuses {
+		if statuses[i].Name == container {
+			containerStatus = &statuses[i]
+			break
+		}
+	}
+	if containerStatus == nil {
+		return diagnosticsSnapshot{}, "", false
+	}
+
+	key := diagnosticsKey(pod.Name, failure.ContainerName)
+	for _, s := range index {
+		if s.Key == key && s.RestartCount == containerStatus.RestartCount {
+			return diagnosticsSnapshot{}, "", false
+		}
+	}
+
+	snapshot := diagnosticsSnapshot{
+		Key:          key,
+		Component:    failure.FailedComponent,
+		Pod:          pod.Name,
+		Container:    failure.ContainerName,
+		Reason:       failure.Reason,
+		RestartCount: containerStatus.RestartCount,
+		CapturedAt:   metav1.Now(),
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Component: %s\nPod: %s\nContainer: %s\nReason: %s\nRestart count: %d\nCaptured at: %s\n",
+		snapshot.Component, snapshot.Pod, snapshot.Container, snapshot.Reason, snapshot.RestartCount,
+		snapshot.CapturedAt.UTC().Format(time.RFC3339))
+
+	previous := containerStatus.LastTerminationState.Terminated != nil
+	logs, err := r.containerLogTail(ctx, pod.Name, container, previous)
+	if err != nil && previous {
+		// The previous instance may already be garbage collected, fall back to the current one
+		previous = false
+		logs, err = r.containerLogTail(ctx, pod.Name, container, false)
+	}
+	if previous {
+		fmt.Fprintf(&b, "\n--- Logs of the previous container instance (last %d lines) ---\n", utils.DiagnosticsLogTailLines)
+	} else {
+		fmt.Fprintf(&b, "\n--- Container logs (last %d lines) ---\n", utils.DiagnosticsLogTailLines)
+	}
+	if err != nil {
+		fmt.Fprintf(&b, "logs unavailable: %v\n", err)
+	} else {
+		b.WriteString(logs)
+		if !strings.HasSuffix(logs, "\n") {
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n--- Pod events ---\n")
+	podEvents, err := r.podEvents(ctx, pod.Name)
+	if err != nil {
+		fmt.Fprintf(&b, "events unavailable: %v\n", err)
+	}
+	for _, e := range podEvents {
+		fmt.Fprintf(&b, "%s %s %s (x%d): %s\n",
+			eventTime(e).UTC().Format(time.RFC3339), e.Type, e.Reason, max(e.Count, 1), e.Message)
+	}
+
+	return snapshot, b.String(), true
+}
+
+// containerLogTail returns the last DiagnosticsLogTailLines lines of a container log, truncated
+// from the front to DiagnosticsLogMaxBytes so the end of a stack trace is always kept.
+func (r *OLSConfigReconciler) containerLogTail(ctx context.Context, pod, container string, previous bool) (string, error) {
+	tailLines := int64(utils.DiagnosticsLogTailLines)
+	stream, err := r.KubeClient.CoreV1().Pods(r.Options.Namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		TailLines: &tailLines,
+	}).Stream(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = stream.Close() }()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, stream); err != nil {
+		return "", err
+	}
+	logs := buf.Bytes()
+	if len(logs) > utils.DiagnosticsLogMaxBytes {
+		logs = logs[len(logs)-utils.DiagnosticsLogMaxBytes:]
+		if i := bytes.IndexByte(logs, '\n'); i >= 0 {
+			logs = logs[i+1:]
+		}
+		return "[truncated]\n" + string(logs), nil
+	}
+	return string(logs), nil
+}
+
+// podEvents returns the most recent Events regarding a pod, oldest first
+func (r *OLSConfigReconciler) podEvents(ctx context.Context, pod string) ([]corev1.Event, error) {
+	eventList, err := r.KubeClient.CoreV1().Events(r.Options.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{
+			"involvedObject.kind": "Pod",
+			"involvedObject.name": pod,
+		}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := eventList.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return eventTime(items[i]).Before(eventTime(items[j]))
+	})
+	if len(items) > utils.DiagnosticsMaxEvents {
+		items = items[len(items)-utils.DiagnosticsMaxEvents:]
+	}
+	return items, nil
+}
+
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+// failingContainers returns one diagnostic per failing pod container; pod-level diagnostics
+// (scheduling, readiness) have no logs to capture.
+func failingContainers(diagnostics []olsv1alpha1.PodDiagnostic) []olsv1alpha1.PodDiagnostic {
+	var result []olsv1alpha1.PodDiagnostic
+	seen := map[string]bool{}
+	for _, d := range diagnostics {
+		if d.ContainerName == "" {
+			continue
+		}
+		key := diagnosticsKey(d.PodName, d.ContainerName)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, d)
+	}
+	return result
+}
+
+// diagnosticsKey returns the ConfigMap key of a container snapshot, e.g. "<pod>.<container>.log"
+// or "<pod>.init-<container>.log" for init containers.
+func diagnosticsKey(pod, container string) string {
+	return fmt.Sprintf("%s.%s.log", pod, strings.ReplaceAll(container, "/", "-"))
+}
+
+func readDiagnosticsIndex(cm *corev1.ConfigMap) []diagnosticsSnapshot {
+	var index []diagnosticsSnapshot
+	if raw, ok := cm.Data[utils.DiagnosticsIndexKey]; ok {
+		if err := json.Unmarshal([]byte(raw), &index); err != nil {
+			// A corrupt index only loses the rotation order; the snapshots are rebuilt on the next failure
+			return nil
+		}
+	}
+	return index
+}
+
+func removeSnapshot(index []diagnosticsSnapshot, key string) []diagnosticsSnapshot {
+	result := make([]diagnosticsSnapshot, 0, len(index))
+	for _, s := range index {
+		if s.Key != key {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// rotateDiagnostics drops the oldest snapshots (index is newest first) beyond DiagnosticsMaxSnapshots
+// or DiagnosticsMaxTotalBytes, along with any key not listed in the index. The newest snapshot is always kept.
+func rotateDiagnostics(cm *corev1.ConfigMap, index []diagnosticsSnapshot) []diagnosticsSnapshot {
+	kept := make([]diagnosticsSnapshot, 0, len(index))
+	total := 0
+	for _, s := range index {
+		data, ok := cm.Data[s.Key]
+		if !ok {
+			continue
+		}
+		if len(kept) > 0 && (len(kept) >= utils.DiagnosticsMaxSnapshots || total+len(data) > utils.DiagnosticsMaxTotalBytes) {
+			break
+		}
+		kept = append(kept, s)
+		total += len(data)
+	}
+
+	keep := map[string]bool{utils.DiagnosticsIndexKey: true}
+	for _, s := range kept {
+		keep[s.Key] = true
+	}
+	for key := range cm.Data {
+		if !keep[key] {
+			delete(cm.Data, key)
+		}
+	}
+	return kept
+}
+
+func diagnosticsReference(cm *corev1.ConfigMap, index []diagnosticsSnapshot) *olsv1alpha1.DiagnosticsReference {
+	return &olsv1alpha1.DiagnosticsReference{
+		Name:         cm.Name,
+		Namespace:    cm.Namespace,
+		LatestKey:    index[0].Key,
+		LastCaptured: index[0].CapturedAt,
+	}
+}
@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+var _ = Describe("Failure diagnostics", func() {
+	const podName = "lightspeed-app-server-diagnostics"
+
+	var (
+		ctx        context.Context
+		reconciler *OLSConfigReconciler
+		cr         *olsv1alpha1.OLSConfig
+		pod        *corev1.Pod
+		failures   []olsv1alpha1.PodDiagnostic
+	)
+
+	setRestartCount := func(count int32) {
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:         "lightspeed-service-api",
+			RestartCount: count,
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+			},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+			},
+		}}
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+	}
+
+	readIndex := func() []diagnosticsSnapshot {
+		cm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: utils.DiagnosticsConfigMapName, Namespace: utils.OLSNamespaceDefault}, cm)).To(Succeed())
+		return readDiagnosticsIndex(cm)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		kubeClient := fake.NewClientset(&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: podName + ".backoff", Namespace: utils.OLSNamespaceDefault},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: podName, Namespace: utils.OLSNamespaceDefault},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			Message:        "Back-off restarting failed container",
+			Count:          4,
+		})
+		reconciler = &OLSConfigReconciler{
+			Client:     k8sClient,
+			Options:    getDefaultReconcilerOptions(utils.OLSNamespaceDefault),
+			Logger:     logf.Log.WithName("test.diagnostics"),
+			KubeClient: kubeClient,
+		}
+		cr = utils.GetDefaultOLSConfigCR()
+		cr.UID = "diagnostics-test-uid"
+
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: utils.OLSNamespaceDefault},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "lightspeed-service-api", Image: "lightspeed-service:latest"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+		setRestartCount(3)
+
+		failures = []olsv1alpha1.PodDiagnostic{
+			{FailedComponent: utils.TypeApiReady, PodName: podName, ContainerName: "lightspeed-service-api", Reason: "CrashLoopBackOff"},
+			{FailedComponent: utils.TypeApiReady, PodName: podName, ContainerName: "lightspeed-service-api", Reason: "PreviousCrash: Error"},
+			{FailedComponent: utils.TypeApiReady, PodName: podName, Reason: "Unschedulable"},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, pod))).To(Succeed())
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: utils.DiagnosticsConfigMapName, Namespace: utils.OLSNamespaceDefault}}
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cm))).To(Succeed())
+	})
+
+	It("should not create the ConfigMap without failures", func() {
+		ref, err := reconciler.reconcileDiagnostics(ctx, cr, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ref).To(BeNil())
+	})
+
+	It("should capture the log tail and pod events of a failing container", func() {
+		ref, err := reconciler.reconcileDiagnostics(ctx, cr, failures)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ref).NotTo(BeNil())
+		Expect(ref.Name).To(Equal(utils.DiagnosticsConfigMapName))
+		Expect(ref.Namespace).To(Equal(utils.OLSNamespaceDefault))
+		Expect(ref.LatestKey).To(Equal(podName + ".lightspeed-service-api.log"))
+
+		cm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: utils.DiagnosticsConfigMapName, Namespace: utils.OLSNamespaceDefault}, cm)).To(Succeed())
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+		Expect(cm.Data).To(HaveLen(2))
+		snapshot := cm.Data[ref.LatestKey]
+		Expect(snapshot).To(ContainSubstring("Component: " + utils.TypeApiReady))
+		Expect(snapshot).To(ContainSubstring("Restart count: 3"))
+		Expect(snapshot).To(ContainSubstring("Logs of the previous container instance"))
+		Expect(snapshot).To(ContainSubstring("fake logs"))
+		Expect(snapshot).To(ContainSubstring("Warning BackOff (x4): Back-off restarting failed container"))
+
+		index := readIndex()
+		Expect(index).To(HaveLen(1))
+		Expect(index[0].RestartCount).To(Equal(int32(3)))
+	})
+
+	It("should capture a container again only after it restarted", func() {
+		_, err := reconciler.reconcileDiagnostics(ctx, cr, failures)
+		Expect(err).NotTo(HaveOccurred())
+		first := readIndex()[0].CapturedAt
+
+		ref, err := reconciler.reconcileDiagnostics(ctx, cr, failures)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ref.LastCaptured.Equal(&first)).To(BeTrue())
+
+		setRestartCount(4)
+		_, err = reconciler.reconcileDiagnostics(ctx, cr, failures)
+		Expect(err).NotTo(HaveOccurred())
+		index := readIndex()
+		Expect(index).To(HaveLen(1))
+		Expect(index[0].RestartCount).To(Equal(int32(4)))
+	})
+
+	It("should keep referencing captured snapshots once the component recovered", func() {
+		_, err := reconciler.reconcileDiagnostics(ctx, cr, failures)
+		Expect(err).NotTo(HaveOccurred())
+
+		ref, err := reconciler.reconcileDiagnostics(ctx, cr, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ref).NotTo(BeNil())
+		Expect(ref.LatestKey).To(Equal(podName + ".lightspeed-service-api.log"))
+	})
+
+	It("should not capture without a clientset", func() {
+		reconciler.KubeClient = nil
+		ref, err := reconciler.reconcileDiagnostics(ctx, cr, failures)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ref).To(BeNil())
+	})
+
+	It("should rotate out the oldest snapshots", func() {
+		cm := &corev1.ConfigMap{Data: map[string]string{"stale.log": "x"}}
+		var index []diagnosticsSnapshot
+		for i := 0; i < utils.DiagnosticsMaxSnapshots+2; i++ {
+			key := diagnosticsKey("pod", "c"+strings.Repeat("x", i))
+			cm.Data[key] = "snapshot"
+			index = append(index, diagnosticsSnapshot{Key: key})
+		}
+		kept := rotateDiagnostics(cm, index)
+		Expect(kept).To(HaveLen(utils.DiagnosticsMaxSnapshots))
+		Expect(kept[0].Key).To(Equal(index[0].Key))
+		Expect(cm.Data).To(HaveLen(utils.DiagnosticsMaxSnapshots))
+		Expect(cm.Data).NotTo(HaveKey("stale.log"))
+
+		big := strings.Repeat("x", utils.DiagnosticsMaxTotalBytes/2+1)
+		cm = &corev1.ConfigMap{Data: map[string]string{"a.log": big, "b.log": big}}
+		kept = rotateDiagnostics(cm, []diagnosticsSnapshot{{Key: "a.log"}, {Key: "b.log"}})
+		Expect(kept).To(HaveLen(1))
+		Expect(cm.Data).To(HaveKey("a.log"))
+	})
+
+	It("should name init container snapshots distinctly", func() {
+		Expect(diagnosticsKey("pod", "init/wait-for-db")).To(Equal("pod.init-wait-for-db.log"))
+		indexJSON, err := json.Marshal([]diagnosticsSnapshot{{Key: "k"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(readDiagnosticsIndex(&corev1.ConfigMap{Data: map[string]string{utils.DiagnosticsIndexKey: string(indexJSON)}})).To(HaveLen(1))
+	})
+})
@@ -3,19 +3,25 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
 	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+	"github.com/openshift/lightspeed-operator/internal/metrics"
 )
 
 // This file contains support functions for OLSConfigReconciler:
@@ -101,6 +107,10 @@ func (r *OLSConfigReconciler) GetWatcherConfig() interface{} {
 	return r.WatcherConfig
 }
 
+func (r *OLSConfigReconciler) GetEventRecorder() events.EventRecorder {
+	return r.Recorder
+}
+
 // Status management
 
 // UpdateStatusCondition updates the complete status of the OLSConfig Custom Resource instance.
@@ -176,6 +186,153 @@ func (r *OLSConfigReconciler) UpdateStatusCondition(ctx context.Context, olsconf
 	}
 }
 
+// recordComponentTransitions emits an Event for every operand condition that moved into or out
+// of the "Disabled" state, and for every LLM provider whose preflight started failing. The old
+// status is taken from cr, which still holds the status observed before this reconciliation;
+// components seen for the first time are not reported, providers are.
+func (r *OLSConfigReconciler) recordComponentTransitions(cr *olsv1alpha1.OLSConfig, newStatus olsv1alpha1.OLSConfigStatus) {
+	for _, provider := range newStatus.Providers {
+		if provider.LastError == "" {
+			continue
+		}
+		idx := slices.IndexFunc(cr.Status.Providers, func(p olsv1alpha1.ProviderStatus) bool { return p.Name == provider.Name })
+		if idx >= 0 && cr.Status.Providers[idx].LastError != "" {
+			continue
+		}
+		utils.RecordEvent(r, cr, corev1.EventTypeWarning, utils.EventReasonProviderPreflightFailed, utils.EventActionValidate,
+			"LLM provider %s preflight failed (auth %s): %s", provider.Name, provider.AuthResult, provider.LastError)
+	}
+	// Informational conditions emit a Warning event when they start warning
+	for _, warning := range []struct {
+		condType string
+		status   metav1.ConditionStatus
+		reason   string
+	}{
+		{utils.TypeToolCallingSupported, metav1.ConditionFalse, utils.EventReasonToolCallingUnsupported},
+		{utils.TypeCredentialsExpiring, metav1.ConditionTrue, utils.EventReasonCredentialsExpiring},
+	} {
+		cond := meta.FindStatusCondition(newStatus.Conditions, warning.condType)
+		if cond == nil || cond.Status != warning.status {
+			continue
+		}
+		if old := meta.FindStatusCondition(cr.Status.Conditions, warning.condType); old == nil || old.Status != warning.status {
+			utils.RecordEvent(r, cr, corev1.EventTypeWarning, warning.reason, utils.EventActionValidate, "%s", cond.Message)
+		}
+	}
+	for _, cond := range newStatus.Conditions {
+		old := meta.FindStatusCondition(cr.Status.Conditions, cond.Type)
+		if old == nil {
+			continue
+		}
+		wasDisabled := old.Reason == "Disabled"
+		isDisabled := cond.Reason == "Disabled"
+		switch {
+		case wasDisabled && !isDisabled:
+			utils.RecordEvent(r, cr, corev1.EventTypeNormal, utils.EventReasonComponentEnabled, utils.EventActionReconcile,
+				"Component %s enabled", cond.Type)
+		case !wasDisabled && isDisabled:
+			utils.RecordEvent(r, cr, corev1.EventTypeNormal, utils.EventReasonComponentDisabled, utils.EventActionReconcile,
+				"Component %s disabled: %s", cond.Type, cond.Message)
+		}
+	}
+}
+
+// toolCallingCondition reports whether the default model supports tool calling when MCP servers
+// or a tools approval policy are configured. Models without declared capabilities are assumed to.
+func toolCallingCondition(cr *olsv1alpha1.OLSConfig) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               utils.TypeToolCallingSupported,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cr.Generation,
+		Reason:             "Supported",
+		Message:            "The default model supports tool calling",
+		LastTransitionTime: metav1.Now(),
+	}
+	if !utils.ToolCallingRequired(cr) {
+		condition.Reason = "NotRequired"
+		condition.Message = "No MCP servers or tools approval are configured"
+		return condition
+	}
+	model := utils.FindModel(cr, cr.Spec.OLSConfig.DefaultProvider, cr.Spec.OLSConfig.DefaultModel)
+	if !utils.ModelSupportsToolCalling(model) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Unsupported"
+		condition.Message = fmt.Sprintf("MCP servers or tools approval are configured but the default model %s of provider %s does not support tool calling",
+			cr.Spec.OLSConfig.DefaultModel, cr.Spec.OLSConfig.DefaultProvider)
+	}
+	return condition
+}
+
+// credentialsExpiringCondition reads the expiry of the credentials in the Secrets referenced by the CR,
+// updates the credential expiry metrics and reports the CredentialsExpiring condition.
+// It also returns the time until the condition changes without any event, zero when no expiry is known.
+func (r *OLSConfigReconciler) credentialsExpiringCondition(ctx context.Context, cr *olsv1alpha1.OLSConfig) (metav1.Condition, time.Duration) {
+	days := cr.Spec.LLMConfig.CredentialsExpiryWarningDays
+	if days == 0 {
+		days = utils.CredentialsExpiryWarningDaysDefault
+	}
+	window := time.Duration(days) * 24 * time.Hour
+	now := time.Now()
+
+	expiries := map[string]time.Time{}
+	var expiring, expired []string
+	var recheck time.Duration
+	_ = utils.ForEachExternalSecret(cr, func(name, source string) error {
+		if _, seen := expiries[name]; seen {
+			return nil
+		}
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: r.Options.Namespace}, secret); err != nil {
+			// Missing secrets are reported by the credential validation
+			return nil
+		}
+		expiry, from, err := utils.CredentialsExpiry(secret)
+		if err != nil {
+			r.Logger.Error(err, "Failed to read credentials expiry", "secret", name, "source", source)
+			return nil
+		}
+		if expiry.IsZero() {
+			return nil
+		}
+		expiries[name] = expiry
+		remaining := expiry.Sub(now)
+		next := remaining - window
+		switch {
+		case remaining <= 0:
+			expired = append(expired, fmt.Sprintf("%s (%s, expired %s)", name, from, expiry.Format(time.RFC3339)))
+			return nil
+		case remaining <= window:
+			expiring = append(expiring, fmt.Sprintf("%s (%s, expires %s)", name, from, expiry.Format(time.RFC3339)))
+			next = remaining
+		}
+		if recheck == 0 || next < recheck {
+			recheck = next
+		}
+		return nil
+	})
+	metrics.SetCredentialsExpiry(expiries, window, now)
+
+	condition := metav1.Condition{
+		Type:               utils.TypeCredentialsExpiring,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cr.Generation,
+		Reason:             "NotExpiring",
+		Message:            fmt.Sprintf("No referenced credentials expire within %d days", days),
+		LastTransitionTime: metav1.Now(),
+	}
+	if len(expired) > 0 || len(expiring) > 0 {
+		sort.Strings(expired)
+		sort.Strings(expiring)
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Expiring"
+		if len(expired) > 0 {
+			condition.Reason = "Expired"
+		}
+		condition.Message = fmt.Sprintf("Credentials expire within %d days, rotate them: %s", days, strings.Join(append(expired, expiring...), ", "))
+	}
+	return condition, recheck
+}
+
 // checkDeploymentStatus checks if the deployment is ready and collects diagnostics on failure.
 // Returns the status (Ready/Progressing/Failed), diagnostics array, and error.
 func (r *OLSConfigReconciler) checkDeploymentStatus(
@@ -213,6 +370,19 @@ func (r *OLSConfigReconciler) checkDeploymentStatus(
 	return string(olsv1alpha1.DeploymentStatusProgressing), diagnostics, nil
 }
 
+// deploymentReadyMessage returns the message of an Available or Progressing deployment condition. RHOKP,
+// which may run several replicas, also reports the ratio of ready replicas.
+func deploymentReadyMessage(conditionType string, deployment *appsv1.Deployment, message string) string {
+	if conditionType != utils.TypeRHOKPReady {
+		return message
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return fmt.Sprintf("%s: %d/%d replicas ready", message, deployment.Status.ReadyReplicas, replicas)
+}
+
 // collectDeploymentDiagnostics collects pod-level diagnostics for a failed deployment.
 // Returns a slice of PodDiagnostic entries describing what went wrong with each pod.
 func (r *OLSConfigReconciler) collectDeploymentDiagnostics(
@@ -389,12 +559,16 @@ func (r *OLSConfigReconciler) annotateExternalResources(ctx context.Context,
 
 	// Validate external secrets first (fail fast)
 	if err := utils.ValidateLLMCredentials(r, ctx, cr); err != nil {
+		utils.RecordEvent(r, cr, corev1.EventTypeWarning, utils.EventReasonCredentialsValidationFailed, utils.EventActionValidate,
+			"LLM credentials validation failed: %v", err)
 		return fmt.Errorf("LLM credentials validation failed: %w", err)
 	}
 
 	// Validate TLS secret if custom TLS is configured
 	if cr.Spec.OLSConfig.TLSConfig != nil && cr.Spec.OLSConfig.TLSConfig.KeyCertSecretRef.Name != "" {
 		if err := utils.ValidateTLSSecret(r, ctx, cr); err != nil {
+			utils.RecordEvent(r, cr, corev1.EventTypeWarning, utils.EventReasonTLSSecretValidationFailed, utils.EventActionValidate,
+				"TLS secret validation failed: %v", err)
 			return fmt.Errorf("TLS secret validation failed: %w", err)
 		}
 	}
@@ -417,6 +591,14 @@ func (r *OLSConfigReconciler) annotateExternalResources(ctx context.Context,
 				utils.OLSAppServerDeploymentName,
 			}
 		}
+		// A rotated RHOKP access key restarts RHOKP and its clients, and refreshes the agentic handoff
+		if r.WatcherConfig != nil && source == "rhokp-access-key" {
+			r.WatcherConfig.AnnotatedSecretMapping[name] = []string{
+				utils.RHOKPDeploymentName,
+				utils.OLSAppServerDeploymentName,
+				utils.AgenticConfigurationConfigMapName,
+			}
+		}
 
 		if err := r.annotateSecretIfNeeded(ctx, name, r.Options.Namespace); err != nil {
 			r.Logger.Error(err, "Failed to annotate secret", "source", source, "secret", name)
@@ -470,12 +652,12 @@ func (r *OLSConfigReconciler) syncOpenShiftMCPServerTLSWatcher(cr *olsv1alpha1.O
 }
 
 // syncRHOKPTLSWatcher enables watching lightspeed-rhokp-tls only while
-// byokRAGOnly is false. Same pattern as MCP TLS watcher.
+// the operator deploys RHOKP. Same pattern as MCP TLS watcher.
 func (r *OLSConfigReconciler) syncRHOKPTLSWatcher(cr *olsv1alpha1.OLSConfig) {
 	if r.WatcherConfig == nil {
 		return
 	}
-	r.WatcherConfig.RHOKPTLSWatchEnabled.Store(!cr.Spec.OLSConfig.ByokRAGOnly)
+	r.WatcherConfig.RHOKPTLSWatchEnabled.Store(utils.IsRHOKPDeployed(cr))
 }
 
 // annotateSecretIfNeeded annotates a secret with the watcher annotation if it doesn't already have it.
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -10,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -354,6 +356,166 @@ var _ = Describe("isRESTMappingError", func() {
 	})
 })
 
+var _ = Describe("deploymentReadyMessage", func() {
+	It("should report the ratio of ready RHOKP replicas", func() {
+		deployment := &appsv1.Deployment{
+			Spec:   appsv1.DeploymentSpec{Replicas: &[]int32{3}[0]},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 2},
+		}
+		Expect(deploymentReadyMessage(utils.TypeRHOKPReady, deployment, "Ready")).To(Equal("Ready: 2/3 replicas ready"))
+		Expect(deploymentReadyMessage(utils.TypeApiReady, deployment, "Ready")).To(Equal("Ready"))
+	})
+})
+
+var _ = Describe("recordComponentTransitions", func() {
+	condition := func(condType, reason string) metav1.Condition {
+		return metav1.Condition{Type: condType, Reason: reason, Message: reason}
+	}
+
+	It("should emit ComponentDisabled and ComponentEnabled on Disabled transitions", func() {
+		recorder := events.NewFakeRecorder(10)
+		r := &OLSConfigReconciler{Recorder: recorder}
+		cr := utils.GetDefaultOLSConfigCR()
+		cr.Status.Conditions = []metav1.Condition{
+			condition(utils.TypeMCPServerReady, "Available"),
+			condition(utils.TypeRHOKPReady, "Disabled"),
+			condition(utils.TypeApiReady, "Progressing"),
+		}
+
+		r.recordComponentTransitions(cr, olsv1alpha1.OLSConfigStatus{Conditions: []metav1.Condition{
+			condition(utils.TypeMCPServerReady, "Disabled"),
+			condition(utils.TypeRHOKPReady, "Progressing"),
+			condition(utils.TypeApiReady, "Available"),
+			condition(utils.TypeCacheReady, "Available"),
+		}})
+
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal " + utils.EventReasonComponentDisabled + " Component " + utils.TypeMCPServerReady)))
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal " + utils.EventReasonComponentEnabled + " Component " + utils.TypeRHOKPReady)))
+		Expect(recorder.Events).NotTo(Receive())
+	})
+
+	It("should not panic without a recorder", func() {
+		r := &OLSConfigReconciler{}
+		cr := utils.GetDefaultOLSConfigCR()
+		cr.Status.Conditions = []metav1.Condition{condition(utils.TypeMCPServerReady, "Available")}
+		Expect(func() {
+			r.recordComponentTransitions(cr, olsv1alpha1.OLSConfigStatus{Conditions: []metav1.Condition{
+				condition(utils.TypeMCPServerReady, "Disabled"),
+			}})
+		}).NotTo(Panic())
+	})
+})
+
+var _ = Describe("toolCallingCondition", func() {
+	var cr *olsv1alpha1.OLSConfig
+
+	BeforeEach(func() {
+		cr = utils.GetDefaultOLSConfigCR()
+		cr.Spec.OLSConfig.IntrospectionEnabled = utils.BoolPtr(false)
+	})
+
+	It("should not require tool calling without MCP servers or tools approval", func() {
+		cr.Spec.LLMConfig.Providers[0].Models[0].Capabilities = &olsv1alpha1.ModelCapabilitiesSpec{ToolCalling: utils.BoolPtr(false)}
+		condition := toolCallingCondition(cr)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("NotRequired"))
+	})
+
+	It("should assume tool calling for models without declared capabilities", func() {
+		cr.Spec.OLSConfig.ToolsApprovalConfig = &olsv1alpha1.ToolsApprovalConfig{}
+		condition := toolCallingCondition(cr)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("Supported"))
+	})
+
+	It("should warn when the default model, found by name or alias, does not support tool calling", func() {
+		cr.Spec.OLSConfig.IntrospectionEnabled = nil
+		cr.Spec.LLMConfig.Providers[0].Models[0].Alias = "prod-default"
+		cr.Spec.LLMConfig.Providers[0].Models[0].Capabilities = &olsv1alpha1.ModelCapabilitiesSpec{ToolCalling: utils.BoolPtr(false)}
+		cr.Spec.OLSConfig.DefaultModel = "prod-default"
+		condition := toolCallingCondition(cr)
+		Expect(condition.Type).To(Equal(utils.TypeToolCallingSupported))
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("Unsupported"))
+		Expect(condition.Message).To(ContainSubstring("default model prod-default of provider " + cr.Spec.OLSConfig.DefaultProvider))
+
+		recorder := events.NewFakeRecorder(10)
+		r := &OLSConfigReconciler{Recorder: recorder}
+		r.recordComponentTransitions(cr, olsv1alpha1.OLSConfigStatus{Conditions: []metav1.Condition{condition}})
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning " + utils.EventReasonToolCallingUnsupported)))
+
+		cr.Status.Conditions = []metav1.Condition{condition}
+		r.recordComponentTransitions(cr, olsv1alpha1.OLSConfigStatus{Conditions: []metav1.Condition{condition}})
+		Expect(recorder.Events).NotTo(Receive())
+	})
+})
+
+var _ = Describe("credentialsExpiringCondition", func() {
+	var (
+		reconciler *OLSConfigReconciler
+		cr         *olsv1alpha1.OLSConfig
+		secret     *corev1.Secret
+		ctx        = context.Background()
+	)
+
+	BeforeEach(func() {
+		reconciler = &OLSConfigReconciler{
+			Client:  k8sClient,
+			Options: getDefaultReconcilerOptions(utils.OLSNamespaceDefault),
+			Logger:  logf.Log.WithName("test.reconciler"),
+		}
+		cr = utils.GetDefaultOLSConfigCR()
+		cr.Spec.LLMConfig.Providers[0].CredentialsSecretRef.Name = "test-llm-secret-expiry"
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-llm-secret-expiry", Namespace: utils.OLSNamespaceDefault},
+			Data:       map[string][]byte{"apitoken": []byte("test-token")},
+		}
+	})
+
+	AfterEach(func() {
+		_ = k8sClient.Delete(ctx, secret)
+	})
+
+	It("should not warn for long-lived credentials", func() {
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		condition, recheck := reconciler.credentialsExpiringCondition(ctx, cr)
+		Expect(condition.Type).To(Equal(utils.TypeCredentialsExpiring))
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("NotExpiring"))
+		Expect(recheck).To(BeZero())
+	})
+
+	It("should recheck when credentials enter the warning window", func() {
+		secret.Annotations = map[string]string{
+			utils.CredentialsExpiryAnnotationKey: time.Now().Add(30 * 24 * time.Hour).UTC().Format(time.RFC3339),
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		condition, recheck := reconciler.credentialsExpiringCondition(ctx, cr)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(recheck).To(BeNumerically("~", 16*24*time.Hour, time.Minute))
+	})
+
+	It("should warn when credentials expire within the warning window or have expired", func() {
+		cr.Spec.LLMConfig.CredentialsExpiryWarningDays = 45
+		secret.Annotations = map[string]string{
+			utils.CredentialsExpiryAnnotationKey: time.Now().Add(30 * 24 * time.Hour).UTC().Format(time.RFC3339),
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		condition, recheck := reconciler.credentialsExpiringCondition(ctx, cr)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("Expiring"))
+		Expect(condition.Message).To(ContainSubstring("test-llm-secret-expiry (annotation ols.openshift.io/credentials-expiry, expires "))
+		Expect(recheck).To(BeNumerically("~", 30*24*time.Hour, time.Minute))
+
+		secret.Annotations[utils.CredentialsExpiryAnnotationKey] = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+		condition, recheck = reconciler.credentialsExpiringCondition(ctx, cr)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("Expired"))
+		Expect(recheck).To(BeZero())
+	})
+})
+
 var _ = Describe("Helper Functions", func() {
 	var (
 		reconciler    *OLSConfigReconciler
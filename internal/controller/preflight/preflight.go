@@ -0,0 +1,369 @@
+// Package preflight checks the connectivity of the LLM providers configured in the OLSConfig CR.
+//
+// ValidateLLMCredentials only verifies that the credential Secrets carry the expected keys, so a
+// wrong URL or API key used to surface only when a user asked a question. When
+// spec.llm.preflight.enabled is true, the operator makes a minimal authenticated request to every
+// provider from its own pod, with the provider headers: for OpenAI compatible providers (openai, rhoai_vllm, rhelai_vllm) and
+// Azure OpenAI with an API key it lists the models; for the other provider types it only checks
+// that the endpoint answers. Requests go through spec.ols.proxyConfig (or the cluster proxy from the
+// operator environment), trust the system roots plus spec.ols.additionalCAConfigMapRef and the proxy
+// CA, and honour the provider tlsSecurityProfile.
+//
+// The outcome is published as status.providers. Results are cached by the Prober and only probed
+// again when the provider configuration, its credentials or the trusted CAs change, or when the
+// result is older than spec.llm.preflight.recheckIntervalMinutes (one minute for failures).
+package preflight
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+	utiltls "github.com/openshift/lightspeed-operator/internal/tls"
+)
+
+// Prober runs the provider preflight and remembers the latest result of every provider.
+// It is safe for concurrent use.
+type Prober struct {
+	mu      sync.Mutex
+	results map[string]cachedResult
+}
+
+type cachedResult struct {
+	fingerprint string
+	status      olsv1alpha1.ProviderStatus
+}
+
+// NewProber returns a Prober with an empty result cache.
+func NewProber() *Prober {
+	return &Prober{results: map[string]cachedResult{}}
+}
+
+// trustConfig holds the transport settings shared by all providers of a CR, and the error reading them
+type trustConfig struct {
+	utils.TrustConfig
+	err error
+}
+
+// ProbeProviders returns the preflight status of every provider in spec.llm.providers, in spec order.
+// It returns nil when the preflight is disabled. fake_provider entries are not probed.
+func (p *Prober) ProbeProviders(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) []olsv1alpha1.ProviderStatus {
+	spec := cr.Spec.LLMConfig.Preflight
+	if spec == nil || !spec.Enabled {
+		p.mu.Lock()
+		p.results = map[string]cachedResult{}
+		p.mu.Unlock()
+		return nil
+	}
+
+	timeout := time.Duration(utils.ProviderPreflightTimeoutSecondsDefault) * time.Second
+	if spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(spec.TimeoutSeconds) * time.Second
+	}
+	recheck := time.Duration(utils.ProviderPreflightRecheckIntervalMinutesDefault) * time.Minute
+	if spec.RecheckIntervalMinutes > 0 {
+		recheck = time.Duration(spec.RecheckIntervalMinutes) * time.Minute
+	}
+
+	trust := loadTrustConfig(r, ctx, cr)
+
+	var providers []olsv1alpha1.ProviderSpec
+	for _, provider := range cr.Spec.LLMConfig.Providers {
+		if provider.Type != utils.FakeProviderType {
+			providers = append(providers, provider)
+		}
+	}
+
+	statuses := make([]olsv1alpha1.ProviderStatus, len(providers))
+	var wg sync.WaitGroup
+	for i, provider := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = p.probeProvider(r, ctx, provider, trust, timeout, recheck)
+		}()
+	}
+	wg.Wait()
+
+	// Forget removed providers so that a provider added again under the same name is probed right away
+	p.mu.Lock()
+	for name := range p.results {
+		found := false
+		for _, provider := range providers {
+			if provider.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			delete(p.results, name)
+		}
+	}
+	p.mu.Unlock()
+
+	return statuses
+}
+
+func (p *Prober) probeProvider(r reconciler.Reconciler, ctx context.Context, provider olsv1alpha1.ProviderSpec, trust trustConfig, timeout, recheck time.Duration) olsv1alpha1.ProviderStatus {
+	secret := &corev1.Secret{}
+	var secretErr error
+	// Workload identity and Secrets Store CSI providers have no secret the operator can read;
+	// only their reachability is checked
+	if provider.WorkloadIdentity == nil && provider.CredentialsSource == nil {
+		secretErr = r.Get(ctx, client.ObjectKey{Name: provider.CredentialsSecretRef.Name, Namespace: r.GetNamespace()}, secret)
+	}
+	headers, headerVersions, headersErr := providerHeaders(r, ctx, provider)
+	// InferenceService providers are probed at the endpoint of their first InferenceService
+	var resolveErr error
+	if provider.InferenceServiceRef != nil {
+		endpoints, err := utils.ResolveInferenceServices(r, ctx, provider)
+		if err != nil {
+			resolveErr = err
+		} else {
+			provider.URL = endpoints[0].URL
+		}
+	}
+
+	fingerprint := providerFingerprint(provider, secret.ResourceVersion+headerVersions, trust.Fingerprint, timeout)
+	p.mu.Lock()
+	cached, ok := p.results[provider.Name]
+	p.mu.Unlock()
+	if ok && cached.fingerprint == fingerprint {
+		ttl := recheck
+		if cached.status.LastError != "" && ttl > utils.ProviderPreflightFailureRecheckInterval {
+			ttl = utils.ProviderPreflightFailureRecheckInterval
+		}
+		if time.Since(cached.status.LastChecked.Time) < ttl {
+			return cached.status
+		}
+	}
+
+	status := olsv1alpha1.ProviderStatus{
+		Name:        provider.Name,
+		AuthResult:  olsv1alpha1.ProviderAuthNotChecked,
+		LastChecked: metav1.Now(),
+	}
+	switch {
+	case secretErr != nil:
+		status.LastError = fmt.Sprintf("failed to read credentials secret %s: %v", provider.CredentialsSecretRef.Name, secretErr)
+	case headersErr != nil:
+		status.LastError = headersErr.Error()
+	case resolveErr != nil:
+		status.LastError = resolveErr.Error()
+	case trust.err != nil:
+		status.LastError = trust.err.Error()
+	default:
+		status = probe(ctx, provider, secret, headers, trust, timeout)
+	}
+
+	if status.LastError != "" {
+		r.GetLogger().Info("LLM provider preflight failed", "provider", provider.Name, "error", status.LastError)
+	}
+	p.mu.Lock()
+	p.results[provider.Name] = cachedResult{fingerprint: fingerprint, status: status}
+	p.mu.Unlock()
+	return status
+}
+
+// probe sends the preflight request of one provider
+func probe(ctx context.Context, provider olsv1alpha1.ProviderSpec, secret *corev1.Secret, headers http.Header, trust trustConfig, timeout time.Duration) olsv1alpha1.ProviderStatus {
+	status := olsv1alpha1.ProviderStatus{
+		Name:        provider.Name,
+		AuthResult:  olsv1alpha1.ProviderAuthNotChecked,
+		LastChecked: metav1.Now(),
+	}
+
+	httpClient, err := newHTTPClient(provider, trust, timeout)
+	if err != nil {
+		status.LastError = err.Error()
+		return status
+	}
+	req, checksAuth, err := newProbeRequest(ctx, provider, secret, headers)
+	if err != nil {
+		status.LastError = err.Error()
+		return status
+	}
+	checksAuth = checksAuth && provider.CredentialsSource == nil
+
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	status.LatencyMilliseconds = time.Since(start).Milliseconds()
+	if err != nil {
+		status.LastError = err.Error()
+		return status
+	}
+	defer func() { _ = resp.Body.Close() }()
+	status.Reachable = true
+
+	if !checksAuth {
+		return status
+	}
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		status.AuthResult = olsv1alpha1.ProviderAuthSucceeded
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		status.AuthResult = olsv1alpha1.ProviderAuthFailed
+		status.LastError = responseError(resp)
+	default:
+		status.LastError = responseError(resp)
+	}
+	return status
+}
+
+// newProbeRequest returns the preflight request of a provider and whether its response tells if
+// the credentials were accepted. The extra provider headers are sent along, below the credentials.
+func newProbeRequest(ctx context.Context, provider olsv1alpha1.ProviderSpec, secret *corev1.Secret, headers http.Header) (*http.Request, bool, error) {
+	credentialKey := provider.CredentialKey
+	if credentialKey == "" {
+		credentialKey = utils.DefaultCredentialKey
+	}
+	apiKey := strings.TrimSpace(string(secret.Data[credentialKey]))
+
+	var (
+		target     string
+		header     = headers.Clone()
+		checksAuth bool
+	)
+	switch provider.Type {
+	case "openai", "rhoai_vllm", "rhelai_vllm":
+		base := provider.URL
+		if base == "" && provider.Type == "openai" {
+			base = utils.OpenAIDefaultURL
+		}
+		target = strings.TrimSuffix(base, "/") + "/models"
+		header.Set("Authorization", "Bearer "+apiKey)
+		checksAuth = true
+	case utils.AzureOpenAIType:
+		target = provider.URL
+		// Entra ID client credentials need a token exchange; only reachability is checked for them
+		if apiKey != "" {
+			apiVersion := provider.APIVersion
+			if apiVersion == "" {
+				apiVersion = utils.AzureOpenAIPreflightAPIVersion
+			}
+			target = strings.TrimSuffix(provider.URL, "/") + "/openai/models?api-version=" + url.QueryEscape(apiVersion)
+			header.Set("api-key", apiKey)
+			checksAuth = true
+		}
+	case utils.GoogleVertexType, utils.GoogleVertexAnthropicType:
+		target = provider.URL
+		if target == "" {
+			vertex := provider.GoogleVertexConfig
+			if provider.Type == utils.GoogleVertexAnthropicType {
+				vertex = provider.GoogleVertexAnthropicConfig
+			}
+			if vertex != nil && vertex.Location != "" {
+				target = fmt.Sprintf("https://%s-aiplatform.googleapis.com", vertex.Location)
+			}
+		}
+	case utils.BedrockType:
+		target = provider.URL
+		if target == "" && provider.BedrockConfig != nil {
+			target = provider.BedrockConfig.EndpointURL
+			if target == "" {
+				target = fmt.Sprintf("https://bedrock-runtime.%s.amazonaws.com", provider.BedrockConfig.Region)
+			}
+		}
+	case "watsonx":
+		target = provider.URL
+		if target == "" {
+			target = utils.WatsonxDefaultURL
+		}
+	default:
+		target = provider.URL
+	}
+
+	if target == "" {
+		return nil, false, fmt.Errorf("provider %s has no URL to probe", provider.Name)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid URL of provider %s: %w", provider.Name, err)
+	}
+	req.Header = header
+	return req, checksAuth, nil
+}
+
+// providerHeaders returns the extra headers of a provider, as the app server sends them, and the
+// resource versions of the Secrets they are read from. Secret values are read from the same
+// Secret key the app server mounts.
+func providerHeaders(r reconciler.Reconciler, ctx context.Context, provider olsv1alpha1.ProviderSpec) (http.Header, string, error) {
+	header := http.Header{}
+	var versions strings.Builder
+	for _, h := range provider.Headers {
+		if h.ValueFrom.Type != olsv1alpha1.ProviderHeaderSourceTypeSecret || h.ValueFrom.SecretRef == nil {
+			header.Set(h.Name, h.ValueFrom.Value)
+			continue
+		}
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Name: h.ValueFrom.SecretRef.Name, Namespace: r.GetNamespace()}, secret); err != nil {
+			return nil, "", fmt.Errorf("failed to read header secret %s: %w", h.ValueFrom.SecretRef.Name, err)
+		}
+		value, ok := secret.Data[utils.MCPSECRETDATAPATH]
+		if !ok {
+			return nil, "", fmt.Errorf("header secret %s has no %s key", h.ValueFrom.SecretRef.Name, utils.MCPSECRETDATAPATH)
+		}
+		header.Set(h.Name, strings.TrimSpace(string(value)))
+		versions.WriteString("|" + secret.ResourceVersion)
+	}
+	return header, versions.String(), nil
+}
+
+// newHTTPClient returns a client honouring the proxy, the trusted CAs and the provider TLS profile
+func newHTTPClient(provider olsv1alpha1.ProviderSpec, trust trustConfig, timeout time.Duration) (*http.Client, error) {
+	tlsConfig := &tls.Config{} // #nosec G402 -- MinVersion follows the provider TLS profile below
+	if provider.TLSSecurityProfile != nil {
+		profile := utiltls.GetTLSProfileSpec(provider.TLSSecurityProfile)
+		tlsConfig.MinVersion = utiltls.VersionCode(profile.MinTLSVersion)
+		tlsConfig.CipherSuites, _ = utiltls.CipherCodes(utiltls.TLSCiphers(profile))
+	}
+	return utils.NewHTTPClient(trust.TrustConfig, tlsConfig, timeout)
+}
+
+// loadTrustConfig reads the proxy settings and the CA certificates trusted in addition to the system roots,
+// including the service CA signing the InferenceService endpoints
+func loadTrustConfig(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) trustConfig {
+	var extraCAs []utils.TrustedCAConfigMap
+	if utils.HasInferenceServiceProviders(cr) {
+		extraCAs = append(extraCAs, utils.TrustedCAConfigMap{
+			Name: utils.OLSCAConfigMap,
+			Key:  utils.AppInferenceServiceCACertFile,
+			Err:  utils.ErrGetServiceCACM,
+		})
+	}
+	trust, err := utils.LoadTrustConfig(r, ctx, cr, extraCAs...)
+	return trustConfig{TrustConfig: trust, err: err}
+}
+
+// providerFingerprint identifies everything a preflight result depends on
+func providerFingerprint(provider olsv1alpha1.ProviderSpec, secretVersion, trustFingerprint string, timeout time.Duration) string {
+	providerJSON, _ := json.Marshal(provider)
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%s", providerJSON, secretVersion, trustFingerprint, timeout)))
+	return hex.EncodeToString(hash[:])
+}
+
+// responseError describes an unsuccessful response, including the start of its body
+func responseError(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, utils.ProviderPreflightErrorBodyMaxBytes))
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		return fmt.Sprintf("HTTP %d", resp.StatusCode)
+	}
+	return fmt.Sprintf("HTTP %d: %s", resp.StatusCode, message)
+}
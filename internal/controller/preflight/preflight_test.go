@@ -0,0 +1,228 @@
+package preflight
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+const (
+	testSecretName = "preflight-credentials"
+	testCAName     = "preflight-additional-ca"
+	validAPIKey    = "sk-valid"
+)
+
+// fakeOpenAIHandler serves the OpenAI models list for requests carrying validAPIKey
+func fakeOpenAIHandler(requests *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		if req.URL.Path != "/v1/models" {
+			http.NotFound(w, req)
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer "+validAPIKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"Incorrect API key provided"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"gpt-4o-mini","object":"model"}]}`))
+	})
+}
+
+var _ = Describe("LLM provider preflight", func() {
+	var (
+		cr       *olsv1alpha1.OLSConfig
+		prober   *Prober
+		server   *httptest.Server
+		requests *atomic.Int32
+		secret   *corev1.Secret
+	)
+
+	setAPIKey := func(key string) {
+		secret.Data = map[string][]byte{utils.DefaultCredentialKey: []byte(key)}
+		Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+	}
+
+	provider := func(name, providerType, url string) olsv1alpha1.ProviderSpec {
+		return olsv1alpha1.ProviderSpec{
+			Name:                 name,
+			Type:                 providerType,
+			URL:                  url,
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: testSecretName},
+			Models:               []olsv1alpha1.ModelSpec{{Name: "gpt-4o-mini"}},
+		}
+	}
+
+	BeforeEach(func() {
+		requests = &atomic.Int32{}
+		server = httptest.NewServer(fakeOpenAIHandler(requests))
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: testSecretName, Namespace: utils.OLSNamespaceDefault},
+			Data:       map[string][]byte{utils.DefaultCredentialKey: []byte(validAPIKey)},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+		prober = NewProber()
+		cr = utils.GetDefaultOLSConfigCR()
+		cr.Spec.LLMConfig.Providers = []olsv1alpha1.ProviderSpec{provider("openai", "openai", server.URL+"/v1")}
+		cr.Spec.LLMConfig.Preflight = &olsv1alpha1.ProviderPreflightSpec{Enabled: true}
+	})
+
+	AfterEach(func() {
+		server.Close()
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testCAName, Namespace: utils.OLSNamespaceDefault}}
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cm))).To(Succeed())
+	})
+
+	It("should not probe unless enabled", func() {
+		cr.Spec.LLMConfig.Preflight = nil
+		Expect(prober.ProbeProviders(testReconcilerInstance, ctx, cr)).To(BeNil())
+		cr.Spec.LLMConfig.Preflight = &olsv1alpha1.ProviderPreflightSpec{Enabled: false}
+		Expect(prober.ProbeProviders(testReconcilerInstance, ctx, cr)).To(BeNil())
+		Expect(requests.Load()).To(BeZero())
+	})
+
+	It("should report a reachable provider that accepts the credentials", func() {
+		statuses := prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(statuses).To(HaveLen(1))
+		Expect(statuses[0].Name).To(Equal("openai"))
+		Expect(statuses[0].Reachable).To(BeTrue())
+		Expect(statuses[0].AuthResult).To(Equal(olsv1alpha1.ProviderAuthSucceeded))
+		Expect(statuses[0].LastError).To(BeEmpty())
+		Expect(statuses[0].LastChecked.IsZero()).To(BeFalse())
+	})
+
+	It("should report rejected credentials", func() {
+		setAPIKey("sk-wrong")
+		statuses := prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(statuses[0].Reachable).To(BeTrue())
+		Expect(statuses[0].AuthResult).To(Equal(olsv1alpha1.ProviderAuthFailed))
+		Expect(statuses[0].LastError).To(ContainSubstring("HTTP 401"))
+		Expect(statuses[0].LastError).To(ContainSubstring("Incorrect API key provided"))
+	})
+
+	It("should only check reachability of providers with a Secrets Store CSI credentials source", func() {
+		cr.Spec.LLMConfig.Providers[0].CredentialsSecretRef.Name = ""
+		cr.Spec.LLMConfig.Providers[0].CredentialsSource = &olsv1alpha1.ProviderCredentialsSource{SecretProviderClass: "vault-openai"}
+		statuses := prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(statuses[0].Reachable).To(BeTrue())
+		Expect(statuses[0].AuthResult).To(Equal(olsv1alpha1.ProviderAuthNotChecked))
+		Expect(statuses[0].LastError).To(BeEmpty())
+	})
+
+	It("should report an unreachable provider", func() {
+		server.Close()
+		statuses := prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(statuses[0].Reachable).To(BeFalse())
+		Expect(statuses[0].AuthResult).To(Equal(olsv1alpha1.ProviderAuthNotChecked))
+		Expect(statuses[0].LastError).NotTo(BeEmpty())
+	})
+
+	It("should reuse the result until the credentials change", func() {
+		prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(requests.Load()).To(Equal(int32(1)))
+
+		setAPIKey("sk-wrong")
+		statuses := prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(requests.Load()).To(Equal(int32(2)))
+		Expect(statuses[0].AuthResult).To(Equal(olsv1alpha1.ProviderAuthFailed))
+	})
+
+	It("should trust the additional CA certificates", func() {
+		tlsServer := httptest.NewTLSServer(fakeOpenAIHandler(requests))
+		defer tlsServer.Close()
+		cr.Spec.LLMConfig.Providers = []olsv1alpha1.ProviderSpec{provider("vllm", "rhoai_vllm", tlsServer.URL+"/v1")}
+
+		statuses := prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(statuses[0].Reachable).To(BeFalse())
+		Expect(statuses[0].LastError).To(ContainSubstring("certificate"))
+
+		caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: testCAName, Namespace: utils.OLSNamespaceDefault},
+			Data:       map[string]string{"ca.crt": string(caPEM)},
+		}
+		Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+		cr.Spec.OLSConfig.AdditionalCAConfigMapRef = &corev1.LocalObjectReference{Name: testCAName}
+
+		statuses = prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(statuses[0].Reachable).To(BeTrue())
+		Expect(statuses[0].AuthResult).To(Equal(olsv1alpha1.ProviderAuthSucceeded))
+	})
+
+	It("should only check reachability of providers without a models endpoint and skip the fake provider", func() {
+		cr.Spec.LLMConfig.Providers = []olsv1alpha1.ProviderSpec{
+			provider("watsonx", "watsonx", server.URL),
+			provider("fake", utils.FakeProviderType, ""),
+			provider("bedrock", utils.BedrockType, ""),
+		}
+		statuses := prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(statuses).To(HaveLen(2))
+		Expect(statuses[0].Name).To(Equal("watsonx"))
+		Expect(statuses[0].Reachable).To(BeTrue())
+		Expect(statuses[0].AuthResult).To(Equal(olsv1alpha1.ProviderAuthNotChecked))
+		Expect(statuses[0].LastError).To(BeEmpty())
+		Expect(statuses[1].Name).To(Equal("bedrock"))
+		Expect(statuses[1].LastError).To(ContainSubstring("no URL to probe"))
+	})
+
+	It("should send the provider headers", func() {
+		var tenant, org atomic.Value
+		headerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			tenant.Store(req.Header.Get("X-Tenant-ID"))
+			org.Store(req.Header.Get("OpenAI-Organization"))
+			fakeOpenAIHandler(requests).ServeHTTP(w, req)
+		}))
+		defer headerServer.Close()
+		headerSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "preflight-header", Namespace: utils.OLSNamespaceDefault},
+			Data:       map[string][]byte{utils.MCPSECRETDATAPATH: []byte("org-a")},
+		}
+		Expect(k8sClient.Create(ctx, headerSecret)).To(Succeed())
+		defer func() { Expect(k8sClient.Delete(ctx, headerSecret)).To(Succeed()) }()
+		cr.Spec.LLMConfig.Providers[0].URL = headerServer.URL + "/v1"
+		cr.Spec.LLMConfig.Providers[0].Headers = []olsv1alpha1.ProviderHeader{
+			{Name: "X-Tenant-ID", ValueFrom: olsv1alpha1.ProviderHeaderValueSource{Type: olsv1alpha1.ProviderHeaderSourceTypeValue, Value: "tenant-a"}},
+			{Name: "OpenAI-Organization", ValueFrom: olsv1alpha1.ProviderHeaderValueSource{Type: olsv1alpha1.ProviderHeaderSourceTypeSecret, SecretRef: &corev1.LocalObjectReference{Name: "preflight-header"}}},
+		}
+
+		statuses := prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(statuses[0].AuthResult).To(Equal(olsv1alpha1.ProviderAuthSucceeded))
+		Expect(tenant.Load()).To(Equal("tenant-a"))
+		Expect(org.Load()).To(Equal("org-a"))
+
+		headerSecret.Data = map[string][]byte{utils.MCPSECRETDATAPATH: []byte("org-b")}
+		Expect(k8sClient.Update(ctx, headerSecret)).To(Succeed())
+		prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(org.Load()).To(Equal("org-b"))
+	})
+
+	It("should report a missing header secret", func() {
+		cr.Spec.LLMConfig.Providers[0].Headers = []olsv1alpha1.ProviderHeader{
+			{Name: "X-Api-Token", ValueFrom: olsv1alpha1.ProviderHeaderValueSource{Type: olsv1alpha1.ProviderHeaderSourceTypeSecret, SecretRef: &corev1.LocalObjectReference{Name: "missing-header"}}},
+		}
+		statuses := prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(statuses[0].LastError).To(ContainSubstring("missing-header"))
+		Expect(requests.Load()).To(BeZero())
+	})
+
+	It("should report a missing credentials secret", func() {
+		Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+		statuses := prober.ProbeProviders(testReconcilerInstance, ctx, cr)
+		Expect(statuses[0].LastError).To(ContainSubstring(testSecretName))
+		Expect(requests.Load()).To(BeZero())
+	})
+})
@@ -0,0 +1,346 @@
+package ragbuild
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+// buildScript embeds the documents with the embedding model of the app server image into a FAISS index.
+// The index is written to a new directory of the PersistentVolumeClaim and published by swapping the
+// RAGBuildIndexDir symlink, so that the app server never loads a partially written index.
+const buildScript = `import json
+import os
+import shutil
+import sys
+import time
+
+import faiss
+from llama_index.core import Settings, SimpleDirectoryReader, StorageContext, VectorStoreIndex
+from llama_index.embeddings.huggingface import HuggingFaceEmbedding
+from llama_index.vector_stores.faiss import FaissVectorStore
+
+source_dir, output_dir, index_id, model_path, index_dir = sys.argv[1:6]
+
+Settings.llm = None
+Settings.embed_model = HuggingFaceEmbedding(model_name=model_path)
+Settings.chunk_size = 380
+Settings.chunk_overlap = 0
+
+documents = SimpleDirectoryReader(
+    source_dir, recursive=True, required_exts=[".md", ".html", ".htm", ".pdf", ".txt"]
+).load_data()
+print(f"embedding {len(documents)} documents from {source_dir}")
+
+dimension = len(Settings.embed_model.get_text_embedding("dimension"))
+storage_context = StorageContext.from_defaults(
+    vector_store=FaissVectorStore(faiss_index=faiss.IndexFlatIP(dimension))
+)
+index = VectorStoreIndex.from_documents(documents, storage_context=storage_context)
+index.set_index_id(index_id)
+
+builds_dir = os.path.join(output_dir, "builds")
+build = str(int(time.time()))
+index.storage_context.persist(persist_dir=os.path.join(builds_dir, build))
+with open(os.path.join(builds_dir, build, "metadata.json"), "w") as f:
+    json.dump({"index_id": index_id, "embedding_dimension": dimension, "documents": len(documents)}, f)
+
+link = os.path.join(output_dir, index_dir)
+if os.path.lexists(link + ".new"):
+    os.remove(link + ".new")
+os.symlink(os.path.join("builds", build), link + ".new")
+os.replace(link + ".new", link)
+for previous in os.listdir(builds_dir):
+    if previous != build:
+        shutil.rmtree(os.path.join(builds_dir, previous), ignore_errors=True)
+print(f"index {index_id} published to {link}")
+`
+
+func selectorLabels(name string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/component":  utils.RAGBuildComponentLabel,
+		"app.kubernetes.io/managed-by": "lightspeed-operator",
+		"app.kubernetes.io/name":       utils.RAGBuildResourcePrefix + name,
+		"app.kubernetes.io/part-of":    "openshift-lightspeed",
+		utils.RAGBuildNameLabel:        name,
+	}
+}
+
+// PVCName returns the name of the PersistentVolumeClaim holding the index of the build
+func PVCName(build olsv1alpha1.RAGBuildSpec) string {
+	return utils.RAGBuildResourcePrefix + build.Name
+}
+
+// specHash identifies the build spec and the app server image a build Job is generated from:
+// a change of either, the embedding model being part of the app server image, rebuilds the index
+func specHash(r reconciler.Reconciler, build olsv1alpha1.RAGBuildSpec) string {
+	data, _ := json.Marshal(struct {
+		Source olsv1alpha1.RAGBuildSource `json:"source"`
+		Image  string                     `json:"image"`
+	}{build.Source, r.GetAppServerImage()})
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:8]
+}
+
+// JobName returns the name of the Job building the index for the current spec of the build.
+// Build names are at most 20 characters, so that the CronJob name leaves the 11 characters the CronJob
+// controller appends to its Jobs, and the names of the retry Jobs fit the 63 characters of the job-name label.
+func JobName(r reconciler.Reconciler, build olsv1alpha1.RAGBuildSpec) string {
+	return utils.RAGBuildResourcePrefix + build.Name + "-" + specHash(r, build)
+}
+
+// GenerateScriptConfigMap generates the ConfigMap holding the index build script
+func GenerateScriptConfigMap(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (*corev1.ConfigMap, error) {
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.RAGBuildScriptConfigMapName,
+			Namespace: r.GetNamespace(),
+			Labels: map[string]string{
+				"app.kubernetes.io/component":  utils.RAGBuildComponentLabel,
+				"app.kubernetes.io/managed-by": "lightspeed-operator",
+				"app.kubernetes.io/name":       utils.RAGBuildScriptConfigMapName,
+				"app.kubernetes.io/part-of":    "openshift-lightspeed",
+			},
+		},
+		Data: map[string]string{
+			utils.RAGBuildScriptKey: buildScript,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cr, &cm, r.GetScheme()); err != nil {
+		return nil, err
+	}
+	return &cm, nil
+}
+
+// GeneratePVC generates the PersistentVolumeClaim holding the index of the build
+func GeneratePVC(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig, build olsv1alpha1.RAGBuildSpec) (*corev1.PersistentVolumeClaim, error) {
+	size := resource.MustParse(utils.RAGBuildDefaultPVCSize)
+	var storageClass *string
+	if build.Storage != nil {
+		if !build.Storage.Size.IsZero() {
+			size = build.Storage.Size
+		}
+		if build.Storage.Class != "" {
+			storageClass = &build.Storage.Class
+		}
+	}
+
+	pvc := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PVCName(build),
+			Namespace: r.GetNamespace(),
+			Labels:    selectorLabels(build.Name),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+			StorageClassName: storageClass,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cr, &pvc, r.GetScheme()); err != nil {
+		return nil, err
+	}
+	return &pvc, nil
+}
+
+// mountedByAppServer checks if spec.ols.rag reads the index of the build, the app server then mounts its claim
+func mountedByAppServer(cr *olsv1alpha1.OLSConfig, build olsv1alpha1.RAGBuildSpec) bool {
+	for _, rag := range cr.Spec.OLSConfig.RAG {
+		if rag.PersistentVolumeClaim != nil && rag.PersistentVolumeClaim.ClaimName == PVCName(build) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateJobSpec returns the spec of the Jobs of the build: a git init container clones the repository
+// of a git source, then the app server image runs the build script over the documents
+func generateJobSpec(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig, build olsv1alpha1.RAGBuildSpec) batchv1.JobSpec {
+	volumeDefaultMode := utils.VolumeDefaultMode
+	sourceVolume := corev1.Volume{Name: "source"}
+	sourceMount := corev1.VolumeMount{Name: "source", MountPath: utils.RAGBuildSourcePath, ReadOnly: true}
+	documentsDir := utils.RAGBuildSourcePath
+	var initContainers []corev1.Container
+	switch source := build.Source; {
+	case source.ConfigMap != nil:
+		sourceVolume.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: *source.ConfigMap,
+			DefaultMode:          &volumeDefaultMode,
+		}
+	case source.PersistentVolumeClaim != nil:
+		sourceVolume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: source.PersistentVolumeClaim.ClaimName,
+			ReadOnly:  true,
+		}
+		sourceMount.SubPath = source.PersistentVolumeClaim.Path
+	case source.Git != nil:
+		sourceVolume.EmptyDir = &corev1.EmptyDirVolumeSource{}
+		documentsDir = path.Join(utils.RAGBuildSourcePath, "repository", source.Git.Path)
+		ref := source.Git.Ref
+		if ref == "" {
+			ref = "main"
+		}
+		initContainers = append(initContainers, corev1.Container{
+			Name:            "git-clone",
+			Image:           source.Git.Image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command: []string{"git", "clone", "--depth", "1", "--branch", ref, "--",
+				source.Git.URL, path.Join(utils.RAGBuildSourcePath, "repository")},
+			Env:             []corev1.EnvVar{{Name: "HOME", Value: "/tmp"}},
+			SecurityContext: utils.RestrictedContainerSecurityContext(),
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "source", MountPath: utils.RAGBuildSourcePath},
+				{Name: "tmp", MountPath: "/tmp"},
+			},
+		})
+	}
+
+	// The index claim is ReadWriteOnce: when the app server mounts it, the build runs on an app server node
+	var affinity *corev1.Affinity
+	if mountedByAppServer(cr, build) {
+		affinity = &corev1.Affinity{
+			PodAffinity: &corev1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+					{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: utils.GenerateAppServerSelectorLabels()},
+						TopologyKey:   "kubernetes.io/hostname",
+					},
+				},
+			},
+		}
+	}
+
+	return batchv1.JobSpec{
+		BackoffLimit: &[]int32{utils.RAGBuildBackoffLimit}[0],
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: selectorLabels(build.Name),
+			},
+			Spec: corev1.PodSpec{
+				RestartPolicy:                corev1.RestartPolicyNever,
+				AutomountServiceAccountToken: &[]bool{false}[0],
+				InitContainers:               initContainers,
+				Containers: []corev1.Container{
+					{
+						Name:            "build",
+						Image:           r.GetAppServerImage(),
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command: []string{"python3", path.Join(utils.RAGBuildScriptPath, utils.RAGBuildScriptKey),
+							documentsDir, utils.RAGBuildOutputPath, build.Name, utils.AppServerEmbeddingModelPath, utils.RAGBuildIndexDir},
+						Env: []corev1.EnvVar{
+							{Name: "HOME", Value: "/tmp"},
+							{Name: "HF_HUB_OFFLINE", Value: "1"},
+							{Name: "TRANSFORMERS_OFFLINE", Value: "1"},
+						},
+						SecurityContext: utils.RestrictedContainerSecurityContext(),
+						// Embedding a large document set is memory hungry, the limit leaves room for PDFs
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("4Gi"),
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							sourceMount,
+							{Name: "output", MountPath: utils.RAGBuildOutputPath},
+							{Name: "builder", MountPath: utils.RAGBuildScriptPath, ReadOnly: true},
+							{Name: "tmp", MountPath: "/tmp"},
+						},
+					},
+				},
+				Volumes: []corev1.Volume{
+					sourceVolume,
+					{
+						Name: "output",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: PVCName(build)},
+						},
+					},
+					{
+						Name: "builder",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: utils.RAGBuildScriptConfigMapName},
+								DefaultMode:          &volumeDefaultMode,
+							},
+						},
+					},
+					{
+						Name:         "tmp",
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					},
+				},
+				Affinity: affinity,
+			},
+		},
+	}
+}
+
+// GenerateJob generates the Job building the index for the current spec of the build. The retries of a
+// failed build are new Jobs, suffixed with the number of the retry.
+func GenerateJob(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig, build olsv1alpha1.RAGBuildSpec, retry int32) (*batchv1.Job, error) {
+	labels := selectorLabels(build.Name)
+	labels[utils.RAGBuildSpecHashLabel] = specHash(r, build)
+	name := JobName(r, build)
+	if retry > 0 {
+		name = fmt.Sprintf("%s-retry-%d", name, retry)
+	}
+	job := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   r.GetNamespace(),
+			Labels:      labels,
+			Annotations: map[string]string{utils.RAGBuildRetryAnnotation: strconv.Itoa(int(retry))},
+		},
+		Spec: generateJobSpec(r, cr, build),
+	}
+	if err := controllerutil.SetControllerReference(cr, &job, r.GetScheme()); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GenerateCronJob generates the CronJob rebuilding the index on the schedule of the build
+func GenerateCronJob(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig, build olsv1alpha1.RAGBuildSpec) (*batchv1.CronJob, error) {
+	labels := selectorLabels(build.Name)
+	labels[utils.RAGBuildSpecHashLabel] = specHash(r, build)
+	cronJob := batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.RAGBuildResourcePrefix + build.Name,
+			Namespace: r.GetNamespace(),
+			Labels:    labels,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   build.Schedule,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: &[]int32{1}[0],
+			FailedJobsHistoryLimit:     &[]int32{1}[0],
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: selectorLabels(build.Name),
+				},
+				Spec: generateJobSpec(r, cr, build),
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(cr, &cronJob, r.GetScheme()); err != nil {
+		return nil, err
+	}
+	return &cronJob, nil
+}
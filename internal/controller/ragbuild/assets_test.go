@@ -0,0 +1,168 @@
+package ragbuild
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+var _ = Describe("RAG index build assets", func() {
+	var build olsv1alpha1.RAGBuildSpec
+
+	volume := func(spec batchv1.JobSpec, name string) corev1.Volume {
+		for _, v := range spec.Template.Spec.Volumes {
+			if v.Name == name {
+				return v
+			}
+		}
+		Fail("volume " + name + " not found")
+		return corev1.Volume{}
+	}
+
+	BeforeEach(func() {
+		build = olsv1alpha1.RAGBuildSpec{
+			Name: "product-docs",
+			Source: olsv1alpha1.RAGBuildSource{
+				ConfigMap: &corev1.LocalObjectReference{Name: "product-docs"},
+			},
+		}
+	})
+
+	It("should generate the index PVC with the default size", func() {
+		pvc, err := GeneratePVC(testReconcilerInstance, cr, build)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pvc.Name).To(Equal("lightspeed-rag-build-product-docs"))
+		Expect(pvc.Spec.AccessModes).To(ConsistOf(corev1.ReadWriteOnce))
+		Expect(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse(utils.RAGBuildDefaultPVCSize)))
+		Expect(pvc.Spec.StorageClassName).To(BeNil())
+
+		build.Storage = &olsv1alpha1.Storage{Size: resource.MustParse("5Gi"), Class: "fast"}
+		pvc, err = GeneratePVC(testReconcilerInstance, cr, build)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("5Gi")))
+		Expect(pvc.Spec.StorageClassName).To(HaveValue(Equal("fast")))
+	})
+
+	It("should build a ConfigMap source with the app server image", func() {
+		job, err := GenerateJob(testReconcilerInstance, cr, build, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.Name).To(HavePrefix("lightspeed-rag-build-product-docs-"))
+		Expect(job.Labels).To(HaveKeyWithValue(utils.RAGBuildNameLabel, "product-docs"))
+		Expect(job.Labels).To(HaveKey(utils.RAGBuildSpecHashLabel))
+		Expect(job.OwnerReferences).To(HaveLen(1))
+
+		pod := job.Spec.Template.Spec
+		Expect(pod.InitContainers).To(BeEmpty())
+		Expect(pod.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+		Expect(pod.Containers).To(HaveLen(1))
+		Expect(pod.Containers[0].Image).To(Equal(utils.OLSAppServerImageDefault))
+		Expect(pod.Containers[0].Command).To(Equal([]string{
+			"python3", "/rag-build/builder/build_index.py", utils.RAGBuildSourcePath, utils.RAGBuildOutputPath,
+			"product-docs", utils.AppServerEmbeddingModelPath, utils.RAGBuildIndexDir,
+		}))
+		Expect(volume(job.Spec, "source").ConfigMap.Name).To(Equal("product-docs"))
+		Expect(volume(job.Spec, "output").PersistentVolumeClaim.ClaimName).To(Equal(PVCName(build)))
+	})
+
+	It("should mount a directory of a PVC source read-only", func() {
+		build.Source = olsv1alpha1.RAGBuildSource{
+			PersistentVolumeClaim: &olsv1alpha1.RAGBuildPersistentVolumeClaimSource{ClaimName: "docs", Path: "manuals"},
+		}
+		job, err := GenerateJob(testReconcilerInstance, cr, build, 0)
+		Expect(err).NotTo(HaveOccurred())
+		source := volume(job.Spec, "source")
+		Expect(source.PersistentVolumeClaim.ClaimName).To(Equal("docs"))
+		Expect(source.PersistentVolumeClaim.ReadOnly).To(BeTrue())
+		Expect(job.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name: "source", MountPath: utils.RAGBuildSourcePath, SubPath: "manuals", ReadOnly: true,
+		}))
+	})
+
+	It("should clone a git source in an init container", func() {
+		build.Source = olsv1alpha1.RAGBuildSource{
+			Git: &olsv1alpha1.RAGBuildGitSource{
+				URL:   "https://git.example.com/docs.git",
+				Ref:   "release-1.0",
+				Path:  "content",
+				Image: "registry.example.com/tools/git:latest",
+			},
+		}
+		job, err := GenerateJob(testReconcilerInstance, cr, build, 0)
+		Expect(err).NotTo(HaveOccurred())
+		pod := job.Spec.Template.Spec
+		Expect(volume(job.Spec, "source").EmptyDir).NotTo(BeNil())
+		Expect(pod.InitContainers).To(HaveLen(1))
+		Expect(pod.InitContainers[0].Image).To(Equal("registry.example.com/tools/git:latest"))
+		Expect(pod.InitContainers[0].Command).To(Equal([]string{
+			"git", "clone", "--depth", "1", "--branch", "release-1.0", "--",
+			"https://git.example.com/docs.git", "/rag-build/source/repository",
+		}))
+		Expect(pod.Containers[0].Command[2]).To(Equal("/rag-build/source/repository/content"))
+	})
+
+	It("should run on an app server node when the app server mounts the index", func() {
+		job, err := GenerateJob(testReconcilerInstance, cr, build, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.Spec.Template.Spec.Affinity).To(BeNil())
+
+		mounted := cr.DeepCopy()
+		mounted.Spec.OLSConfig.RAG = []olsv1alpha1.RAGSpec{
+			{PersistentVolumeClaim: &olsv1alpha1.RAGPersistentVolumeClaimSource{ClaimName: PVCName(build)}},
+		}
+		job, err = GenerateJob(testReconcilerInstance, mounted, build, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.Spec.Template.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(ConsistOf(corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: utils.GenerateAppServerSelectorLabels()},
+			TopologyKey:   "kubernetes.io/hostname",
+		}))
+	})
+
+	It("should number the Jobs retrying a failed build", func() {
+		job, err := GenerateJob(testReconcilerInstance, cr, build, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.Name).To(Equal(JobName(testReconcilerInstance, build) + "-retry-2"))
+		Expect(job.Annotations).To(HaveKeyWithValue(utils.RAGBuildRetryAnnotation, "2"))
+	})
+
+	It("should keep the names of a build with the longest name within the Kubernetes limits", func() {
+		build.Name = strings.Repeat("a", 20)
+		build.Schedule = "0 3 * * *"
+		cronJob, err := GenerateCronJob(testReconcilerInstance, cr, build)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(len(cronJob.Name)).To(BeNumerically("<=", 52), "the CronJob controller appends 11 characters to the Job names")
+
+		job, err := GenerateJob(testReconcilerInstance, cr, build, 999999)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validation.IsDNS1123Label(job.Name)).To(BeEmpty(), "the Job name is the value of the job-name label of its pods")
+	})
+
+	It("should name the Job after the source and the app server image", func() {
+		name := JobName(testReconcilerInstance, build)
+		Expect(JobName(testReconcilerInstance, build)).To(Equal(name))
+
+		build.Schedule = "0 3 * * *"
+		Expect(JobName(testReconcilerInstance, build)).To(Equal(name), "the schedule does not rebuild the index")
+
+		build.Source.ConfigMap.Name = "other-docs"
+		Expect(JobName(testReconcilerInstance, build)).NotTo(Equal(name))
+	})
+
+	It("should generate the CronJob of a scheduled build", func() {
+		build.Schedule = "0 3 * * *"
+		cronJob, err := GenerateCronJob(testReconcilerInstance, cr, build)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cronJob.Name).To(Equal("lightspeed-rag-build-product-docs"))
+		Expect(cronJob.Spec.Schedule).To(Equal("0 3 * * *"))
+		Expect(cronJob.Spec.ConcurrencyPolicy).To(Equal(batchv1.ForbidConcurrent))
+		Expect(cronJob.Spec.JobTemplate.Labels).To(HaveKeyWithValue(utils.RAGBuildNameLabel, "product-docs"))
+	})
+})
@@ -0,0 +1,392 @@
+package ragbuild
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+// ReconcileResources reconciles Phase 1 RAG index build resources: the build script, the index
+// PersistentVolumeClaims, the Jobs building the current spec of each build and the CronJobs of the
+// scheduled builds. Jobs and CronJobs of removed builds are deleted, their claims are kept.
+func ReconcileResources(r reconciler.Reconciler, ctx context.Context, olsconfig *olsv1alpha1.OLSConfig) error {
+	return utils.RunReconcileTasks(r, ctx, olsconfig, "reconcileRAGBuildResources", []utils.ReconcileTask{
+		{Name: "reconcile RAG index build script", Task: reconcileScriptConfigMap},
+		{Name: "reconcile RAG index build PVCs", Task: reconcilePVCs},
+		{Name: "reconcile RAG index build Jobs", Task: reconcileJobs},
+		{Name: "reconcile RAG index build CronJobs", Task: reconcileCronJobs},
+	}, true)
+}
+
+// Remove deletes the Jobs, the CronJobs and the script of the RAG index builds. The index
+// PersistentVolumeClaims are kept, spec.ols.rag may still reference them.
+func Remove(r reconciler.Reconciler, ctx context.Context) error {
+	return utils.RunDeleteTasks(r, ctx, "RemoveRAGBuilds", []utils.DeleteTask{
+		{Name: "delete RAG index build CronJobs", Task: func(r reconciler.Reconciler, ctx context.Context) error {
+			return deleteCronJobs(r, ctx, nil)
+		}},
+		{Name: "delete RAG index build Jobs", Task: func(r reconciler.Reconciler, ctx context.Context) error {
+			return deleteJobs(r, ctx, func(batchv1.Job) bool { return true })
+		}},
+		{Name: "delete RAG index build script", Task: deleteScriptConfigMap},
+	})
+}
+
+func reconcileScriptConfigMap(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	cm, err := GenerateScriptConfigMap(r, cr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrReconcileRAGBuildScript, err)
+	}
+
+	foundCm := &corev1.ConfigMap{}
+	err = r.Get(ctx, client.ObjectKey{Name: cm.Name, Namespace: r.GetNamespace()}, foundCm)
+	if err != nil && errors.IsNotFound(err) {
+		r.GetLogger().Info("creating RAG index build script configmap", "configmap", cm.Name)
+		if err := r.Create(ctx, cm); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrReconcileRAGBuildScript, err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrReconcileRAGBuildScript, err)
+	}
+
+	if apiequality.Semantic.DeepEqual(foundCm.Data, cm.Data) {
+		return nil
+	}
+	foundCm.Data = cm.Data
+	if err := r.Update(ctx, foundCm); err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrReconcileRAGBuildScript, err)
+	}
+	r.GetLogger().Info("RAG index build script configmap reconciled", "configmap", cm.Name)
+	return nil
+}
+
+// reconcilePVCs creates the missing index PersistentVolumeClaims, existing claims are left untouched
+func reconcilePVCs(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	for _, build := range cr.Spec.OLSConfig.RAGBuilds {
+		pvc, err := GeneratePVC(r, cr, build)
+		if err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrGenerateRAGBuildPVC, err)
+		}
+		err = r.Get(ctx, client.ObjectKey{Name: pvc.Name, Namespace: r.GetNamespace()}, &corev1.PersistentVolumeClaim{})
+		if err != nil && errors.IsNotFound(err) {
+			r.GetLogger().Info("creating RAG index build PVC", "pvc", pvc.Name)
+			if err := r.Create(ctx, pvc); err != nil {
+				return fmt.Errorf("%s: %w", utils.ErrCreateRAGBuildPVC, err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrGetRAGBuildPVC, err)
+		}
+	}
+	return nil
+}
+
+// reconcileJobs creates the Job of the current spec of each build, and retries a failed Job with a new one
+// once its backoff elapsed. The Jobs of a previous spec and the failed attempts are deleted once the current
+// Job finished, so that the status keeps the last build until then.
+func reconcileJobs(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	currentJobs := map[string]string{}
+	for _, build := range cr.Spec.OLSConfig.RAGBuilds {
+		latest, err := latestSpecJob(r, ctx, build)
+		if err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrGetRAGBuildJob, err)
+		}
+		var retry int32
+		if latest != nil {
+			currentJobs[build.Name] = latest.Name
+			if !jobFinished(latest) {
+				currentJobs[build.Name] = ""
+				continue
+			}
+			nextRetry := nextRetryTime(latest)
+			if nextRetry == nil || time.Now().Before(nextRetry.Time) {
+				continue
+			}
+			retry = jobRetry(latest) + 1
+		}
+
+		job, err := GenerateJob(r, cr, build, retry)
+		if err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrGenerateRAGBuildJob, err)
+		}
+		r.GetLogger().Info("creating RAG index build job", "job", job.Name, "retry", retry)
+		if err := r.Create(ctx, job); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrCreateRAGBuildJob, err)
+		}
+		currentJobs[build.Name] = ""
+	}
+
+	return deleteJobs(r, ctx, func(job batchv1.Job) bool {
+		current, found := currentJobs[job.Labels[utils.RAGBuildNameLabel]]
+		if !found {
+			return true
+		}
+		return current != "" && job.Name != current
+	})
+}
+
+// reconcileCronJobs creates or updates the CronJobs of the scheduled builds and deletes the others
+func reconcileCronJobs(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	scheduled := map[string]bool{}
+	for _, build := range cr.Spec.OLSConfig.RAGBuilds {
+		if build.Schedule == "" {
+			continue
+		}
+		scheduled[utils.RAGBuildResourcePrefix+build.Name] = true
+		cronJob, err := GenerateCronJob(r, cr, build)
+		if err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrGenerateRAGBuildCronJob, err)
+		}
+
+		foundCronJob := &batchv1.CronJob{}
+		err = r.Get(ctx, client.ObjectKey{Name: cronJob.Name, Namespace: r.GetNamespace()}, foundCronJob)
+		if err != nil && errors.IsNotFound(err) {
+			r.GetLogger().Info("creating RAG index build cronjob", "cronjob", cronJob.Name)
+			if err := r.Create(ctx, cronJob); err != nil {
+				return fmt.Errorf("%s: %w", utils.ErrCreateRAGBuildCronJob, err)
+			}
+			continue
+		} else if err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrGetRAGBuildCronJob, err)
+		}
+
+		if foundCronJob.Labels[utils.RAGBuildSpecHashLabel] == cronJob.Labels[utils.RAGBuildSpecHashLabel] &&
+			foundCronJob.Spec.Schedule == cronJob.Spec.Schedule &&
+			apiequality.Semantic.DeepEqual(foundCronJob.Spec.JobTemplate.Spec.Template.Spec.Affinity,
+				cronJob.Spec.JobTemplate.Spec.Template.Spec.Affinity) {
+			continue
+		}
+		foundCronJob.Labels = cronJob.Labels
+		foundCronJob.Spec = cronJob.Spec
+		if err := r.Update(ctx, foundCronJob); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrUpdateRAGBuildCronJob, err)
+		}
+		r.GetLogger().Info("RAG index build cronjob reconciled", "cronjob", cronJob.Name)
+	}
+	return deleteCronJobs(r, ctx, scheduled)
+}
+
+// deleteJobs deletes the build Jobs created by the operator matching the filter, with their pods.
+// The Jobs created by the CronJobs are left to the CronJob history limits.
+func deleteJobs(r reconciler.Reconciler, ctx context.Context, filter func(batchv1.Job) bool) error {
+	jobs, err := listJobs(r, ctx, client.HasLabels{utils.RAGBuildNameLabel})
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if owner := metav1.GetControllerOf(&job); owner == nil || owner.Kind != "OLSConfig" || !filter(job) {
+			continue
+		}
+		r.GetLogger().Info("deleting RAG index build job", "job", job.Name)
+		err := r.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("%s: %w", utils.ErrDeleteRAGBuildJob, err)
+		}
+	}
+	return nil
+}
+
+// deleteCronJobs deletes the build CronJobs not in keep, with the Jobs they created
+func deleteCronJobs(r reconciler.Reconciler, ctx context.Context, keep map[string]bool) error {
+	cronJobs := &batchv1.CronJobList{}
+	err := r.List(ctx, cronJobs, client.InNamespace(r.GetNamespace()), client.HasLabels{utils.RAGBuildNameLabel})
+	if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrListRAGBuildCronJobs, err)
+	}
+	for _, cronJob := range cronJobs.Items {
+		if keep[cronJob.Name] {
+			continue
+		}
+		r.GetLogger().Info("deleting RAG index build cronjob", "cronjob", cronJob.Name)
+		err := r.Delete(ctx, &cronJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("%s: %w", utils.ErrDeleteRAGBuildCronJob, err)
+		}
+	}
+	return nil
+}
+
+func deleteScriptConfigMap(r reconciler.Reconciler, ctx context.Context) error {
+	cm := &corev1.ConfigMap{}
+	cm.Name = utils.RAGBuildScriptConfigMapName
+	cm.Namespace = r.GetNamespace()
+	if err := r.Delete(ctx, cm); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("%s: %w", utils.ErrReconcileRAGBuildScript, err)
+	}
+	return nil
+}
+
+func listJobs(r reconciler.Reconciler, ctx context.Context, opts ...client.ListOption) ([]batchv1.Job, error) {
+	jobs := &batchv1.JobList{}
+	err := r.List(ctx, jobs, append([]client.ListOption{client.InNamespace(r.GetNamespace())}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.ErrListRAGBuildJobs, err)
+	}
+	return jobs.Items, nil
+}
+
+func jobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		if job.Status.Conditions[i].Type == conditionType && job.Status.Conditions[i].Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func jobFinished(job *batchv1.Job) bool {
+	return jobCondition(job, batchv1.JobComplete) != nil || jobCondition(job, batchv1.JobFailed) != nil
+}
+
+// latestSpecJob returns the most recent attempt of the operator at building the current spec of the build,
+// nil before the first one. The Jobs created by the CronJob carry no spec hash.
+func latestSpecJob(r reconciler.Reconciler, ctx context.Context, build olsv1alpha1.RAGBuildSpec) (*batchv1.Job, error) {
+	jobs, err := listJobs(r, ctx, client.MatchingLabels{
+		utils.RAGBuildNameLabel:     build.Name,
+		utils.RAGBuildSpecHashLabel: specHash(r, build),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var latest *batchv1.Job
+	for i := range jobs {
+		if owner := metav1.GetControllerOf(&jobs[i]); owner == nil || owner.Kind != "OLSConfig" {
+			continue
+		}
+		if latest == nil || jobRetry(&jobs[i]) > jobRetry(latest) {
+			latest = &jobs[i]
+		}
+	}
+	return latest, nil
+}
+
+// jobRetry returns the number of the retry of a failed build the Job is, 0 for the first attempt
+func jobRetry(job *batchv1.Job) int32 {
+	retry, err := strconv.ParseInt(job.Annotations[utils.RAGBuildRetryAnnotation], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(retry)
+}
+
+// nextRetryTime returns when a failed build Job of the operator is retried, nil if the Job did not fail.
+// The delay starts at RAGBuildRetryInterval and doubles with each retry, up to RAGBuildMaxRetryInterval.
+func nextRetryTime(job *batchv1.Job) *metav1.Time {
+	failed := jobCondition(job, batchv1.JobFailed)
+	if failed == nil {
+		return nil
+	}
+	backoff := utils.RAGBuildRetryInterval
+	for i := int32(0); i < jobRetry(job) && backoff < utils.RAGBuildMaxRetryInterval; i++ {
+		backoff *= 2
+	}
+	next := metav1.NewTime(failed.LastTransitionTime.Add(min(backoff, utils.RAGBuildMaxRetryInterval)))
+	return &next
+}
+
+// lastSucceededTime returns the completion time of the most recent successful Job of the build,
+// including the Jobs already removed by the CronJob history limit
+func lastSucceededTime(r reconciler.Reconciler, ctx context.Context, build olsv1alpha1.RAGBuildSpec, jobs []batchv1.Job) *metav1.Time {
+	var last *metav1.Time
+	for i := range jobs {
+		if jobCondition(&jobs[i], batchv1.JobComplete) != nil && jobs[i].Status.CompletionTime != nil &&
+			(last == nil || last.Before(jobs[i].Status.CompletionTime)) {
+			last = jobs[i].Status.CompletionTime
+		}
+	}
+	if build.Schedule != "" {
+		cronJob := &batchv1.CronJob{}
+		err := r.Get(ctx, client.ObjectKey{Name: utils.RAGBuildResourcePrefix + build.Name, Namespace: r.GetNamespace()}, cronJob)
+		if err == nil && cronJob.Status.LastSuccessfulTime != nil &&
+			(last == nil || last.Before(cronJob.Status.LastSuccessfulTime)) {
+			last = cronJob.Status.LastSuccessfulTime
+		}
+	}
+	return last
+}
+
+// Statuses reports the state of the builds of spec.ols.ragBuilds from their most recent Job
+func Statuses(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) []olsv1alpha1.RAGBuildStatus {
+	var statuses []olsv1alpha1.RAGBuildStatus
+	for _, build := range cr.Spec.OLSConfig.RAGBuilds {
+		status := olsv1alpha1.RAGBuildStatus{
+			Name:                  build.Name,
+			Phase:                 olsv1alpha1.RAGBuildPending,
+			PersistentVolumeClaim: PVCName(build),
+		}
+		jobs, err := listJobs(r, ctx, client.MatchingLabels{utils.RAGBuildNameLabel: build.Name})
+		if err != nil {
+			status.Message = err.Error()
+			statuses = append(statuses, status)
+			continue
+		}
+		sort.Slice(jobs, func(i, j int) bool {
+			if !jobs[i].CreationTimestamp.Equal(&jobs[j].CreationTimestamp) {
+				return jobs[j].CreationTimestamp.Before(&jobs[i].CreationTimestamp)
+			}
+			return jobRetry(&jobs[i]) > jobRetry(&jobs[j])
+		})
+		if len(jobs) > 0 {
+			latest := &jobs[0]
+			status.JobName = latest.Name
+			if failed := jobCondition(latest, batchv1.JobFailed); failed != nil {
+				status.Phase = olsv1alpha1.RAGBuildFailed
+				status.Message = failed.Message
+				if latest.Labels[utils.RAGBuildSpecHashLabel] == specHash(r, build) {
+					status.Retries = jobRetry(latest)
+					status.NextRetryTime = nextRetryTime(latest)
+				}
+			} else if jobCondition(latest, batchv1.JobComplete) != nil {
+				status.Phase = olsv1alpha1.RAGBuildSucceeded
+			} else if latest.Status.Active > 0 {
+				status.Phase = olsv1alpha1.RAGBuildRunning
+			}
+		}
+		status.LastSucceededTime = lastSucceededTime(r, ctx, build, jobs)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// CompletionHash identifies the last successful builds of the indexes spec.ols.rag reads from: its change
+// restarts the app server to load the new indexes. It is empty when spec.ols.rag uses no built index.
+func CompletionHash(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) (string, error) {
+	claims := map[string]bool{}
+	for _, rag := range cr.Spec.OLSConfig.RAG {
+		if rag.PersistentVolumeClaim != nil {
+			claims[rag.PersistentVolumeClaim.ClaimName] = true
+		}
+	}
+	var completions []string
+	for _, build := range cr.Spec.OLSConfig.RAGBuilds {
+		if !claims[PVCName(build)] {
+			continue
+		}
+		jobs, err := listJobs(r, ctx, client.MatchingLabels{utils.RAGBuildNameLabel: build.Name})
+		if err != nil {
+			return "", err
+		}
+		if last := lastSucceededTime(r, ctx, build, jobs); last != nil {
+			completions = append(completions, build.Name+"="+last.UTC().Format(time.RFC3339))
+		}
+	}
+	if len(completions) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(completions, ",")))), nil
+}
@@ -0,0 +1,189 @@
+package ragbuild
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+var _ = Describe("RAG index build reconciler", Ordered, func() {
+	var testCR *olsv1alpha1.OLSConfig
+
+	getJob := func(name string) (*batchv1.Job, error) {
+		job := &batchv1.Job{}
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: utils.OLSNamespaceDefault}, job)
+		return job, err
+	}
+
+	finishJob := func(name string, conditionType batchv1.JobConditionType, message string) {
+		job, err := getJob(name)
+		Expect(err).NotTo(HaveOccurred())
+		now := metav1.Now()
+		job.Status.StartTime = &now
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:               conditionType,
+			Status:             corev1.ConditionTrue,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+			Message:            message,
+		}}
+		if conditionType == batchv1.JobComplete {
+			job.Status.CompletionTime = &now
+			job.Status.Succeeded = 1
+		} else {
+			job.Status.Failed = 1
+		}
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+	}
+
+	BeforeAll(func() {
+		testCR = cr.DeepCopy()
+		testCR.Spec.OLSConfig.RAGBuilds = []olsv1alpha1.RAGBuildSpec{
+			{
+				Name: "product-docs",
+				Source: olsv1alpha1.RAGBuildSource{
+					ConfigMap: &corev1.LocalObjectReference{Name: "product-docs"},
+				},
+			},
+			{
+				Name:     "runbooks",
+				Schedule: "0 3 * * *",
+				Source: olsv1alpha1.RAGBuildSource{
+					PersistentVolumeClaim: &olsv1alpha1.RAGBuildPersistentVolumeClaimSource{ClaimName: "runbooks"},
+				},
+			},
+		}
+		Expect(ReconcileResources(testReconcilerInstance, ctx, testCR)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		Expect(Remove(testReconcilerInstance, ctx)).To(Succeed())
+	})
+
+	It("should create the build script, the index PVCs and the build Jobs", func() {
+		cm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: utils.RAGBuildScriptConfigMapName, Namespace: utils.OLSNamespaceDefault}, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKey(utils.RAGBuildScriptKey))
+
+		for _, build := range testCR.Spec.OLSConfig.RAGBuilds {
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: PVCName(build), Namespace: utils.OLSNamespaceDefault}, pvc)).To(Succeed())
+			_, err := getJob(JobName(testReconcilerInstance, build))
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	It("should only create a CronJob for the scheduled build", func() {
+		cronJobs := &batchv1.CronJobList{}
+		Expect(k8sClient.List(ctx, cronJobs, client.InNamespace(utils.OLSNamespaceDefault))).To(Succeed())
+		Expect(cronJobs.Items).To(HaveLen(1))
+		Expect(cronJobs.Items[0].Name).To(Equal("lightspeed-rag-build-runbooks"))
+		Expect(cronJobs.Items[0].Spec.Schedule).To(Equal("0 3 * * *"))
+	})
+
+	It("should report the phase of the builds", func() {
+		statuses := Statuses(testReconcilerInstance, ctx, testCR)
+		Expect(statuses).To(HaveLen(2))
+		Expect(statuses[0].Phase).To(Equal(olsv1alpha1.RAGBuildPending))
+		Expect(statuses[0].PersistentVolumeClaim).To(Equal("lightspeed-rag-build-product-docs"))
+
+		finishJob(JobName(testReconcilerInstance, testCR.Spec.OLSConfig.RAGBuilds[0]), batchv1.JobComplete, "")
+		finishJob(JobName(testReconcilerInstance, testCR.Spec.OLSConfig.RAGBuilds[1]), batchv1.JobFailed, "BackoffLimitExceeded")
+
+		statuses = Statuses(testReconcilerInstance, ctx, testCR)
+		Expect(statuses[0].Phase).To(Equal(olsv1alpha1.RAGBuildSucceeded))
+		Expect(statuses[0].LastSucceededTime).NotTo(BeNil())
+		Expect(statuses[1].Phase).To(Equal(olsv1alpha1.RAGBuildFailed))
+		Expect(statuses[1].Message).To(Equal("BackoffLimitExceeded"))
+		Expect(statuses[1].LastSucceededTime).To(BeNil())
+		Expect(statuses[1].Retries).To(BeZero())
+		Expect(statuses[1].NextRetryTime).NotTo(BeNil())
+		Expect(statuses[1].NextRetryTime.Time).To(BeTemporally("~", time.Now().Add(utils.RAGBuildRetryInterval), time.Minute))
+	})
+
+	It("should retry a failed build once its backoff elapsed", func() {
+		failedName := JobName(testReconcilerInstance, testCR.Spec.OLSConfig.RAGBuilds[1])
+		Expect(ReconcileResources(testReconcilerInstance, ctx, testCR)).To(Succeed())
+		_, err := getJob(failedName + "-retry-1")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue(), "the failed build waits for its backoff")
+
+		failed, err := getJob(failedName)
+		Expect(err).NotTo(HaveOccurred())
+		failed.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(-utils.RAGBuildRetryInterval))
+		Expect(k8sClient.Status().Update(ctx, failed)).To(Succeed())
+		Expect(ReconcileResources(testReconcilerInstance, ctx, testCR)).To(Succeed())
+
+		retry, err := getJob(failedName + "-retry-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retry.Annotations).To(HaveKeyWithValue(utils.RAGBuildRetryAnnotation, "1"))
+		_, err = getJob(failedName)
+		Expect(err).NotTo(HaveOccurred(), "the failed Job is kept while the retry runs")
+
+		finishJob(retry.Name, batchv1.JobFailed, "BackoffLimitExceeded")
+		statuses := Statuses(testReconcilerInstance, ctx, testCR)
+		Expect(statuses[1].JobName).To(Equal(retry.Name))
+		Expect(statuses[1].Retries).To(Equal(int32(1)))
+		Expect(statuses[1].NextRetryTime.Time).To(BeTemporally("~", time.Now().Add(2*utils.RAGBuildRetryInterval), time.Minute))
+	})
+
+	It("should only hash the completions of the indexes read by the app server", func() {
+		hash, err := CompletionHash(testReconcilerInstance, ctx, testCR)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hash).To(BeEmpty())
+
+		withRAG := testCR.DeepCopy()
+		withRAG.Spec.OLSConfig.RAG = []olsv1alpha1.RAGSpec{{
+			PersistentVolumeClaim: &olsv1alpha1.RAGPersistentVolumeClaimSource{ClaimName: "lightspeed-rag-build-product-docs"},
+			IndexPath:             "/vector_db",
+			IndexID:               "product-docs",
+		}}
+		hash, err = CompletionHash(testReconcilerInstance, ctx, withRAG)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hash).NotTo(BeEmpty())
+	})
+
+	It("should replace the Job of a changed build once it finished", func() {
+		previous := JobName(testReconcilerInstance, testCR.Spec.OLSConfig.RAGBuilds[0])
+		testCR.Spec.OLSConfig.RAGBuilds[0].Source.ConfigMap.Name = "product-docs-v2"
+		Expect(ReconcileResources(testReconcilerInstance, ctx, testCR)).To(Succeed())
+
+		current := JobName(testReconcilerInstance, testCR.Spec.OLSConfig.RAGBuilds[0])
+		Expect(current).NotTo(Equal(previous))
+		_, err := getJob(current)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = getJob(previous)
+		Expect(err).NotTo(HaveOccurred(), "the previous Job is kept while the new one runs")
+
+		finishJob(current, batchv1.JobComplete, "")
+		Expect(ReconcileResources(testReconcilerInstance, ctx, testCR)).To(Succeed())
+		Eventually(func() bool {
+			_, err := getJob(previous)
+			return apierrors.IsNotFound(err)
+		}).Should(BeTrue())
+	})
+
+	It("should delete the Jobs and CronJobs of removed builds and keep their PVCs", func() {
+		removed := testCR.Spec.OLSConfig.RAGBuilds[1]
+		testCR.Spec.OLSConfig.RAGBuilds = testCR.Spec.OLSConfig.RAGBuilds[:1]
+		Expect(ReconcileResources(testReconcilerInstance, ctx, testCR)).To(Succeed())
+
+		Eventually(func() bool {
+			_, err := getJob(JobName(testReconcilerInstance, removed))
+			return apierrors.IsNotFound(err)
+		}).Should(BeTrue())
+		cronJob := &batchv1.CronJob{}
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: "lightspeed-rag-build-runbooks", Namespace: utils.OLSNamespaceDefault}, cronJob)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: PVCName(removed), Namespace: utils.OLSNamespaceDefault}, &corev1.PersistentVolumeClaim{})).To(Succeed())
+	})
+})
@@ -25,6 +25,7 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -88,4 +89,7 @@ type Reconciler interface {
 
 	// GetWatcherConfig returns the watcher configuration for external resource monitoring
 	GetWatcherConfig() interface{}
+
+	// GetEventRecorder returns the recorder for Kubernetes Events, or nil when events are not emitted
+	GetEventRecorder() events.EventRecorder
 }
@@ -0,0 +1,144 @@
+package rhokp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+// reconcileAccessKey creates and rotates the RHOKP access key Secret when
+// spec.ols.deployment.rhokp.accessKeyRotation is set. The Secret carries the watcher annotation,
+// the secret watcher then restarts RHOKP and the app server and refreshes the agentic handoff.
+// RHOKP only accepts the current key, so retrieval fails until both have rolled out.
+// A Secret created by the user is adopted and rotated from then on. The Secret has no owner
+// reference: it outlives the OLSConfig like the user-created one.
+func reconcileAccessKey(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	rotation := utils.RHOKPAccessKeyRotation(cr)
+	if rotation == nil {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Name: utils.RHOOKPAccessKeySecretName, Namespace: r.GetNamespace()}, secret)
+	if err != nil && errors.IsNotFound(err) {
+		key, err := generateAccessKey()
+		if err != nil {
+			return err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      utils.RHOOKPAccessKeySecretName,
+				Namespace: r.GetNamespace(),
+				Annotations: map[string]string{
+					utils.RHOKPAccessKeyRotatedAtAnnotation: time.Now().UTC().Format(time.RFC3339),
+				},
+			},
+			Data: map[string][]byte{
+				utils.RHOOKPAccessKeySecretKey: []byte(key),
+			},
+		}
+		utils.AnnotateSecretWatcher(secret)
+		r.GetLogger().Info("creating RHOKP access key secret", "secret", secret.Name)
+		if err := r.Create(ctx, secret); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrCreateRHOKPAccessKeySecret, err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrGetRHOKPAccessKeySecret, err)
+	}
+
+	now := time.Now().UTC()
+	rotatedAt, known := accessKeyRotatedAt(secret)
+	switch {
+	case len(secret.Data[utils.RHOOKPAccessKeySecretKey]) == 0:
+		r.GetLogger().Info("RHOKP access key secret has no access key, generating one", "secret", secret.Name)
+	case !known:
+		// Adopt the existing key, its age is unknown
+		r.GetLogger().Info("adopting RHOKP access key secret for rotation", "secret", secret.Name)
+		utils.AnnotateSecretWatcher(secret)
+		secret.Annotations[utils.RHOKPAccessKeyRotatedAtAnnotation] = now.Format(time.RFC3339)
+		if err := r.Update(ctx, secret); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrUpdateRHOKPAccessKeySecret, err)
+		}
+		return nil
+	case now.Before(rotatedAt.Add(accessKeyInterval(rotation))):
+		return nil
+	default:
+		r.GetLogger().Info("rotating RHOKP access key", "secret", secret.Name, "rotatedAt", rotatedAt)
+	}
+
+	key, err := generateAccessKey()
+	if err != nil {
+		return err
+	}
+	utils.AnnotateSecretWatcher(secret)
+	secret.Annotations[utils.RHOKPAccessKeyRotatedAtAnnotation] = now.Format(time.RFC3339)
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[utils.RHOOKPAccessKeySecretKey] = []byte(key)
+	if err := r.Update(ctx, secret); err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrUpdateRHOKPAccessKeySecret, err)
+	}
+	return nil
+}
+
+// NextAccessKeyRotation returns when the RHOKP access key is rotated next, zero when the rotation
+// is not managed by the operator or the access key Secret does not exist yet.
+func NextAccessKeyRotation(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) time.Time {
+	rotation := utils.RHOKPAccessKeyRotation(cr)
+	if rotation == nil {
+		return time.Time{}
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: utils.RHOOKPAccessKeySecretName, Namespace: r.GetNamespace()}, secret); err != nil {
+		return time.Time{}
+	}
+	rotatedAt, known := accessKeyRotatedAt(secret)
+	if !known {
+		return time.Time{}
+	}
+	return rotatedAt.Add(accessKeyInterval(rotation))
+}
+
+// accessKeyRotatedAt reads the last rotation time recorded on the access key Secret
+func accessKeyRotatedAt(secret *corev1.Secret) (time.Time, bool) {
+	value, ok := secret.Annotations[utils.RHOKPAccessKeyRotatedAtAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return rotatedAt, true
+}
+
+// accessKeyInterval returns the rotation interval, 30 days when unset
+func accessKeyInterval(rotation *olsv1alpha1.RHOKPAccessKeyRotation) time.Duration {
+	days := rotation.IntervalDays
+	if days <= 0 {
+		days = utils.RHOKPAccessKeyRotationIntervalDaysDefault
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// generateAccessKey returns a random hex-encoded access key
+func generateAccessKey() (string, error) {
+	key := make([]byte, utils.RHOKPAccessKeyBytes)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("%s: %w", utils.ErrGenerateRHOKPAccessKey, err)
+	}
+	return hex.EncodeToString(key), nil
+}
@@ -0,0 +1,92 @@
+package rhokp
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+var _ = Describe("RHOKP access key rotation", Ordered, func() {
+	var testCR *olsv1alpha1.OLSConfig
+
+	getAccessKeySecret := func() *corev1.Secret {
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Name:      utils.RHOOKPAccessKeySecretName,
+			Namespace: utils.OLSNamespaceDefault,
+		}, secret)).To(Succeed())
+		return secret
+	}
+
+	BeforeAll(func() {
+		testCR = cr.DeepCopy()
+		testCR.Spec.OLSConfig.ByokRAGOnly = false
+		testCR.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.AccessKeyRotation = &olsv1alpha1.RHOKPAccessKeyRotation{IntervalDays: 7}
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: utils.RHOOKPAccessKeySecretName, Namespace: utils.OLSNamespaceDefault},
+		}))).To(Succeed())
+	})
+
+	It("should not manage the Secret without a rotation", func() {
+		userCR := cr.DeepCopy()
+		userCR.Spec.OLSConfig.ByokRAGOnly = false
+		Expect(reconcileAccessKey(testReconcilerInstance, ctx, userCR)).To(Succeed())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Name:      utils.RHOOKPAccessKeySecretName,
+			Namespace: utils.OLSNamespaceDefault,
+		}, &corev1.Secret{})).NotTo(Succeed())
+		Expect(NextAccessKeyRotation(testReconcilerInstance, ctx, userCR).IsZero()).To(BeTrue())
+	})
+
+	It("should generate a watched access key and schedule its rotation", func() {
+		Expect(reconcileAccessKey(testReconcilerInstance, ctx, testCR)).To(Succeed())
+
+		secret := getAccessKeySecret()
+		Expect(secret.Data[utils.RHOOKPAccessKeySecretKey]).To(HaveLen(2 * utils.RHOKPAccessKeyBytes))
+		Expect(secret.Annotations).To(HaveKeyWithValue(utils.WatcherAnnotationKey, utils.OLSConfigName))
+		Expect(secret.OwnerReferences).To(BeEmpty())
+		Expect(NextAccessKeyRotation(testReconcilerInstance, ctx, testCR)).To(
+			BeTemporally("~", time.Now().Add(7*24*time.Hour), time.Minute))
+
+		By("keeping the key until the interval elapses")
+		Expect(reconcileAccessKey(testReconcilerInstance, ctx, testCR)).To(Succeed())
+		Expect(getAccessKeySecret().Data).To(Equal(secret.Data))
+	})
+
+	It("should rotate the access key once the interval elapsed", func() {
+		Expect(reconcileAccessKey(testReconcilerInstance, ctx, testCR)).To(Succeed())
+		secret := getAccessKeySecret()
+		oldKey := string(secret.Data[utils.RHOOKPAccessKeySecretKey])
+		secret.Annotations[utils.RHOKPAccessKeyRotatedAtAnnotation] = time.Now().Add(-8 * 24 * time.Hour).UTC().Format(time.RFC3339)
+		Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+
+		Expect(reconcileAccessKey(testReconcilerInstance, ctx, testCR)).To(Succeed())
+		rotated := getAccessKeySecret()
+		Expect(string(rotated.Data[utils.RHOOKPAccessKeySecretKey])).NotTo(Equal(oldKey))
+		Expect(NextAccessKeyRotation(testReconcilerInstance, ctx, testCR)).To(BeTemporally(">", time.Now()))
+	})
+
+	It("should adopt the access key created by the user", func() {
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: utils.RHOOKPAccessKeySecretName, Namespace: utils.OLSNamespaceDefault},
+			Data:       map[string][]byte{utils.RHOOKPAccessKeySecretKey: []byte("user-key")},
+		})).To(Succeed())
+
+		Expect(reconcileAccessKey(testReconcilerInstance, ctx, testCR)).To(Succeed())
+		secret := getAccessKeySecret()
+		Expect(string(secret.Data[utils.RHOOKPAccessKeySecretKey])).To(Equal("user-key"))
+		Expect(secret.Annotations).To(HaveKey(utils.RHOKPAccessKeyRotatedAtAnnotation))
+		Expect(secret.Annotations).To(HaveKeyWithValue(utils.WatcherAnnotationKey, utils.OLSConfigName))
+	})
+})
@@ -7,6 +7,8 @@ import (
 	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -150,3 +152,70 @@ func generateServiceMonitor(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig)
 	}
 	return &serviceMonitor, nil
 }
+
+// generateSolrDataPVC generates the ReadWriteOnce PVC of the Solr data directory, 75Gi of the default
+// storage class unless spec.ols.deployment.rhokp.storage sets the size or the class.
+func generateSolrDataPVC(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (*corev1.PersistentVolumeClaim, error) {
+	storage := cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.Storage
+	size := resource.MustParse(utils.RHOKPSolrDataSizeLimitDefault)
+	if !storage.Size.IsZero() {
+		size = storage.Size
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.RHOKPSolrDataPVCName,
+			Namespace: r.GetNamespace(),
+			Labels:    selectorLabels(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+	if storage.Class != "" {
+		pvc.Spec.StorageClassName = &storage.Class
+	}
+
+	if err := controllerutil.SetControllerReference(cr, pvc, r.GetScheme()); err != nil {
+		return nil, err
+	}
+	return pvc, nil
+}
+
+// replicas returns the number of RHOKP replicas set in spec.ols.deployment.rhokp, 1 when unset
+func replicas(cr *olsv1alpha1.OLSConfig) int32 {
+	if r := cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.Replicas; r != nil {
+		return *r
+	}
+	return 1
+}
+
+// generatePodDisruptionBudget generates the PodDisruptionBudget letting voluntary disruptions such as
+// node drains evict one RHOKP pod at a time, so the other replicas keep serving the documentation.
+func generatePodDisruptionBudget(r reconciler.Reconciler, cr *olsv1alpha1.OLSConfig) (*policyv1.PodDisruptionBudget, error) {
+	maxUnavailable := intstr.FromInt32(1)
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.RHOKPPodDisruptionBudgetName,
+			Namespace: r.GetNamespace(),
+			Labels:    selectorLabels(),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels(),
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(cr, pdb, r.GetScheme()); err != nil {
+		return nil, err
+	}
+	return pdb, nil
+}
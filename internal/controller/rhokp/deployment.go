@@ -7,6 +7,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,17 +21,19 @@ import (
 )
 
 func getResources(cr *olsv1alpha1.OLSConfig) *corev1.ResourceRequirements {
-	return utils.GetResourcesOrDefault(
-		cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.Resources,
-		&corev1.ResourceRequirements{
-			Requests: corev1.ResourceList{
-				corev1.ResourceCPU:              resource.MustParse("2"),
-				corev1.ResourceMemory:           resource.MustParse("2Gi"),
-				corev1.ResourceEphemeralStorage: resource.MustParse(utils.RHOKPSolrDataSizeLimitDefault),
-			},
-			Claims: []corev1.ResourceClaim{},
+	defaults := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:              resource.MustParse("2"),
+			corev1.ResourceMemory:           resource.MustParse("2Gi"),
+			corev1.ResourceEphemeralStorage: resource.MustParse(utils.RHOKPSolrDataSizeLimitDefault),
 		},
-	)
+		Claims: []corev1.ResourceClaim{},
+	}
+	// Solr data on a persistent volume does not use the node ephemeral storage
+	if cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.Storage != nil {
+		delete(defaults.Requests, corev1.ResourceEphemeralStorage)
+	}
+	return utils.GetResourcesOrDefault(cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.Resources, defaults)
 }
 
 func getSecretResourceVersion(r reconciler.Reconciler, ctx context.Context, secretName string) (string, error) {
@@ -43,7 +46,7 @@ func getSecretResourceVersion(r reconciler.Reconciler, ctx context.Context, secr
 }
 
 // GenerateDeployment generates the standalone RHOKP Deployment with service-ca TLS
-// mounted for Apache httpd, an EmptyDir for Solr data, and HTTPS probes.
+// mounted for Apache httpd, an EmptyDir or a PVC for Solr data, and HTTPS probes.
 func GenerateDeployment(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) (*appsv1.Deployment, error) {
 	revisionHistoryLimit := int32(1)
 	runAsNonRoot := true
@@ -62,6 +65,19 @@ func GenerateDeployment(r reconciler.Reconciler, ctx context.Context, cr *olsv1a
 	if es, ok := resources.Requests[corev1.ResourceEphemeralStorage]; ok {
 		solrDataSizeLimit = es
 	}
+	solrDataVolumeSource := corev1.VolumeSource{
+		EmptyDir: &corev1.EmptyDirVolumeSource{
+			SizeLimit: &solrDataSizeLimit,
+		},
+	}
+	rhokpSpec := cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer
+	if rhokpSpec.Storage != nil {
+		solrDataVolumeSource = corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: utils.RHOKPSolrDataPVCName,
+			},
+		}
+	}
 
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -167,12 +183,8 @@ func GenerateDeployment(r reconciler.Reconciler, ctx context.Context, cr *olsv1a
 							},
 						},
 						{
-							Name: utils.RHOKPSolrDataVolumeName,
-							VolumeSource: corev1.VolumeSource{
-								EmptyDir: &corev1.EmptyDirVolumeSource{
-									SizeLimit: &solrDataSizeLimit,
-								},
-							},
+							Name:         utils.RHOKPSolrDataVolumeName,
+							VolumeSource: solrDataVolumeSource,
 						},
 					},
 				},
@@ -180,7 +192,33 @@ func GenerateDeployment(r reconciler.Reconciler, ctx context.Context, cr *olsv1a
 		},
 	}
 
-	utils.ApplyPodDeploymentConfig(deployment, cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer, false)
+	if rhokpSpec.Storage != nil {
+		// The ReadWriteOnce claim is released by the old pod before the new one starts
+		deployment.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+	}
+	if rhokpSpec.WarmUpGate {
+		deployment.Spec.Template.Spec.ReadinessGates = []corev1.PodReadinessGate{
+			{ConditionType: utils.RHOKPWarmUpConditionType},
+		}
+	}
+
+	utils.ApplyPodDeploymentConfig(deployment, rhokpSpec.Config, true)
+	if *deployment.Spec.Replicas > 1 {
+		// Prefer spreading the replicas across nodes so that a node drain keeps documentation retrieval up
+		deployment.Spec.Template.Spec.Affinity = &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+					{
+						Weight: utils.RHOKPAntiAffinityWeight,
+						PodAffinityTerm: corev1.PodAffinityTerm{
+							LabelSelector: &metav1.LabelSelector{MatchLabels: selectorLabels()},
+							TopologyKey:   corev1.LabelHostname,
+						},
+					},
+				},
+			},
+		}
+	}
 
 	if err := controllerutil.SetControllerReference(cr, deployment, r.GetScheme()); err != nil {
 		return nil, fmt.Errorf("%s: %w", utils.ErrSetRHOKPDeploymentOwnerReference, err)
@@ -192,7 +230,9 @@ func GenerateDeployment(r reconciler.Reconciler, ctx context.Context, cr *olsv1a
 // UpdateDeployment updates the RHOKP Deployment when the pod spec or TLS Secret changes.
 func UpdateDeployment(r reconciler.Reconciler, ctx context.Context, existingDeployment, desiredDeployment *appsv1.Deployment) error {
 	utils.SetDefaults_Deployment(desiredDeployment)
-	changed := !utils.DeploymentSpecEqual(&existingDeployment.Spec, &desiredDeployment.Spec, false)
+	changed := !utils.DeploymentSpecEqual(&existingDeployment.Spec, &desiredDeployment.Spec, false) ||
+		!apiequality.Semantic.DeepEqual(existingDeployment.Spec.Template.Spec.ReadinessGates, desiredDeployment.Spec.Template.Spec.ReadinessGates) ||
+		!apiequality.Semantic.DeepEqual(existingDeployment.Spec.Template.Spec.Affinity, desiredDeployment.Spec.Template.Spec.Affinity)
 
 	if existingDeployment.Annotations[utils.RHOKPTLSSecretResourceVersionAnnotation] !=
 		desiredDeployment.Annotations[utils.RHOKPTLSSecretResourceVersionAnnotation] {
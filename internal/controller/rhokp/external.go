@@ -0,0 +1,136 @@
+package rhokp
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+// ExternalChecker runs CheckExternal and remembers its outcome, so that the external knowledge portal is
+// only pinged again when its settings, credentials or trusted CAs change, or on the periodic recheck
+// once the result is older than utils.OKPExternalRecheckInterval. It is safe for concurrent use.
+type ExternalChecker struct {
+	mu          sync.Mutex
+	fingerprint string
+	checkedAt   time.Time
+	err         error
+}
+
+// NewExternalChecker returns an ExternalChecker without a cached result.
+func NewExternalChecker() *ExternalChecker {
+	return &ExternalChecker{}
+}
+
+// Check returns the cached outcome of the external knowledge portal check, checking the portal again
+// when the cached result is stale.
+func (c *ExternalChecker) Check(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	external := utils.OKPExternal(cr)
+	if external == nil {
+		return nil
+	}
+	trust, err := externalTrustConfig(r, ctx, cr, external)
+	if err != nil {
+		return err
+	}
+	fingerprint := externalFingerprint(r, ctx, external, trust)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fingerprint == fingerprint && time.Since(c.checkedAt) < utils.OKPExternalRecheckInterval {
+		return c.err
+	}
+	c.err = checkExternal(r, ctx, external, trust)
+	c.fingerprint = fingerprint
+	c.checkedAt = time.Now()
+	return c.err
+}
+
+// externalFingerprint identifies everything the external knowledge portal check depends on
+func externalFingerprint(r reconciler.Reconciler, ctx context.Context, external *olsv1alpha1.OKPExternalSpec, trust utils.TrustConfig) string {
+	specJSON, _ := json.Marshal(external)
+	// The credentials Secret is identified by its resource version, a missing Secret by an empty one
+	secret := &corev1.Secret{}
+	if ref := external.CredentialsSecretRef; ref != nil && ref.Name != "" {
+		_ = r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: r.GetNamespace()}, secret)
+	}
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s", specJSON, secret.ResourceVersion, trust.Fingerprint)))
+	return hex.EncodeToString(hash[:])
+}
+
+// externalTrustConfig returns the proxy settings and the trusted CAs of the check: the system roots
+// plus the portal CA, spec.ols.additionalCAConfigMapRef and the proxy CA
+func externalTrustConfig(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig, external *olsv1alpha1.OKPExternalSpec) (utils.TrustConfig, error) {
+	var extraCAs []utils.TrustedCAConfigMap
+	if ref := external.CAConfigMapRef; ref != nil && ref.Name != "" {
+		extraCAs = append(extraCAs, utils.TrustedCAConfigMap{
+			Name: ref.Name,
+			Key:  utils.OKPExternalCACertKey,
+			Err:  utils.ErrGetOKPExternalCAConfigMap,
+		})
+	}
+	return utils.LoadTrustConfig(r, ctx, cr, extraCAs...)
+}
+
+// CheckExternal pings the Solr collection of the external knowledge portal set in spec.ols.okp.external.
+// The request goes through spec.ols.proxyConfig (or the cluster proxy from the operator environment),
+// trusts the system roots plus the portal CA, spec.ols.additionalCAConfigMapRef and the proxy CA, and
+// authenticates with the credentials Secret when one is referenced.
+func CheckExternal(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	external := utils.OKPExternal(cr)
+	if external == nil {
+		return nil
+	}
+	trust, err := externalTrustConfig(r, ctx, cr, external)
+	if err != nil {
+		return err
+	}
+	return checkExternal(r, ctx, external, trust)
+}
+
+// checkExternal sends the Solr ping of CheckExternal with an already loaded trust config
+func checkExternal(r reconciler.Reconciler, ctx context.Context, external *olsv1alpha1.OKPExternalSpec, trust utils.TrustConfig) error {
+	httpClient, err := utils.NewHTTPClient(trust, &tls.Config{MinVersion: tls.VersionTLS12}, utils.OKPExternalCheckTimeout)
+	if err != nil {
+		return err
+	}
+
+	endpoint := strings.TrimSuffix(external.URL, "/") + utils.RHOOKPReadinessHTTPPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrCheckOKPExternal, err)
+	}
+	if ref := external.CredentialsSecretRef; ref != nil && ref.Name != "" {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: r.GetNamespace()}, secret); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrGetOKPExternalCredentialsSecret, err)
+		}
+		if token := strings.TrimSpace(string(secret.Data["apitoken"])); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else if username := string(secret.Data["username"]); username != "" {
+			req.SetBasicAuth(username, string(secret.Data["password"]))
+		}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrCheckOKPExternal, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s returned HTTP %d", utils.ErrCheckOKPExternal, endpoint, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package rhokp
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+var _ = Describe("External knowledge portal check", Ordered, func() {
+	const caConfigMapName = "external-okp-ca"
+	const credentialsSecretName = "external-okp-credentials"
+
+	var server *httptest.Server
+	var pingStatus int
+	var pings int
+	var authorization string
+	var testCR *olsv1alpha1.OLSConfig
+
+	BeforeAll(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			authorization = req.Header.Get("Authorization")
+			pings++
+			if req.URL.Path != utils.RHOOKPReadinessHTTPPath {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(pingStatus)
+		}))
+		caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: caConfigMapName, Namespace: utils.OLSNamespaceDefault},
+			Data:       map[string]string{utils.OKPExternalCACertKey: string(caPEM)},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: credentialsSecretName, Namespace: utils.OLSNamespaceDefault},
+			Data:       map[string][]byte{"apitoken": []byte("portal-token")},
+		})).To(Succeed())
+	})
+
+	AfterAll(func() {
+		server.Close()
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: caConfigMapName, Namespace: utils.OLSNamespaceDefault},
+		}))).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: credentialsSecretName, Namespace: utils.OLSNamespaceDefault},
+		}))).To(Succeed())
+	})
+
+	BeforeEach(func() {
+		pingStatus = http.StatusOK
+		authorization = ""
+		testCR = cr.DeepCopy()
+		testCR.Spec.OLSConfig.ByokRAGOnly = false
+		testCR.Spec.OLSConfig.OKP = &olsv1alpha1.OKPSpec{
+			External: &olsv1alpha1.OKPExternalSpec{
+				URL:                  server.URL + "/",
+				CAConfigMapRef:       &corev1.LocalObjectReference{Name: caConfigMapName},
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: credentialsSecretName},
+			},
+		}
+	})
+
+	It("should succeed when the portal answers the Solr ping", func() {
+		Expect(CheckExternal(testReconcilerInstance, ctx, testCR)).To(Succeed())
+		Expect(authorization).To(Equal("Bearer portal-token"))
+	})
+
+	It("should fail when the portal CA is not trusted", func() {
+		testCR.Spec.OLSConfig.OKP.External.CAConfigMapRef = nil
+		err := CheckExternal(testReconcilerInstance, ctx, testCR)
+		Expect(err).To(MatchError(ContainSubstring(utils.ErrCheckOKPExternal)))
+	})
+
+	It("should fail when the Solr ping is not successful", func() {
+		pingStatus = http.StatusServiceUnavailable
+		err := CheckExternal(testReconcilerInstance, ctx, testCR)
+		Expect(err).To(MatchError(ContainSubstring("HTTP 503")))
+	})
+
+	It("should fail when the credentials Secret is missing", func() {
+		testCR.Spec.OLSConfig.OKP.External.CredentialsSecretRef = &corev1.LocalObjectReference{Name: "missing-secret"}
+		err := CheckExternal(testReconcilerInstance, ctx, testCR)
+		Expect(err).To(MatchError(ContainSubstring(utils.ErrGetOKPExternalCredentialsSecret)))
+	})
+
+	It("should only ping the portal again when its settings change", func() {
+		checker := NewExternalChecker()
+		pings = 0
+		Expect(checker.Check(testReconcilerInstance, ctx, testCR)).To(Succeed())
+		Expect(checker.Check(testReconcilerInstance, ctx, testCR)).To(Succeed())
+		Expect(pings).To(Equal(1))
+
+		By("reusing the result until the periodic recheck")
+		pingStatus = http.StatusServiceUnavailable
+		Expect(checker.Check(testReconcilerInstance, ctx, testCR)).To(Succeed())
+		Expect(pings).To(Equal(1))
+
+		By("checking again once the portal settings change")
+		testCR.Spec.OLSConfig.OKP.External.CredentialsSecretRef = nil
+		Expect(checker.Check(testReconcilerInstance, ctx, testCR)).To(MatchError(ContainSubstring("HTTP 503")))
+		Expect(pings).To(Equal(2))
+	})
+
+	It("should not check anything without an external portal", func() {
+		testCR.Spec.OLSConfig.OKP = nil
+		Expect(CheckExternal(testReconcilerInstance, ctx, testCR)).To(Succeed())
+	})
+})
@@ -9,6 +9,8 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -21,15 +23,20 @@ import (
 func ReconcileResources(r reconciler.Reconciler, ctx context.Context, olsconfig *olsv1alpha1.OLSConfig) error {
 	return utils.RunReconcileTasks(r, ctx, olsconfig, "reconcileRHOKPResources", []utils.ReconcileTask{
 		{Name: "reconcile RHOKP NetworkPolicy", Task: reconcileNetworkPolicy},
+		{Name: "reconcile RHOKP access key", Task: reconcileAccessKey},
 	}, true)
 }
 
-// ReconcileDeployment reconciles Phase 2: Service, TLS material, and Deployment.
+// ReconcileDeployment reconciles Phase 2: Service, TLS material, Solr data PVC, Deployment, warm-up gate and
+// PodDisruptionBudget.
 func ReconcileDeployment(r reconciler.Reconciler, ctx context.Context, olsconfig *olsv1alpha1.OLSConfig) error {
 	return utils.RunReconcileTasks(r, ctx, olsconfig, "reconcileRHOKPDeployment", []utils.ReconcileTask{
 		{Name: "reconcile RHOKP Service", Task: reconcileService},
 		{Name: "reconcile RHOKP TLS Certs", Task: reconcileTLSSecret},
+		{Name: "reconcile RHOKP Solr data PVC", Task: reconcileSolrDataPVC},
 		{Name: "reconcile RHOKP Deployment", Task: reconcileDeployment},
+		{Name: "reconcile RHOKP warm-up gate", Task: reconcileWarmUpGate},
+		{Name: "reconcile RHOKP PodDisruptionBudget", Task: reconcilePodDisruptionBudget},
 		{Name: "reconcile RHOKP ServiceMonitor", Task: reconcileServiceMonitor},
 	}, false)
 }
@@ -42,6 +49,8 @@ func Remove(r reconciler.Reconciler, ctx context.Context) error {
 		{Name: "delete RHOKP network policy", Task: deleteNetworkPolicy},
 		{Name: "delete RHOKP TLS secret", Task: deleteTLSSecret},
 		{Name: "delete RHOKP ServiceMonitor", Task: deleteServiceMonitor},
+		{Name: "delete RHOKP Solr data PVC", Task: deleteSolrDataPVC},
+		{Name: "delete RHOKP PodDisruptionBudget", Task: deletePodDisruptionBudget},
 	})
 }
 
@@ -115,6 +124,80 @@ func reconcileDeployment(r reconciler.Reconciler, ctx context.Context, cr *olsv1
 	return nil
 }
 
+func reconcileSolrDataPVC(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	if cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.Storage == nil {
+		// Solr data is back on an emptyDir, release the volume
+		if err := deleteSolrDataPVC(r, ctx); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrDeleteRHOKPSolrDataPVC, err)
+		}
+		return nil
+	}
+	pvc, err := generateSolrDataPVC(r, cr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrGenerateRHOKPSolrDataPVC, err)
+	}
+
+	// The claim is only created, its size and class cannot change with most storage classes
+	err = r.Get(ctx, client.ObjectKey{Name: utils.RHOKPSolrDataPVCName, Namespace: r.GetNamespace()}, &corev1.PersistentVolumeClaim{})
+	if err != nil && errors.IsNotFound(err) {
+		r.GetLogger().Info("creating RHOKP Solr data PVC", "pvc", pvc.Name)
+		if err := r.Create(ctx, pvc); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrCreateRHOKPSolrDataPVC, err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrGetRHOKPSolrDataPVC, err)
+	}
+	return nil
+}
+
+func reconcilePodDisruptionBudget(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	if replicas(cr) <= 1 {
+		// A single pod is never protected, the budget would block node drains
+		if err := deletePodDisruptionBudget(r, ctx); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrDeleteRHOKPPodDisruptionBudget, err)
+		}
+		return nil
+	}
+	pdb, err := generatePodDisruptionBudget(r, cr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrGenerateRHOKPPodDisruptionBudget, err)
+	}
+
+	foundPDB := &policyv1.PodDisruptionBudget{}
+	err = r.Get(ctx, client.ObjectKey{Name: utils.RHOKPPodDisruptionBudgetName, Namespace: r.GetNamespace()}, foundPDB)
+	if err != nil && errors.IsNotFound(err) {
+		r.GetLogger().Info("creating RHOKP PodDisruptionBudget", "poddisruptionbudget", pdb.Name)
+		if err := r.Create(ctx, pdb); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrCreateRHOKPPodDisruptionBudget, err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrGetRHOKPPodDisruptionBudget, err)
+	}
+
+	if apiequality.Semantic.DeepEqual(foundPDB.Spec, pdb.Spec) && reflect.DeepEqual(foundPDB.Labels, pdb.Labels) {
+		r.GetLogger().Info("RHOKP PodDisruptionBudget unchanged, reconciliation skipped", "poddisruptionbudget", pdb.Name)
+		return nil
+	}
+
+	foundPDB.Labels = pdb.Labels
+	foundPDB.Spec = pdb.Spec
+	if err := r.Update(ctx, foundPDB); err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrUpdateRHOKPPodDisruptionBudget, err)
+	}
+	r.GetLogger().Info("RHOKP PodDisruptionBudget reconciled", "poddisruptionbudget", pdb.Name)
+	return nil
+}
+
+func deletePodDisruptionBudget(r reconciler.Reconciler, ctx context.Context) error {
+	return deleteNamespacedObject(r, ctx, &policyv1.PodDisruptionBudget{}, utils.RHOKPPodDisruptionBudgetName)
+}
+
+func deleteSolrDataPVC(r reconciler.Reconciler, ctx context.Context) error {
+	return deleteNamespacedObject(r, ctx, &corev1.PersistentVolumeClaim{}, utils.RHOKPSolrDataPVCName)
+}
+
 func deleteDeployment(r reconciler.Reconciler, ctx context.Context) error {
 	return deleteNamespacedObject(r, ctx, &appsv1.Deployment{}, utils.RHOKPDeploymentName)
 }
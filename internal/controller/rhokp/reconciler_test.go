@@ -9,6 +9,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -265,4 +266,146 @@ var _ = Describe("RHOKP reconciler", Ordered, func() {
 			Expect(apierrors.IsNotFound(err)).To(BeTrue(), "ServiceMonitor should be deleted")
 		})
 	})
+
+	Context("Solr data persistence and warm-up gate", func() {
+		var persistentCR *olsv1alpha1.OLSConfig
+
+		BeforeAll(func() {
+			persistentCR = testCR.DeepCopy()
+			persistentCR.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.Storage = &olsv1alpha1.Storage{Class: "fast"}
+			persistentCR.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.WarmUpGate = true
+			ensureRHOKPTLSSecret()
+			Expect(ReconcileDeployment(testReconcilerInstance, ctx, persistentCR)).To(Succeed())
+		})
+
+		AfterAll(func() {
+			Expect(Remove(testReconcilerInstance, ctx)).To(Succeed())
+		})
+
+		It("should create the Solr data PVC with the default size", func() {
+			pvc := &corev1.PersistentVolumeClaim{}
+			err := k8sClient.Get(ctx, types.NamespacedName{
+				Name:      utils.RHOKPSolrDataPVCName,
+				Namespace: utils.OLSNamespaceDefault,
+			}, pvc)
+			Expect(err).NotTo(HaveOccurred())
+			expectOwnedByOLSConfig(pvc)
+			Expect(pvc.Spec.AccessModes).To(ConsistOf(corev1.ReadWriteOnce))
+			Expect(pvc.Spec.Resources.Requests.Storage().String()).To(Equal(utils.RHOKPSolrDataSizeLimitDefault))
+			Expect(pvc.Spec.StorageClassName).To(HaveValue(Equal("fast")))
+		})
+
+		It("should mount the PVC, recreate pods and gate their readiness on the warm-up", func() {
+			dep := &appsv1.Deployment{}
+			err := k8sClient.Get(ctx, types.NamespacedName{
+				Name:      utils.RHOKPDeploymentName,
+				Namespace: utils.OLSNamespaceDefault,
+			}, dep)
+			Expect(err).NotTo(HaveOccurred())
+
+			var solrVolume *corev1.Volume
+			for i := range dep.Spec.Template.Spec.Volumes {
+				if dep.Spec.Template.Spec.Volumes[i].Name == utils.RHOKPSolrDataVolumeName {
+					solrVolume = &dep.Spec.Template.Spec.Volumes[i]
+					break
+				}
+			}
+			Expect(solrVolume).NotTo(BeNil())
+			Expect(solrVolume.PersistentVolumeClaim).NotTo(BeNil())
+			Expect(solrVolume.PersistentVolumeClaim.ClaimName).To(Equal(utils.RHOKPSolrDataPVCName))
+			Expect(dep.Spec.Strategy.Type).To(Equal(appsv1.RecreateDeploymentStrategyType))
+			Expect(dep.Spec.Template.Spec.ReadinessGates).To(ConsistOf(corev1.PodReadinessGate{
+				ConditionType: utils.RHOKPWarmUpConditionType,
+			}))
+			Expect(dep.Spec.Template.Spec.Containers[0].Resources.Requests).NotTo(HaveKey(corev1.ResourceEphemeralStorage))
+		})
+
+		It("should release the PVC when the storage is removed", func() {
+			Expect(ReconcileDeployment(testReconcilerInstance, ctx, testCR)).To(Succeed())
+
+			dep := &appsv1.Deployment{}
+			err := k8sClient.Get(ctx, types.NamespacedName{
+				Name:      utils.RHOKPDeploymentName,
+				Namespace: utils.OLSNamespaceDefault,
+			}, dep)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dep.Spec.Template.Spec.ReadinessGates).To(BeEmpty())
+			Expect(dep.Spec.Strategy.Type).To(Equal(appsv1.RollingUpdateDeploymentStrategyType))
+
+			// envtest has no controller removing the pvc-protection finalizer
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = k8sClient.Get(ctx, types.NamespacedName{
+				Name:      utils.RHOKPSolrDataPVCName,
+				Namespace: utils.OLSNamespaceDefault,
+			}, pvc)
+			Expect(apierrors.IsNotFound(err) || pvc.DeletionTimestamp != nil).To(BeTrue(), "PVC should be deleted")
+		})
+	})
+
+	Context("Multiple replicas", func() {
+		var scaledCR *olsv1alpha1.OLSConfig
+
+		BeforeAll(func() {
+			scaledCR = testCR.DeepCopy()
+			scaledCR.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.Replicas = &[]int32{3}[0]
+			ensureRHOKPTLSSecret()
+			Expect(ReconcileDeployment(testReconcilerInstance, ctx, scaledCR)).To(Succeed())
+		})
+
+		AfterAll(func() {
+			Expect(Remove(testReconcilerInstance, ctx)).To(Succeed())
+			err := k8sClient.Get(ctx, types.NamespacedName{
+				Name:      utils.RHOKPPodDisruptionBudgetName,
+				Namespace: utils.OLSNamespaceDefault,
+			}, &policyv1.PodDisruptionBudget{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue(), "PodDisruptionBudget should be deleted")
+		})
+
+		It("should spread the replicas across nodes", func() {
+			dep := &appsv1.Deployment{}
+			err := k8sClient.Get(ctx, types.NamespacedName{
+				Name:      utils.RHOKPDeploymentName,
+				Namespace: utils.OLSNamespaceDefault,
+			}, dep)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*dep.Spec.Replicas).To(Equal(int32(3)))
+			Expect(dep.Spec.Template.Spec.Affinity).NotTo(BeNil())
+			terms := dep.Spec.Template.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+			Expect(terms).To(HaveLen(1))
+			Expect(terms[0].PodAffinityTerm.TopologyKey).To(Equal(corev1.LabelHostname))
+			Expect(terms[0].PodAffinityTerm.LabelSelector.MatchLabels).To(Equal(selectorLabels()))
+			Expect(dep.Spec.Template.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+		})
+
+		It("should create a PodDisruptionBudget evicting one pod at a time", func() {
+			pdb := &policyv1.PodDisruptionBudget{}
+			err := k8sClient.Get(ctx, types.NamespacedName{
+				Name:      utils.RHOKPPodDisruptionBudgetName,
+				Namespace: utils.OLSNamespaceDefault,
+			}, pdb)
+			Expect(err).NotTo(HaveOccurred())
+			expectOwnedByOLSConfig(pdb)
+			Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+			Expect(pdb.Spec.Selector.MatchLabels).To(Equal(selectorLabels()))
+		})
+
+		It("should drop the PodDisruptionBudget and the anti-affinity with a single replica", func() {
+			Expect(ReconcileDeployment(testReconcilerInstance, ctx, testCR)).To(Succeed())
+
+			err := k8sClient.Get(ctx, types.NamespacedName{
+				Name:      utils.RHOKPPodDisruptionBudgetName,
+				Namespace: utils.OLSNamespaceDefault,
+			}, &policyv1.PodDisruptionBudget{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue(), "PodDisruptionBudget should be deleted")
+
+			dep := &appsv1.Deployment{}
+			err = k8sClient.Get(ctx, types.NamespacedName{
+				Name:      utils.RHOKPDeploymentName,
+				Namespace: utils.OLSNamespaceDefault,
+			}, dep)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*dep.Spec.Replicas).To(Equal(int32(1)))
+			Expect(dep.Spec.Template.Spec.Affinity).To(BeNil())
+		})
+	})
 })
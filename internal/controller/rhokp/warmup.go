@@ -0,0 +1,180 @@
+package rhokp
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+// reconcileWarmUpGate sets the ols.openshift.io/rhokp-warmed-up readiness gate of the RHOKP pods
+// once they answer the warm-up query with documentation chunks, so that the Service only routes to
+// pods with the portal-rag core loaded. Pods failing the query are retried after
+// RHOKPWarmUpRecheckInterval (see WarmUpPending), the Deployment stays not ready meanwhile.
+func reconcileWarmUpGate(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
+	if !cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.WarmUpGate {
+		return nil
+	}
+
+	pending, err := pendingWarmUpPods(r, ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	httpClient, err := warmUpClient(r, ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrWarmUpRHOKPPod, err)
+	}
+	token, err := warmUpToken(r, ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", utils.ErrWarmUpRHOKPPod, err)
+	}
+	for _, pod := range pending {
+		baseURL := "https://" + net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(utils.RHOOKPImageHTTPSPort))
+		if err := warmUpPod(ctx, httpClient, baseURL, token); err != nil {
+			r.GetLogger().Info("RHOKP pod not warmed up yet", "pod", pod.Name, "reason", err.Error())
+			continue
+		}
+		pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+			Type:               utils.RHOKPWarmUpConditionType,
+			Status:             corev1.ConditionTrue,
+			Reason:             "WarmedUp",
+			Message:            "The portal-rag core answered the warm-up query",
+			LastTransitionTime: metav1.Now(),
+		})
+		if err := r.Status().Update(ctx, pod); err != nil {
+			return fmt.Errorf("%s: %w", utils.ErrUpdateRHOKPPodStatus, err)
+		}
+		r.GetLogger().Info("RHOKP pod warmed up", "pod", pod.Name)
+	}
+	return nil
+}
+
+// WarmUpPending checks if a RHOKP pod still waits for its warm-up gate. Nothing in the cluster reports
+// that the portal-rag core got loaded, the reconciliation queries these pods again after RHOKPWarmUpRecheckInterval.
+func WarmUpPending(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) bool {
+	if !utils.IsRHOKPDeployed(cr) || !cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.WarmUpGate {
+		return false
+	}
+	pending, err := pendingWarmUpPods(r, ctx)
+	return err == nil && len(pending) > 0
+}
+
+// pendingWarmUpPods returns the RHOKP pods waiting for their warm-up gate
+func pendingWarmUpPods(r reconciler.Reconciler, ctx context.Context) ([]*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(r.GetNamespace()), client.MatchingLabels(selectorLabels())); err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.ErrListRHOKPPods, err)
+	}
+	var pending []*corev1.Pod
+	for i := range pods.Items {
+		if pod := &pods.Items[i]; needsWarmUp(pod) {
+			pending = append(pending, pod)
+		}
+	}
+	return pending, nil
+}
+
+// needsWarmUp checks if a running pod with ready containers still waits for its warm-up gate
+func needsWarmUp(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.PodIP == "" {
+		return false
+	}
+	containersReady := false
+	for _, condition := range pod.Status.Conditions {
+		switch condition.Type {
+		case utils.RHOKPWarmUpConditionType:
+			if condition.Status == corev1.ConditionTrue {
+				return false
+			}
+		case corev1.ContainersReady:
+			containersReady = condition.Status == corev1.ConditionTrue
+		}
+	}
+	return containersReady
+}
+
+// warmUpClient returns an HTTPS client trusting the service-ca that signs the RHOKP serving certificate
+func warmUpClient(r reconciler.Reconciler, ctx context.Context) (*http.Client, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Name: utils.OLSCAConfigMap, Namespace: r.GetNamespace()}, cm); err != nil {
+		return nil, fmt.Errorf("failed to get service CA configmap %s: %w", utils.OLSCAConfigMap, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(cm.Data[utils.RHOKPServiceCACertKey])) {
+		return nil, fmt.Errorf("no certificate in service CA configmap %s", utils.OLSCAConfigMap)
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    pool,
+				ServerName: strings.Join([]string{utils.RHOKPServiceName, r.GetNamespace(), "svc"}, "."),
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+		Timeout: utils.RHOKPWarmUpTimeout,
+	}, nil
+}
+
+// warmUpToken returns the access key authenticating the warm-up query, rotated by the operator or
+// created by the user, empty without an access key Secret
+func warmUpToken(r reconciler.Reconciler, ctx context.Context) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: utils.RHOOKPAccessKeySecretName, Namespace: r.GetNamespace()}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("%s: %w", utils.ErrGetRHOKPAccessKeySecret, err)
+	}
+	return strings.TrimSpace(string(secret.Data[utils.RHOOKPAccessKeySecretKey])), nil
+}
+
+// warmUpPod runs the warm-up query against one RHOKP pod, it succeeds once the portal-rag core
+// returns documentation chunks
+func warmUpPod(ctx context.Context, httpClient *http.Client, baseURL, token string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+utils.RHOKPWarmUpPath, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("warm-up query returned HTTP %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Response struct {
+			NumFound int64 `json:"numFound"`
+		} `json:"response"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("invalid warm-up query response: %w", err)
+	}
+	if result.Response.NumFound == 0 {
+		return fmt.Errorf("warm-up query returned no documentation chunk")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package rhokp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+var _ = Describe("RHOKP warm-up gate", Ordered, func() {
+	var server *httptest.Server
+	var response string
+	var authorization string
+
+	BeforeAll(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			authorization = req.Header.Get("Authorization")
+			if req.URL.Path != "/solr/"+utils.RHOOKPSolrCollection+"/select" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(response))
+		}))
+	})
+
+	AfterAll(func() {
+		server.Close()
+	})
+
+	It("should succeed once the core returns documentation chunks", func() {
+		response = `{"response":{"numFound":42,"docs":[{}]}}`
+		Expect(warmUpPod(ctx, server.Client(), server.URL, "rotated-key")).To(Succeed())
+		Expect(authorization).To(Equal("Bearer rotated-key"))
+	})
+
+	It("should fail while the core is still empty", func() {
+		response = `{"response":{"numFound":0,"docs":[]}}`
+		Expect(warmUpPod(ctx, server.Client(), server.URL, "")).To(MatchError(ContainSubstring("no documentation chunk")))
+		Expect(authorization).To(BeEmpty())
+	})
+
+	It("should authenticate with the access key Secret whenever it exists", func() {
+		token, err := warmUpToken(testReconcilerInstance, ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(BeEmpty())
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: utils.RHOOKPAccessKeySecretName, Namespace: utils.OLSNamespaceDefault},
+			Data:       map[string][]byte{utils.RHOOKPAccessKeySecretKey: []byte("user-key\n")},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+		})
+		token, err = warmUpToken(testReconcilerInstance, ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("user-key"), "the key is read without an operator managed rotation")
+	})
+
+	It("should only poll the pods when the warm-up gate is enabled", func() {
+		testCR := cr.DeepCopy()
+		testCR.Spec.OLSConfig.ByokRAGOnly = false
+		testCR.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.WarmUpGate = false
+		Expect(WarmUpPending(testReconcilerInstance, ctx, testCR)).To(BeFalse())
+
+		testCR.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.WarmUpGate = true
+		Expect(WarmUpPending(testReconcilerInstance, ctx, testCR)).To(BeFalse(), "no RHOKP pod waits for its warm-up")
+	})
+
+	It("should only warm up running pods with ready containers", func() {
+		pod := &corev1.Pod{Status: corev1.PodStatus{
+			PodIP: "10.0.0.1",
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+			},
+		}}
+		Expect(needsWarmUp(pod)).To(BeTrue())
+
+		notReady := pod.DeepCopy()
+		notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+		Expect(needsWarmUp(notReady)).To(BeFalse())
+
+		warmedUp := pod.DeepCopy()
+		warmedUp.Status.Conditions = append(warmedUp.Status.Conditions, corev1.PodCondition{
+			Type: utils.RHOKPWarmUpConditionType, Status: corev1.ConditionTrue,
+		})
+		Expect(needsWarmUp(warmedUp)).To(BeFalse())
+
+		terminating := pod.DeepCopy()
+		terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		Expect(needsWarmUp(terminating)).To(BeFalse())
+	})
+})
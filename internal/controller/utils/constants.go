@@ -62,6 +62,8 @@ const (
 	APIKeyMountRoot = "/etc/apikeys" // #nosec G101
 	// CredentialsMountRoot is the directory hosting the credential files in the container
 	CredentialsMountRoot = "/etc/credentials"
+	// LLMProviderHeadersMountRoot is the directory hosting the LLM provider header secrets in the container
+	LLMProviderHeadersMountRoot = "/etc/llm/headers"
 	// OLSAppCertsMountRoot is the directory hosting the cert files in the container
 	OLSAppCertsMountRoot = "/etc/certs"
 	// OLSConfigMountRoot is the directory hosting the OLS configuration files in the container
@@ -94,6 +96,22 @@ const (
 	RAGVolumeName = "rag"
 	// RAGVolumeMountPath is the path of the volume hosting customized RAG content
 	RAGVolumeMountPath = "/rag-data"
+	// RAGEmbeddingModelDirSuffix suffixes the directory the local embedding model of a RAG source is copied to
+	RAGEmbeddingModelDirSuffix = "-embeddings"
+	// RAGIndexMetadataFile is the metadata file the RAG content tooling writes next to an index
+	RAGIndexMetadataFile = "metadata.json"
+	// RAGIndexStoreFile is the llama-index store file of an index, listing its index ID
+	RAGIndexStoreFile = "index_store.json"
+	// RAGSourceVolumePrefix prefixes the volume name of a RAG source mounted in place (PVC, OCI artifact, ConfigMap)
+	RAGSourceVolumePrefix = "rag-source-"
+	// RAGSourceMountRoot is the directory hosting the RAG sources mounted in place, one sub-directory per source
+	RAGSourceMountRoot = "/rag-sources"
+	// ImageVolumeMinOpenShiftMajor and ImageVolumeMinOpenShiftMinor are the first OpenShift release
+	// with image volumes enabled, older clusters copy OCI artifact RAG sources with an init container
+	ImageVolumeMinOpenShiftMajor = 4
+	ImageVolumeMinOpenShiftMinor = 20
+	// EmbeddingCredentialsMountRoot is the directory hosting the API keys of remote embedding endpoints in the container
+	EmbeddingCredentialsMountRoot = "/etc/embeddings" // #nosec G101
 	// OLSAppServerNetworkPolicyName is the name of the network policy for the OLS application server
 	OLSAppServerNetworkPolicyName = "lightspeed-app-server"
 	// FeatureGateMCPServer is the feature gate flag activating the MCP server
@@ -142,6 +160,8 @@ const (
 	GoogleVertexAnthropicType = "google_vertex_anthropic"
 	// BedrockType is the name of the AWS Bedrock provider type
 	BedrockType = "bedrock"
+	// RHOAIVLLMType is the name of the Red Hat OpenShift AI vLLM provider type
+	RHOAIVLLMType = "rhoai_vllm"
 	// DeploymentInProgress message
 	DeploymentInProgress = "In Progress"
 	// OLSSystemPromptFileName is the filename for the system prompt
@@ -202,6 +222,11 @@ const (
 	DefaultOpenShiftCerts = "kube-root-ca.crt"
 	// Force reload annotation key
 	ForceReloadAnnotationKey = "ols.openshift.io/force-reload"
+	/*** Credentials Expiry ***/
+	// CredentialsExpiryAnnotationKey is the Secret annotation declaring when its credentials expire (RFC 3339)
+	CredentialsExpiryAnnotationKey = "ols.openshift.io/credentials-expiry" // #nosec G101
+	// CredentialsExpiryWarningDaysDefault is the default number of days ahead of expiry to raise CredentialsExpiring
+	CredentialsExpiryWarningDaysDefault = 14
 	/*** Postgres Constants ***/
 	// PostgresCAVolume is the name of the OLS Postgres TLS ca certificate volume name
 	PostgresCAVolume = "cm-olspostgresca"
@@ -282,6 +307,8 @@ const (
 	AgenticConfigurationRHOKPEndpointKey = "rhokp-endpoint"
 	// AgenticConfigurationRHOKPCASecretKey is the ConfigMap data key naming the RHOKP client-CA Secret.
 	AgenticConfigurationRHOKPCASecretKey = "rhokp-ca-secret" // #nosec G101
+	// AgenticConfigurationRHOKPAccessKeySecretKey is the ConfigMap data key naming the operator-managed RHOKP access key Secret.
+	AgenticConfigurationRHOKPAccessKeySecretKey = "rhokp-access-key-secret" // #nosec G101
 	// AgenticConfigurationCertReloadAnnotation is bumped to force ConfigMap RV change
 	// when client CA Secrets rotate so agentic-operator reloads trust material.
 	AgenticConfigurationCertReloadAnnotation = "ols.openshift.io/client-ca-reload"
@@ -561,12 +588,15 @@ ssl_ca_file = '/etc/certs/cm-olspostgresca/service-ca.crt'
 	RHOOKPImageHTTPSPort      = 8443
 	RHOOKPContainerEntrypoint = "/usr/bin/container-entrypoint"
 	RHOOKPMainCommand         = "/usr/local/bin/mel"
-	// Solr hybrid defaults written to the OLS config file (not exposed on OLSConfig CR).
+	// Solr hybrid defaults written to the OLS config file, overridable in spec.ols.okp.
 	SolrHybridMaxResultsDefault         = 5
 	SolrHybridVectorBoostDefault        = 8.0
 	SolrHybridPoolDocsDefault           = 100
 	SolrHybridScoreThresholdDefault     = 0.0
 	SolrHybridSolrTimeoutSecondsDefault = 60.0
+	// Solr fields of the documentation chunks filtered by spec.ols.okp.documentationScopes
+	SolrProductVersionField = "product_version"
+	SolrProductField        = "product"
 	// MCP server timeout, sec
 	OpenShiftMCPServerTimeout = 60
 	// MCP server SSE read timeout, sec
@@ -633,6 +663,18 @@ ssl_ca_file = '/etc/certs/cm-olspostgresca/service-ca.crt'
 	OpenShiftMCPServerTLSSecretResourceVersionAnnotation = "ols.openshift.io/mcp-server-tls-secret-version" // #nosec G101
 	// RAGSpecHashAnnotation tracks the hash of .spec.ols.rag on the app-server Deployment.
 	RAGSpecHashAnnotation = "ols.openshift.io/rag-spec-hash"
+	// RAGBuildCompletionAnnotation tracks the completions of the RAG index builds feeding .spec.ols.rag on the app-server Deployment.
+	RAGBuildCompletionAnnotation = "ols.openshift.io/rag-build-completion"
+	// RAGImageRevisionAnnotation tracks the revision of the RAG images rolled out by the operator on the app-server Deployment.
+	RAGImageRevisionAnnotation = "ols.openshift.io/rag-image-revision"
+	// RAGUpdateApprovalAnnotation on the OLSConfig approves the pending RAG image revision of the Manual update policy.
+	RAGUpdateApprovalAnnotation = "ols.openshift.io/approve-rag-update"
+	// RAGCanaryDeploymentName is the Deployment running the canary replica of a RAG image update.
+	RAGCanaryDeploymentName = "lightspeed-app-server-rag-canary"
+	// RAGCanaryLabel marks the pods of the RAG canary Deployment.
+	RAGCanaryLabel = "ols.openshift.io/rag-canary"
+	// RAGUpdateWindowDurationDefault is the length of the RAG update windows when durationMinutes is unset.
+	RAGUpdateWindowDurationDefault = time.Hour
 
 	/*** Standalone RHOKP Constants ***/
 	// RHOKPDeploymentName is the Deployment name for the standalone RHOKP operand.
@@ -665,6 +707,219 @@ ssl_ca_file = '/etc/certs/cm-olspostgresca/service-ca.crt'
 	RHOKPServiceMonitorName = "lightspeed-rhokp-monitor"
 	// RHOKPMetricsPath is the Prometheus metrics endpoint path on the RHOKP Solr server.
 	RHOKPMetricsPath = "/solr/admin/metrics"
+	// RHOKPSolrDataPVCName is the PersistentVolumeClaim of the Solr data directory when spec.ols.deployment.rhokp.storage is set.
+	RHOKPSolrDataPVCName = "lightspeed-rhokp-solr-data"
+	// RHOKPWarmUpConditionType is the pod readiness gate set by the operator once a RHOKP pod answered the warm-up query.
+	RHOKPWarmUpConditionType = "ols.openshift.io/rhokp-warmed-up"
+	// RHOKPWarmUpPath is the warm-up query, it loads the portal-rag core and succeeds once documentation chunks are returned.
+	RHOKPWarmUpPath = "/solr/" + RHOOKPSolrCollection + "/select?q=openshift&rows=1&wt=json"
+	// RHOKPWarmUpTimeout bounds the warm-up query of a RHOKP pod.
+	RHOKPWarmUpTimeout = 30 * time.Second
+	// RHOKPWarmUpRecheckInterval is how often the RHOKP pods waiting for their warm-up gate are queried again.
+	RHOKPWarmUpRecheckInterval = 10 * time.Second
+	// RHOKPServiceCACertKey is the key of the service CA signing the RHOKP serving certificate in OLSCAConfigMap.
+	RHOKPServiceCACertKey = "service-ca.crt"
+	// RHOKPAccessKeyRotatedAtAnnotation records when the operator last rotated the RHOKP access key.
+	RHOKPAccessKeyRotatedAtAnnotation = "ols.openshift.io/rhokp-access-key-rotated-at" // #nosec G101
+	// RHOKPAccessKeyBytes is the number of random bytes of a generated RHOKP access key.
+	RHOKPAccessKeyBytes = 32
+	// RHOKPAccessKeyRotationIntervalDaysDefault is the RHOKP access key rotation interval when intervalDays is unset.
+	RHOKPAccessKeyRotationIntervalDaysDefault = 30
+	// RHOKPPodDisruptionBudgetName is the PodDisruptionBudget of the RHOKP pods when more than one replica runs.
+	RHOKPPodDisruptionBudgetName = "lightspeed-rhokp"
+	// RHOKPAntiAffinityWeight is the weight of the soft anti-affinity spreading RHOKP replicas across nodes.
+	RHOKPAntiAffinityWeight = 100
+
+	/*** RAG Index Build Constants ***/
+	// RAGBuildResourcePrefix prefixes the Job, CronJob and PersistentVolumeClaim names of a RAG index build
+	RAGBuildResourcePrefix = "lightspeed-rag-build-"
+	// RAGBuildScriptConfigMapName is the ConfigMap holding the index build script
+	RAGBuildScriptConfigMapName = "lightspeed-rag-builder"
+	// RAGBuildScriptKey is the key of the index build script in RAGBuildScriptConfigMapName
+	RAGBuildScriptKey = "build_index.py"
+	// RAGBuildComponentLabel is the app.kubernetes.io/component label value of the RAG index build resources.
+	RAGBuildComponentLabel = "rag-build"
+	// RAGBuildNameLabel carries the name of the RAG index build on its Jobs and CronJob
+	RAGBuildNameLabel = "ols.openshift.io/rag-build"
+	// RAGBuildSpecHashLabel carries the hash of the RAG index build spec its Jobs and CronJob were generated from
+	RAGBuildSpecHashLabel = "ols.openshift.io/rag-build-hash"
+	// RAGBuildSourcePath is the directory of the documents in the build container
+	RAGBuildSourcePath = "/rag-build/source"
+	// RAGBuildOutputPath is the mount path of the index PersistentVolumeClaim in the build container
+	RAGBuildOutputPath = "/rag-build/output"
+	// RAGBuildScriptPath is the mount path of the index build script in the build container
+	RAGBuildScriptPath = "/rag-build/builder"
+	// RAGBuildIndexDir is the directory of the index in the index PersistentVolumeClaim
+	RAGBuildIndexDir = "vector_db"
+	// RAGBuildDefaultPVCSize is the default size of the index PersistentVolumeClaim
+	RAGBuildDefaultPVCSize = "1Gi"
+	// RAGBuildBackoffLimit is the number of retries of a failed index build
+	RAGBuildBackoffLimit = 2
+	// RAGBuildRetryAnnotation carries the number of the retry of a failed index build on its Job
+	RAGBuildRetryAnnotation = "ols.openshift.io/rag-build-retry"
+	// RAGBuildRetryInterval is the delay before the first retry of a failed index build Job, doubled on each retry
+	RAGBuildRetryInterval = 5 * time.Minute
+	// RAGBuildMaxRetryInterval caps the delay between the retries of a failed index build Job
+	RAGBuildMaxRetryInterval = 6 * time.Hour
+	// AppServerEmbeddingModelPath is the embedding model of the app server image
+	AppServerEmbeddingModelPath = "/app-root/embeddings_model"
+
+	/*** Console Dashboard ***/
+	// ConsoleDashboardNamespace is the namespace the OpenShift console loads monitoring dashboards from
+	ConsoleDashboardNamespace = "openshift-config-managed"
+	// ConsoleDashboardConfigMapName is the name of the Lightspeed monitoring dashboard ConfigMap
+	ConsoleDashboardConfigMapName = "grafana-dashboard-lightspeed"
+	// ConsoleDashboardDataKey is the key holding the dashboard JSON in the ConfigMap
+	ConsoleDashboardDataKey = "lightspeed.json"
+	// ConsoleDashboardLabel marks a ConfigMap in ConsoleDashboardNamespace as a console dashboard
+	ConsoleDashboardLabel = "console.openshift.io/dashboard"
+
+	/*** LLM Provider Preflight ***/
+	// ProviderPreflightTimeoutSecondsDefault is the default timeout of a provider preflight request
+	ProviderPreflightTimeoutSecondsDefault = 10
+	// ProviderPreflightRecheckIntervalMinutesDefault is how long a successful preflight result is reused by default
+	ProviderPreflightRecheckIntervalMinutesDefault = 30
+	// ProviderPreflightFailureRecheckInterval is how long a failed preflight result is reused at most
+	ProviderPreflightFailureRecheckInterval = time.Minute
+	// ProviderPreflightErrorBodyMaxBytes bounds the response body quoted in status.providers[].lastError
+	ProviderPreflightErrorBodyMaxBytes = 256
+	// OpenAIDefaultURL is the API endpoint used by the openai provider type when no URL is set
+	OpenAIDefaultURL = "https://api.openai.com/v1"
+	// WatsonxDefaultURL is the API endpoint used by the watsonx provider type when no URL is set
+	WatsonxDefaultURL = "https://us-south.ml.cloud.ibm.com"
+	// AzureOpenAIPreflightAPIVersion is the Azure OpenAI API version used by the preflight when none is set
+	AzureOpenAIPreflightAPIVersion = "2024-10-21"
+
+	/*** LLM Provider Workload Identity ***/
+	// WorkloadIdentityVolumeName is the projected volume holding the ServiceAccount tokens of workload identity providers
+	WorkloadIdentityVolumeName = "workload-identity-token"
+	// WorkloadIdentityMountRoot is the directory hosting one token directory per workload identity provider
+	WorkloadIdentityMountRoot = "/var/run/secrets/workload-identity"
+	// WorkloadIdentityTokenFileName is the token file name inside the directory of a provider
+	WorkloadIdentityTokenFileName = "token"
+	// WorkloadIdentityTokenExpirationSeconds is the requested lifetime of the projected tokens; the kubelet rotates them at 80%
+	WorkloadIdentityTokenExpirationSeconds = 3600
+	// AWSWorkloadIdentityAudience is the default token audience for AssumeRoleWithWebIdentity
+	AWSWorkloadIdentityAudience = "sts.amazonaws.com"
+	// AzureWorkloadIdentityAudience is the default token audience for Entra ID workload identity federation
+	AzureWorkloadIdentityAudience = "api://AzureADTokenExchange"
+	// GCPWorkloadIdentityAudiencePrefix prefixes the workload identity pool provider name in the default GCP token audience
+	GCPWorkloadIdentityAudiencePrefix = "//iam.googleapis.com/"
+
+	/*** LLM Provider Secrets Store CSI Credentials ***/
+	// SecretsStoreCSIDriverName is the name of the Secrets Store CSI driver
+	SecretsStoreCSIDriverName = "secrets-store.csi.k8s.io"
+	// SecretsStoreGroup is the API group of SecretProviderClasses and SecretProviderClassPodStatuses
+	SecretsStoreGroup = "secrets-store.csi.x-k8s.io"
+	// SecretsStoreVersion is the API version of the Secrets Store CSI driver resources
+	SecretsStoreVersion = "v1"
+	// SecretProviderClassPodStatusKind is the kind the driver uses to record the object versions mounted in a pod
+	SecretProviderClassPodStatusKind = "SecretProviderClassPodStatus"
+	// SecretProviderClassVolumeAttribute is the CSI volume attribute naming the SecretProviderClass
+	SecretProviderClassVolumeAttribute = "secretProviderClass"
+	// CredentialsSourceVolumePrefix prefixes the app server CSI volume name of a provider
+	CredentialsSourceVolumePrefix = "csi-"
+	// CredentialsSourceMountRoot is the directory hosting the CSI credentials of each provider. Secret names
+	// cannot contain an underscore, so it never collides with the credentials secret directories.
+	CredentialsSourceMountRoot = APIKeyMountRoot + "/_csi"
+
+	/*** External Knowledge Portal ***/
+	// OKPExternalCACertKey is the key of the CA certificate in the ConfigMap referenced by spec.ols.okp.external.caConfigMapRef
+	OKPExternalCACertKey = "ca.crt"
+	// OKPCredentialsMountRoot is the directory hosting the external knowledge portal credentials in the container
+	OKPCredentialsMountRoot = "/etc/okp/credentials" // #nosec G101
+	// OKPExternalCheckTimeout is the timeout of the external knowledge portal health check
+	OKPExternalCheckTimeout = 10 * time.Second
+	// OKPExternalRecheckInterval is how often a reachable external knowledge portal is checked again
+	OKPExternalRecheckInterval = 5 * time.Minute
+
+	/*** KServe InferenceService Providers ***/
+	// InferenceServiceGroup is the API group of KServe InferenceServices
+	InferenceServiceGroup = "serving.kserve.io"
+	// InferenceServiceVersion is the API version of KServe InferenceServices
+	InferenceServiceVersion = "v1beta1"
+	// InferenceServiceKind is the kind of KServe InferenceServices
+	InferenceServiceKind = "InferenceService"
+	// InferenceServiceAPIPath is appended to the InferenceService address to reach the OpenAI compatible vLLM API
+	InferenceServiceAPIPath = "/v1"
+	// AppInferenceServiceCACertDir is the app-server mount directory for the service CA signing the InferenceService certificates
+	AppInferenceServiceCACertDir = "inference-service-ca"
+	// AppInferenceServiceCACertVolumeName is the app-server volume name for the InferenceService serving CA
+	AppInferenceServiceCACertVolumeName = "inference-service-ca"
+	// AppInferenceServiceCACertFile is the service CA key of OLSCAConfigMap and the filename within AppInferenceServiceCACertDir
+	AppInferenceServiceCACertFile = "service-ca.crt"
+
+	/*** Failure Diagnostics ***/
+	// DiagnosticsConfigMapName is the ConfigMap holding log tails and Events of failed components
+	DiagnosticsConfigMapName = "lightspeed-diagnostics"
+	// DiagnosticsIndexKey is the ConfigMap key listing the stored snapshots, newest first
+	DiagnosticsIndexKey = "index.json"
+	// DiagnosticsLogTailLines is the number of log lines captured per failing container
+	DiagnosticsLogTailLines = 200
+	// DiagnosticsLogMaxBytes bounds the log tail kept per snapshot; the end of the log is kept
+	DiagnosticsLogMaxBytes = 32 * 1024
+	// DiagnosticsMaxEvents is the number of most recent pod Events kept per snapshot
+	DiagnosticsMaxEvents = 20
+	// DiagnosticsMaxSnapshots is the number of snapshots kept before the oldest is rotated out
+	DiagnosticsMaxSnapshots = 10
+	// DiagnosticsMaxTotalBytes bounds the ConfigMap data, well below the 1MiB object size limit
+	DiagnosticsMaxTotalBytes = 512 * 1024
+
+	/*** Kubernetes Events ***/
+	// EventRecorderName is the reporting controller name set on Events emitted by the operator
+	EventRecorderName = "lightspeed-operator"
+	// EventReasonComponentEnabled is emitted when an optional operand transitions from Disabled to enabled
+	EventReasonComponentEnabled = "ComponentEnabled"
+	// EventReasonComponentDisabled is emitted when an optional operand transitions to Disabled
+	EventReasonComponentDisabled = "ComponentDisabled"
+	// EventReasonDeploymentRestarted is emitted when a watched Secret/ConfigMap change restarts a deployment
+	EventReasonDeploymentRestarted = "DeploymentRestarted"
+	// EventReasonDeploymentRestartFailed is emitted when a watcher-triggered restart fails
+	EventReasonDeploymentRestartFailed = "DeploymentRestartFailed"
+	// EventReasonCredentialsValidationFailed is emitted when LLM provider credentials fail validation
+	EventReasonCredentialsValidationFailed = "CredentialsValidationFailed"
+	// EventReasonProviderPreflightFailed is emitted when the connectivity preflight of an LLM provider starts failing
+	EventReasonProviderPreflightFailed = "ProviderPreflightFailed"
+	// EventReasonToolCallingUnsupported is emitted when tools are configured but the default model does not support tool calling
+	EventReasonToolCallingUnsupported = "ToolCallingUnsupported"
+	// EventReasonCredentialsExpiring is emitted when referenced credentials start to expire within the warning window
+	EventReasonCredentialsExpiring = "CredentialsExpiring"
+	// EventReasonTLSSecretValidationFailed is emitted when the custom TLS secret fails validation
+	EventReasonTLSSecretValidationFailed = "TLSSecretValidationFailed"
+	// EventReasonTLSSecretRotated is emitted when a watched TLS secret changes
+	EventReasonTLSSecretRotated = "TLSSecretRotated"
+	// EventReasonFinalizerAdded is emitted when the operator adds its finalizer to the OLSConfig CR
+	EventReasonFinalizerAdded = "FinalizerAdded"
+	// EventReasonFinalizing is emitted when cleanup of the OLSConfig CR starts
+	EventReasonFinalizing = "Finalizing"
+	// EventReasonCleanupFailed is emitted when an operand cannot be removed during finalization
+	EventReasonCleanupFailed = "CleanupFailed"
+	// EventReasonFinalized is emitted when cleanup completes and the finalizer is removed
+	EventReasonFinalized = "Finalized"
+	// EventActionReconcile is the Event action for changes made while reconciling the CR
+	EventActionReconcile = "Reconcile"
+	// EventActionValidate is the Event action for external resource validation
+	EventActionValidate = "Validate"
+	// EventActionRestart is the Event action for watcher-triggered restarts
+	EventActionRestart = "Restart"
+	// EventActionFinalize is the Event action for finalizer handling
+	EventActionFinalize = "Finalize"
+
+	/*** Alerting Rules ***/
+	// AlertingRuleGroupName is the PrometheusRule group holding the opt-in OLS alerts
+	AlertingRuleGroupName = "ols.alerts"
+	// AlertingRunbookBaseURLDefault is the default base URL of the alert runbooks
+	AlertingRunbookBaseURLDefault = "https://github.com/openshift/lightspeed-operator/blob/main/docs/runbooks/"
+	// AlertingStreamingQueryErrorPercentDefault is the default 5xx percentage on /v1/streaming_query that fires an alert
+	AlertingStreamingQueryErrorPercentDefault = 5
+	// AlertingTokenQuotaUsagePercentDefault is the default consumed token quota percentage that fires an alert
+	AlertingTokenQuotaUsagePercentDefault = 90
+	// AlertingForDefault is the default pending duration of the alerts
+	AlertingForDefault = "10m"
+	// AlertSeverityWarning is the severity label of alerts that need attention
+	AlertSeverityWarning = "warning"
+	// AlertSeverityCritical is the severity label of alerts for an unusable service
+	AlertSeverityCritical = "critical"
 
 	/*** Environment Variable Suffixes ***/
 	// EnvVarSuffixAPIKey is the environment variable suffix for API key credentials
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// credentialsExpiryJSONFields are the lower-cased top-level fields holding the RFC 3339 expiry of JSON credentials:
+// AWS credential_process output ("Expiration"), GCP generated access tokens ("expireTime") and OAuth2 tokens ("expiry")
+var credentialsExpiryJSONFields = map[string]bool{
+	"expiration":  true,
+	"expiry":      true,
+	"expires_at":  true,
+	"expiretime":  true,
+	"expire_time": true,
+}
+
+// CredentialsExpiry returns when the credentials of a Secret expire and where the expiry was read from.
+// The ols.openshift.io/credentials-expiry annotation takes precedence. Otherwise the earliest expiry found
+// in the Secret data is used: the NotAfter of PEM certificates (e.g. tls.crt or client certificates) and the
+// expiry fields of JSON credentials. Long-lived credentials such as API keys and service account JSON keys
+// carry no expiry; a zero time is returned for them.
+func CredentialsExpiry(secret *corev1.Secret) (time.Time, string, error) {
+	if value, ok := secret.Annotations[CredentialsExpiryAnnotationKey]; ok {
+		expiry, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+		if err != nil {
+			return time.Time{}, "", fmt.Errorf("invalid %s annotation of secret %s: %w", CredentialsExpiryAnnotationKey, secret.Name, err)
+		}
+		return expiry, "annotation " + CredentialsExpiryAnnotationKey, nil
+	}
+
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var earliest time.Time
+	source := ""
+	for _, key := range keys {
+		expiry := dataExpiry(secret.Data[key])
+		if !expiry.IsZero() && (earliest.IsZero() || expiry.Before(earliest)) {
+			earliest = expiry
+			source = "key " + key
+		}
+	}
+	return earliest, source, nil
+}
+
+// dataExpiry returns the earliest expiry of the certificates or JSON credentials in a Secret value,
+// a zero time when it has none
+func dataExpiry(data []byte) time.Time {
+	var earliest time.Time
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		if earliest.IsZero() || cert.NotAfter.Before(earliest) {
+			earliest = cert.NotAfter
+		}
+	}
+	if !earliest.IsZero() {
+		return earliest
+	}
+
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return time.Time{}
+	}
+	for name, value := range fields {
+		text, ok := value.(string)
+		if !ok || !credentialsExpiryJSONFields[strings.ToLower(name)] {
+			continue
+		}
+		expiry, err := time.Parse(time.RFC3339, text)
+		if err != nil {
+			continue
+		}
+		if earliest.IsZero() || expiry.Before(earliest) {
+			earliest = expiry
+		}
+	}
+	return earliest
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CredentialsExpiry", func() {
+	notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	certificatePEM := func(notAfter time.Time) []byte {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "lightspeed"},
+			NotBefore:    notAfter.Add(-24 * time.Hour),
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).NotTo(HaveOccurred())
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+
+	secret := func(annotations map[string]string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: OLSNamespaceDefault, Annotations: annotations},
+			Data:       data,
+		}
+	}
+
+	It("should prefer the expiry annotation", func() {
+		expiry, source, err := CredentialsExpiry(secret(
+			map[string]string{CredentialsExpiryAnnotationKey: "2029-06-30T00:00:00Z"},
+			map[string][]byte{"tls.crt": certificatePEM(notAfter)},
+		))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expiry).To(BeTemporally("==", time.Date(2029, 6, 30, 0, 0, 0, 0, time.UTC)))
+		Expect(source).To(Equal("annotation " + CredentialsExpiryAnnotationKey))
+
+		_, _, err = CredentialsExpiry(secret(map[string]string{CredentialsExpiryAnnotationKey: "next month"}, nil))
+		Expect(err).To(MatchError(ContainSubstring("invalid ols.openshift.io/credentials-expiry annotation of secret creds")))
+	})
+
+	It("should read the earliest expiry of certificates and JSON credentials", func() {
+		expiry, source, err := CredentialsExpiry(secret(nil, map[string][]byte{
+			"apitoken":    []byte("sk-long-lived"),
+			"client.crt":  certificatePEM(notAfter),
+			"credentials": []byte(`{"AccessKeyId":"AKIA","Expiration":"2029-12-31T23:00:00Z"}`),
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expiry).To(BeTemporally("==", time.Date(2029, 12, 31, 23, 0, 0, 0, time.UTC)))
+		Expect(source).To(Equal("key credentials"))
+
+		expiry, source, err = CredentialsExpiry(secret(nil, map[string][]byte{"tls.crt": certificatePEM(notAfter)}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expiry).To(BeTemporally("==", notAfter))
+		Expect(source).To(Equal("key tls.crt"))
+	})
+
+	It("should return a zero time for long-lived credentials", func() {
+		expiry, _, err := CredentialsExpiry(secret(nil, map[string][]byte{
+			"apitoken":         []byte("sk-long-lived"),
+			"credentials.json": []byte(`{"type":"service_account","project_id":"project","private_key_id":"abc"}`),
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expiry.IsZero()).To(BeTrue())
+	})
+})
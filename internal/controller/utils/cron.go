@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed five-field cron expression: minute, hour, day of month, month and day of week.
+// Each field is a bit set of the values it matches.
+type CronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// a day matches either day field when both are restricted, as in cron(8)
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+// cronSearchLimit bounds the search of the next matching time, schedules such as "0 0 30 2 *" never match
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// ParseCronSchedule parses a five-field cron expression. Fields accept *, numbers, ranges (1-5),
+// lists (1,3,5) and steps (*/15, 0-30/10). Day of week 0 and 7 are Sunday.
+func ParseCronSchedule(spec string) (*CronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron schedule %q, found %d", spec, len(fields))
+	}
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron schedule %q: %w", spec, err)
+		}
+		sets[i] = set
+	}
+	// Sunday is both 0 and 7
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return &CronSchedule{
+		minute:        sets[0],
+		hour:          sets[1],
+		dayOfMonth:    sets[2],
+		month:         sets[3],
+		dayOfWeek:     sets[4],
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField returns the bit set of the values matched by a comma separated list of cron ranges
+func parseCronField(field string, low, high int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+		}
+		first, last := low, high
+		if rangePart != "*" {
+			start, end, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if first, err = strconv.Atoi(start); err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			last = first
+			if isRange {
+				if last, err = strconv.Atoi(end); err != nil {
+					return 0, fmt.Errorf("invalid value %q", part)
+				}
+			} else if hasStep {
+				last = high
+			}
+		}
+		if first < low || last > high || first > last {
+			return 0, fmt.Errorf("%q is out of range %d-%d", part, low, high)
+		}
+		for v := first; v <= last; v += step {
+			set |= 1 << uint(v) // #nosec G115
+		}
+	}
+	return set, nil
+}
+
+// Matches checks if the minute of t, in UTC, matches the schedule
+func (s *CronSchedule) Matches(t time.Time) bool {
+	t = t.UTC()
+	return s.minute&(1<<uint(t.Minute())) != 0 && // #nosec G115
+		s.hour&(1<<uint(t.Hour())) != 0 && // #nosec G115
+		s.month&(1<<uint(t.Month())) != 0 && // #nosec G115
+		s.matchesDay(t)
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0   // #nosec G115
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0 // #nosec G115
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// Next returns the first minute strictly after t matching the schedule, in UTC.
+// It returns false when the schedule does not match within five years.
+func (s *CronSchedule) Next(t time.Time) (time.Time, bool) {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0: // #nosec G115
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<uint(t.Hour())) == 0: // #nosec G115
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<uint(t.Minute())) == 0: // #nosec G115
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// InWindow checks if t falls in a window of the given duration starting at a time matching the schedule
+func (s *CronSchedule) InWindow(t time.Time, duration time.Duration) bool {
+	start, ok := s.Next(t.Add(-duration))
+	return ok && !start.After(t)
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CronSchedule", func() {
+	// a Sunday
+	now := time.Date(2026, 10, 18, 10, 7, 30, 0, time.UTC)
+
+	next := func(spec string) time.Time {
+		schedule, err := ParseCronSchedule(spec)
+		Expect(err).NotTo(HaveOccurred())
+		t, ok := schedule.Next(now)
+		Expect(ok).To(BeTrue())
+		return t
+	}
+
+	It("should find the next matching minute", func() {
+		Expect(next("0 2 * * 6")).To(Equal(time.Date(2026, 10, 24, 2, 0, 0, 0, time.UTC)))
+		Expect(next("*/15 * * * *")).To(Equal(time.Date(2026, 10, 18, 10, 15, 0, 0, time.UTC)))
+		Expect(next("30 8-18/2 * * 1-5")).To(Equal(time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)))
+		Expect(next("0 0 1 */3 *")).To(Equal(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)))
+		Expect(next("0 0 * * 7")).To(Equal(time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)), "7 is Sunday")
+	})
+
+	It("should match either restricted day field", func() {
+		Expect(next("0 3 1,15 * 1")).To(Equal(time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)))
+		Expect(next("0 3 1,15 * *")).To(Equal(time.Date(2026, 11, 1, 3, 0, 0, 0, time.UTC)))
+	})
+
+	It("should not find a time for a schedule that never matches", func() {
+		schedule, err := ParseCronSchedule("0 0 30 2 *")
+		Expect(err).NotTo(HaveOccurred())
+		_, ok := schedule.Next(now)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should reject invalid schedules", func() {
+		for _, spec := range []string{"0 2 * *", "x * * * *", "0 24 * * *", "5-1 * * * *", "*/0 * * * *", "0 0 0 * *"} {
+			_, err := ParseCronSchedule(spec)
+			Expect(err).To(HaveOccurred(), spec)
+		}
+	})
+
+	It("should tell whether a time falls in a window", func() {
+		schedule, err := ParseCronSchedule("0 2 * * 6")
+		Expect(err).NotTo(HaveOccurred())
+		start := time.Date(2026, 10, 24, 2, 0, 0, 0, time.UTC)
+		Expect(schedule.InWindow(start, time.Hour)).To(BeTrue())
+		Expect(schedule.InWindow(start.Add(59*time.Minute), time.Hour)).To(BeTrue())
+		Expect(schedule.InWindow(start.Add(time.Hour), time.Hour)).To(BeFalse())
+		Expect(schedule.InWindow(start.Add(-time.Second), time.Hour)).To(BeFalse())
+	})
+})
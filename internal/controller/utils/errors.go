@@ -50,6 +50,7 @@ const (
 	ErrGeneratePrometheusRule              = "failed to generate PrometheusRule"
 	ErrGetAdditionalCACM                   = "failed to get additional CA configmap"
 	ErrGetProxyCACM                        = "failed to get proxy CA configmap"
+	ErrGetServiceCACM                      = "failed to get service CA configmap"
 	ErrGeneratePostgresSecret              = "failed to generate OLS Postgres secret"
 	ErrGeneratePostgresBootstrapSecret     = "failed to generate OLS Postgres bootstrap secret"
 	ErrGeneratePostgresConfigMap           = "failed to generate OLS Postgres configmap"
@@ -80,6 +81,9 @@ const (
 	ErrGetServiceMonitor                   = "failed to get ServiceMonitor"
 	ErrGetMetricsReaderSecret              = "failed to get metrics reader secret"
 	ErrGetPrometheusRule                   = "failed to get PrometheusRule"
+	ErrGetRAGImageStream                   = "failed to get RAG ImageStream"
+	ErrRAGOCIArtifactUnsupported           = "OCI artifact RAG sources are mounted as image volumes, not supported by the cluster"
+	ErrListAppServerPods                   = "failed to list app server pods"
 	ErrUpdateAPIConfigmap                  = "failed to update OLS configmap"
 	ErrUpdateAPIDeployment                 = "failed to update OLS deployment"
 	ErrUpdateAPIService                    = "failed to update OLS service"
@@ -247,10 +251,74 @@ const (
 	ErrGetRHOKPTLSSecret                    = "failed to get RHOKP TLS secret" // #nosec G101
 	ErrGenerateRHOKPServiceMonitor          = "failed to generate RHOKP ServiceMonitor"
 	ErrSetRHOKPServiceMonitorOwnerReference = "failed to set RHOKP ServiceMonitor owner reference"
+	ErrGenerateRHOKPSolrDataPVC             = "failed to generate RHOKP Solr data PVC"
+	ErrCreateRHOKPSolrDataPVC               = "failed to create RHOKP Solr data PVC"
+	ErrGetRHOKPSolrDataPVC                  = "failed to get RHOKP Solr data PVC"
+	ErrDeleteRHOKPSolrDataPVC               = "failed to delete RHOKP Solr data PVC"
+	ErrListRHOKPPods                        = "failed to list RHOKP pods"
+	ErrWarmUpRHOKPPod                       = "failed to warm up RHOKP pod"
+	ErrUpdateRHOKPPodStatus                 = "failed to update RHOKP pod status"
+	ErrGetRHOKPAccessKeySecret              = "failed to get RHOKP access key secret"    // #nosec G101
+	ErrCreateRHOKPAccessKeySecret           = "failed to create RHOKP access key secret" // #nosec G101
+	ErrUpdateRHOKPAccessKeySecret           = "failed to update RHOKP access key secret" // #nosec G101
+	ErrGenerateRHOKPAccessKey               = "failed to generate RHOKP access key"      // #nosec G101
+	ErrGenerateRHOKPPodDisruptionBudget     = "failed to generate RHOKP PodDisruptionBudget"
+	ErrCreateRHOKPPodDisruptionBudget       = "failed to create RHOKP PodDisruptionBudget"
+	ErrGetRHOKPPodDisruptionBudget          = "failed to get RHOKP PodDisruptionBudget"
+	ErrUpdateRHOKPPodDisruptionBudget       = "failed to update RHOKP PodDisruptionBudget"
+	ErrDeleteRHOKPPodDisruptionBudget       = "failed to delete RHOKP PodDisruptionBudget"
+
+	/*** Console Dashboard Errors ***/
+	ErrGenerateConsoleDashboard = "failed to generate console dashboard configmap"
+	ErrCreateConsoleDashboard   = "failed to create console dashboard configmap"
+	ErrGetConsoleDashboard      = "failed to get console dashboard configmap"
+	ErrUpdateConsoleDashboard   = "failed to update console dashboard configmap"
+	ErrDeleteConsoleDashboard   = "failed to delete console dashboard configmap"
+
+	/*** Failure Diagnostics Errors ***/
+	ErrCreateDiagnosticsConfigMap = "failed to create diagnostics configmap"
+	ErrGetDiagnosticsConfigMap    = "failed to get diagnostics configmap"
+	ErrUpdateDiagnosticsConfigMap = "failed to update diagnostics configmap"
+
+	/*** KServe InferenceService Errors ***/
+	ErrGetInferenceService   = "failed to get InferenceService"
+	ErrListInferenceServices = "failed to list InferenceServices"
 
 	// Cleanup error constants for conditional operand removal.
 	ErrRemoveOpenShiftMCPServerResources = "failed to remove openshift-mcp-server resources"
 	ErrRemoveRHOKPResources              = "failed to remove RHOKP resources"
 	ErrRemoveAgenticConsoleUIResources   = "failed to remove agentic console UI resources"
 	ErrRemoveAlertsAdapterResources      = "failed to remove alerts adapter resources"
+
+	/*** External Knowledge Portal Errors ***/
+	ErrGetOKPExternalCAConfigMap       = "failed to get external knowledge portal CA configmap"
+	ErrGetOKPExternalCredentialsSecret = "failed to get external knowledge portal credentials secret"
+	ErrCheckOKPExternal                = "external knowledge portal health check failed"
+
+	/*** RAG Index Build Errors ***/
+	ErrReconcileRAGBuildScript = "failed to reconcile RAG index build script configmap"
+	ErrGenerateRAGBuildPVC     = "failed to generate RAG index build PVC"
+	ErrCreateRAGBuildPVC       = "failed to create RAG index build PVC"
+	ErrGetRAGBuildPVC          = "failed to get RAG index build PVC"
+	ErrGenerateRAGBuildJob     = "failed to generate RAG index build job"
+	ErrCreateRAGBuildJob       = "failed to create RAG index build job"
+	ErrGetRAGBuildJob          = "failed to get RAG index build job"
+	ErrListRAGBuildJobs        = "failed to list RAG index build jobs"
+	ErrDeleteRAGBuildJob       = "failed to delete RAG index build job"
+	ErrGenerateRAGBuildCronJob = "failed to generate RAG index build cronjob"
+	ErrCreateRAGBuildCronJob   = "failed to create RAG index build cronjob"
+	ErrGetRAGBuildCronJob      = "failed to get RAG index build cronjob"
+	ErrUpdateRAGBuildCronJob   = "failed to update RAG index build cronjob"
+	ErrListRAGBuildCronJobs    = "failed to list RAG index build cronjobs"
+	ErrDeleteRAGBuildCronJob   = "failed to delete RAG index build cronjob"
+	ErrRemoveRAGBuildResources = "failed to remove RAG index build resources"
+
+	/*** RAG Image Update Errors ***/
+	ErrPlanRAGUpdate                = "failed to evaluate RAG image update"
+	ErrGenerateRAGCanaryDeployment  = "failed to generate RAG canary deployment"
+	ErrCreateRAGCanaryDeployment    = "failed to create RAG canary deployment"
+	ErrGetRAGCanaryDeployment       = "failed to get RAG canary deployment"
+	ErrUpdateRAGCanaryDeployment    = "failed to update RAG canary deployment"
+	ErrDeleteRAGCanaryDeployment    = "failed to delete RAG canary deployment"
+	ErrParseRAGUpdateWindowSchedule = "invalid RAG update window schedule"
 )
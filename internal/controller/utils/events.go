@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+)
+
+// RecordEvent emits a Kubernetes Event regarding obj using the reconciler's event recorder.
+// It is a no-op when the reconciler has no recorder (unit tests, local runs without a manager).
+func RecordEvent(r reconciler.Reconciler, obj runtime.Object, eventType, reason, action, note string, args ...interface{}) {
+	recorder := r.GetEventRecorder()
+	if recorder == nil || obj == nil {
+		return
+	}
+	recorder.Eventf(obj, nil, eventType, reason, action, note, args...)
+}
+
+// RecordOLSConfigEvent emits a Kubernetes Event regarding the cluster OLSConfig CR.
+// It is meant for code paths that run outside Reconcile (e.g. watchers) and do not hold the CR;
+// the CR is fetched so that the Event carries its UID and shows up in `oc describe olsconfig`.
+// Failures to fetch the CR are logged at debug level and the Event is dropped.
+func RecordOLSConfigEvent(r reconciler.Reconciler, ctx context.Context, eventType, reason, action, note string, args ...interface{}) {
+	if r.GetEventRecorder() == nil {
+		return
+	}
+	cr := &olsv1alpha1.OLSConfig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: OLSConfigName}, cr); err != nil {
+		r.GetLogger().V(1).Info("skipping event, failed to get OLSConfig CR", "reason", reason, "error", err)
+		return
+	}
+	RecordEvent(r, cr, eventType, reason, action, note, args...)
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+)
+
+// InferenceServiceGVK is the GroupVersionKind of KServe InferenceServices
+var InferenceServiceGVK = schema.GroupVersionKind{
+	Group:   InferenceServiceGroup,
+	Version: InferenceServiceVersion,
+	Kind:    InferenceServiceKind,
+}
+
+// InferenceServiceEndpoint is the in-cluster endpoint of a ready KServe InferenceService
+type InferenceServiceEndpoint struct {
+	Name      string
+	Namespace string
+	// URL is the base URL of the OpenAI compatible API served by the InferenceService
+	URL string
+}
+
+// NewInferenceService returns an empty InferenceService object, used to watch and read
+// InferenceServices without depending on the KServe API module.
+func NewInferenceService() *unstructured.Unstructured {
+	isvc := &unstructured.Unstructured{}
+	isvc.SetGroupVersionKind(InferenceServiceGVK)
+	return isvc
+}
+
+// IsKServeAvailable checks if the KServe InferenceService CRD is available on the cluster.
+func IsKServeAvailable(ctx context.Context, c client.Client) bool {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(InferenceServiceGVK.GroupVersion().WithKind(InferenceServiceKind + "List"))
+	return c.List(ctx, list, &client.ListOptions{Limit: 1}) == nil
+}
+
+// InferenceServiceURL returns the base URL of the OpenAI compatible API of an InferenceService.
+// The cluster-local address is preferred over the externally exposed URL.
+// It returns an empty string until KServe has published an address.
+func InferenceServiceURL(isvc *unstructured.Unstructured) string {
+	address, _, _ := unstructured.NestedString(isvc.Object, "status", "address", "url")
+	if address == "" {
+		address, _, _ = unstructured.NestedString(isvc.Object, "status", "url")
+	}
+	if address == "" {
+		return ""
+	}
+	return strings.TrimSuffix(address, "/") + InferenceServiceAPIPath
+}
+
+// HasInferenceServiceProviders checks if any LLM provider references KServe InferenceServices
+func HasInferenceServiceProviders(cr *olsv1alpha1.OLSConfig) bool {
+	for _, provider := range cr.Spec.LLMConfig.Providers {
+		if provider.InferenceServiceRef != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveInferenceServices returns the endpoints of the InferenceServices referenced by a provider,
+// sorted by namespace and name. It fails when a referenced InferenceService has no address yet
+// or when no ready InferenceService matches the selector.
+func ResolveInferenceServices(r reconciler.Reconciler, ctx context.Context, provider olsv1alpha1.ProviderSpec) ([]InferenceServiceEndpoint, error) {
+	ref := provider.InferenceServiceRef
+	if ref == nil {
+		return nil, nil
+	}
+
+	if ref.Name != "" {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = r.GetNamespace()
+		}
+		isvc := NewInferenceService()
+		if err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, isvc); err != nil {
+			return nil, fmt.Errorf("%s %s/%s of provider %s: %w", ErrGetInferenceService, namespace, ref.Name, provider.Name, err)
+		}
+		url := InferenceServiceURL(isvc)
+		if url == "" {
+			return nil, fmt.Errorf("InferenceService %s/%s of provider %s has no address yet", namespace, ref.Name, provider.Name)
+		}
+		return []InferenceServiceEndpoint{{Name: ref.Name, Namespace: namespace, URL: url}}, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(ref.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid InferenceService selector of provider %s: %w", provider.Name, err)
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(InferenceServiceGVK.GroupVersion().WithKind(InferenceServiceKind + "List"))
+	opts := []client.ListOption{client.MatchingLabelsSelector{Selector: selector}}
+	if ref.Namespace != "" {
+		opts = append(opts, client.InNamespace(ref.Namespace))
+	}
+	if err := r.List(ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("%s of provider %s: %w", ErrListInferenceServices, provider.Name, err)
+	}
+
+	endpoints := []InferenceServiceEndpoint{}
+	for i := range list.Items {
+		url := InferenceServiceURL(&list.Items[i])
+		if url == "" {
+			continue
+		}
+		endpoints = append(endpoints, InferenceServiceEndpoint{
+			Name:      list.Items[i].GetName(),
+			Namespace: list.Items[i].GetNamespace(),
+			URL:       url,
+		})
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no ready InferenceService matches the selector of provider %s", provider.Name)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Namespace != endpoints[j].Namespace {
+			return endpoints[i].Namespace < endpoints[j].Namespace
+		}
+		return endpoints[i].Name < endpoints[j].Name
+	})
+	return endpoints, nil
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+)
+
+var _ = Describe("InferenceService discovery", func() {
+	var r *TestReconciler
+
+	inferenceService := func(name, namespace, address string, labels map[string]string) *unstructured.Unstructured {
+		isvc := NewInferenceService()
+		isvc.SetName(name)
+		isvc.SetNamespace(namespace)
+		isvc.SetLabels(labels)
+		if address != "" {
+			Expect(unstructured.SetNestedField(isvc.Object, address, "status", "address", "url")).To(Succeed())
+		}
+		return isvc
+	}
+
+	provider := func(ref *olsv1alpha1.InferenceServiceReference) olsv1alpha1.ProviderSpec {
+		return olsv1alpha1.ProviderSpec{Name: "vllm", Type: RHOAIVLLMType, InferenceServiceRef: ref}
+	}
+
+	BeforeEach(func() {
+		sch := runtime.NewScheme()
+		sch.AddKnownTypeWithName(InferenceServiceGVK, &unstructured.Unstructured{})
+		sch.AddKnownTypeWithName(InferenceServiceGVK.GroupVersion().WithKind(InferenceServiceKind+"List"), &unstructured.UnstructuredList{})
+		labels := map[string]string{"lightspeed": "true"}
+		k8s := fake.NewClientBuilder().WithScheme(sch).WithObjects(
+			inferenceService("granite", OLSNamespaceDefault, "https://granite-predictor.openshift-lightspeed.svc.cluster.local/", nil),
+			inferenceService("mistral", "team-b", "https://mistral-predictor.team-b.svc.cluster.local", labels),
+			inferenceService("llama", "team-a", "https://llama-predictor.team-a.svc.cluster.local", labels),
+			inferenceService("pending", "team-a", "", labels),
+		).Build()
+		r = NewTestReconciler(k8s, logf.Log.WithName("isvc-test"), sch, OLSNamespaceDefault)
+	})
+
+	It("should resolve an InferenceService by name in the operator namespace", func() {
+		endpoints, err := ResolveInferenceServices(r, context.Background(), provider(&olsv1alpha1.InferenceServiceReference{Name: "granite"}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(endpoints).To(Equal([]InferenceServiceEndpoint{{
+			Name:      "granite",
+			Namespace: OLSNamespaceDefault,
+			URL:       "https://granite-predictor.openshift-lightspeed.svc.cluster.local/v1",
+		}}))
+	})
+
+	It("should fail when the InferenceService has no address yet", func() {
+		_, err := ResolveInferenceServices(r, context.Background(), provider(&olsv1alpha1.InferenceServiceReference{Name: "pending", Namespace: "team-a"}))
+		Expect(err).To(MatchError(ContainSubstring("InferenceService team-a/pending of provider vllm has no address yet")))
+
+		_, err = ResolveInferenceServices(r, context.Background(), provider(&olsv1alpha1.InferenceServiceReference{Name: "missing"}))
+		Expect(err).To(MatchError(ContainSubstring(ErrGetInferenceService)))
+	})
+
+	It("should resolve the ready InferenceServices matching a selector across namespaces", func() {
+		selector := &metav1.LabelSelector{MatchLabels: map[string]string{"lightspeed": "true"}}
+		endpoints, err := ResolveInferenceServices(r, context.Background(), provider(&olsv1alpha1.InferenceServiceReference{Selector: selector}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(endpoints).To(Equal([]InferenceServiceEndpoint{
+			{Name: "llama", Namespace: "team-a", URL: "https://llama-predictor.team-a.svc.cluster.local/v1"},
+			{Name: "mistral", Namespace: "team-b", URL: "https://mistral-predictor.team-b.svc.cluster.local/v1"},
+		}))
+
+		endpoints, err = ResolveInferenceServices(r, context.Background(), provider(&olsv1alpha1.InferenceServiceReference{Selector: selector, Namespace: "team-b"}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(endpoints).To(HaveLen(1))
+		Expect(endpoints[0].Name).To(Equal("mistral"))
+
+		selector = &metav1.LabelSelector{MatchLabels: map[string]string{"lightspeed": "none"}}
+		_, err = ResolveInferenceServices(r, context.Background(), provider(&olsv1alpha1.InferenceServiceReference{Selector: selector}))
+		Expect(err).To(MatchError(ContainSubstring("no ready InferenceService matches the selector of provider vllm")))
+	})
+
+	It("should fall back to the external URL", func() {
+		isvc := NewInferenceService()
+		Expect(InferenceServiceURL(isvc)).To(BeEmpty())
+		Expect(unstructured.SetNestedField(isvc.Object, "https://granite.apps.example.com", "status", "url")).To(Succeed())
+		Expect(InferenceServiceURL(isvc)).To(Equal("https://granite.apps.example.com/v1"))
+	})
+})
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+)
+
+// SecretProviderClassPodStatusGVK is the GroupVersionKind of Secrets Store CSI SecretProviderClassPodStatuses
+var SecretProviderClassPodStatusGVK = schema.GroupVersionKind{
+	Group:   SecretsStoreGroup,
+	Version: SecretsStoreVersion,
+	Kind:    SecretProviderClassPodStatusKind,
+}
+
+// NewSecretProviderClassPodStatus returns an empty SecretProviderClassPodStatus object, used to watch
+// the secrets mounted by the Secrets Store CSI driver without depending on its API module.
+func NewSecretProviderClassPodStatus() *unstructured.Unstructured {
+	status := &unstructured.Unstructured{}
+	status.SetGroupVersionKind(SecretProviderClassPodStatusGVK)
+	return status
+}
+
+// IsSecretsStoreCSIAvailable checks if the Secrets Store CSI driver CRDs are available on the cluster.
+func IsSecretsStoreCSIAvailable(ctx context.Context, c client.Client) bool {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(SecretProviderClassPodStatusGVK.GroupVersion().WithKind(SecretProviderClassPodStatusKind + "List"))
+	return c.List(ctx, list, &client.ListOptions{Limit: 1}) == nil
+}
+
+// IsSecretProviderClassReferencedInCR checks if an LLM provider mounts its credentials from a SecretProviderClass
+func IsSecretProviderClassReferencedInCR(cr *olsv1alpha1.OLSConfig, name string) bool {
+	for _, provider := range cr.Spec.LLMConfig.Providers {
+		if provider.CredentialsSource != nil && provider.CredentialsSource.SecretProviderClass == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretProviderClassPodStatusInfo returns the SecretProviderClass and the pod of a SecretProviderClassPodStatus
+// and a hash of the versions of the mounted objects, which the driver updates when it rotates them.
+func SecretProviderClassPodStatusInfo(status *unstructured.Unstructured) (secretProviderClass string, podName string, versionsHash string, err error) {
+	secretProviderClass, _, _ = unstructured.NestedString(status.Object, "status", "secretProviderClassName")
+	podName, _, _ = unstructured.NestedString(status.Object, "status", "podName")
+	objects, _, err := unstructured.NestedSlice(status.Object, "status", "objects")
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid objects of SecretProviderClassPodStatus %s: %w", status.GetName(), err)
+	}
+	versions := make([]string, 0, len(objects))
+	for _, object := range objects {
+		fields, ok := object.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _, _ := unstructured.NestedString(fields, "id")
+		version, _, _ := unstructured.NestedString(fields, "version")
+		versions = append(versions, id+"="+version)
+	}
+	sort.Strings(versions)
+	hash := sha256.New()
+	for _, version := range versions {
+		hash.Write([]byte(version + "\n"))
+	}
+	return secretProviderClass, podName, hex.EncodeToString(hash.Sum(nil)), nil
+}
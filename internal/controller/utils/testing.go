@@ -5,6 +5,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
@@ -31,6 +32,7 @@ type TestReconciler struct {
 	openShiftMajor      string
 	openShiftMinor      string
 	PrometheusAvailable bool
+	EventRecorder       events.EventRecorder
 	watcherConfig       interface{}
 }
 
@@ -106,6 +108,10 @@ func (r *TestReconciler) GetWatcherConfig() interface{} {
 	return r.watcherConfig
 }
 
+func (r *TestReconciler) GetEventRecorder() events.EventRecorder {
+	return r.EventRecorder
+}
+
 func (r *TestReconciler) SetWatcherConfig(config interface{}) {
 	r.watcherConfig = config
 }
@@ -114,6 +120,11 @@ func (r *TestReconciler) SetRosaOKPProductEnv(env *corev1.EnvVar) {
 	r.rosaOKPProductEnv = env
 }
 
+func (r *TestReconciler) SetOpenShiftVersion(major, minor string) {
+	r.openShiftMajor = major
+	r.openShiftMinor = minor
+}
+
 // NewTestReconciler creates a new TestReconciler instance with the provided parameters
 func NewTestReconciler(
 	client client.Client,
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
+)
+
+// TrustConfig holds the transport settings of the requests the operator sends itself to the
+// services configured in the OLSConfig CR, such as the LLM provider preflight and the external
+// knowledge portal check
+type TrustConfig struct {
+	// RootCAs are the system roots extended with the CAs of the CR
+	RootCAs *x509.CertPool
+	// ProxyURL is spec.ols.proxyConfig.proxyURL, the cluster proxy from the operator environment is used when empty
+	ProxyURL string
+	// Fingerprint identifies the proxy URL and the trusted certificates, for the callers caching their results
+	Fingerprint string
+}
+
+// TrustedCAConfigMap is a ConfigMap key holding CA certificates to trust in addition to the ones of
+// spec.ols.additionalCAConfigMapRef and spec.ols.proxyConfig
+type TrustedCAConfigMap struct {
+	Name string
+	Key  string
+	// Err prefixes the error returned when the ConfigMap cannot be read
+	Err string
+}
+
+// LoadTrustConfig reads the proxy settings and the CA certificates trusted in addition to the system roots:
+// the extra ConfigMaps, spec.ols.additionalCAConfigMapRef and the proxy CA.
+func LoadTrustConfig(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig, extraCAs ...TrustedCAConfigMap) (TrustConfig, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	trust := TrustConfig{RootCAs: pool}
+	hash := sha256.New()
+	addCerts := func(certData string) {
+		pool.AppendCertsFromPEM([]byte(certData))
+		hash.Write([]byte(certData))
+	}
+
+	for _, ca := range extraCAs {
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Name: ca.Name, Namespace: r.GetNamespace()}, cm); err != nil {
+			return trust, fmt.Errorf("%s: %w", ca.Err, err)
+		}
+		addCerts(cm.Data[ca.Key])
+	}
+	if ref := cr.Spec.OLSConfig.AdditionalCAConfigMapRef; ref != nil && ref.Name != "" {
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: r.GetNamespace()}, cm); err != nil {
+			return trust, fmt.Errorf("%s: %w", ErrGetAdditionalCACM, err)
+		}
+		for _, key := range slices.Sorted(maps.Keys(cm.Data)) {
+			addCerts(cm.Data[key])
+		}
+	}
+	if proxy := cr.Spec.OLSConfig.ProxyConfig; proxy != nil {
+		trust.ProxyURL = proxy.ProxyURL
+		hash.Write([]byte(proxy.ProxyURL))
+		if cmName := GetProxyCACertConfigMapName(proxy.ProxyCACertificateRef); cmName != "" {
+			cm := &corev1.ConfigMap{}
+			if err := r.Get(ctx, client.ObjectKey{Name: cmName, Namespace: r.GetNamespace()}, cm); err != nil {
+				return trust, fmt.Errorf("%s: %w", ErrGetProxyCACM, err)
+			}
+			addCerts(cm.Data[GetProxyCACertKey(proxy.ProxyCACertificateRef)])
+		}
+	}
+	trust.Fingerprint = hex.EncodeToString(hash.Sum(nil))
+	return trust, nil
+}
+
+// NewHTTPClient returns a client sending its requests through the proxy of the trust config, the
+// cluster proxy from the operator environment without one, and trusting its root CAs. tlsConfig
+// sets the TLS versions and ciphers, its RootCAs are replaced.
+func NewHTTPClient(trust TrustConfig, tlsConfig *tls.Config, timeout time.Duration) (*http.Client, error) {
+	tlsConfig.RootCAs = trust.RootCAs
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+	}
+	if trust.ProxyURL != "" {
+		proxyURL, err := url.Parse(trust.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %s: %w", trust.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
@@ -21,6 +21,14 @@ const (
 	TypeMCPServerReady            = "MCPServerReady"
 	TypeRHOKPReady                = "RHOKPReady"
 	TypeCRReconciled              = "Reconciled"
+	TypeToolCallingSupported      = "ToolCallingSupported"
+	TypeCredentialsExpiring       = "CredentialsExpiring"
+)
+
+// Reasons of the RHOKPReady condition when spec.ols.okp.external replaces the RHOKP deployment
+const (
+	ReasonOKPExternal            = "External"
+	ReasonOKPExternalUnreachable = "ExternalUnreachable"
 )
 
 type OLSConfigReconcilerOptions struct {
@@ -39,6 +47,8 @@ type OLSConfigReconcilerOptions struct {
 	RosaOKPProductEnv              *corev1.EnvVar
 	Namespace                      string
 	PrometheusAvailable            bool
+	KServeAvailable                bool
+	SecretsStoreCSIAvailable       bool
 }
 
 // SystemSecret represents a secret managed by Kubernetes or other applications
@@ -145,6 +155,34 @@ type ProviderConfig struct {
 	GoogleVertexConfig *GoogleVertexConfig `json:"google_vertex_config,omitempty"`
 	// Google Vertex Anthropic Config
 	GoogleVertexAnthropicConfig *GoogleVertexAnthropicConfig `json:"google_vertex_anthropic_config,omitempty"`
+	// AWS Bedrock Config
+	BedrockConfig *BedrockConfig `json:"bedrock_config,omitempty"`
+	// Workload identity federation replacing secret credentials
+	WorkloadIdentity *WorkloadIdentityConfig `json:"workload_identity,omitempty"`
+	// Extra HTTP headers sent with every request to the provider
+	Headers []ProviderHeaderConfig `json:"headers,omitempty"`
+	// Request timeout in seconds
+	Timeout int32 `json:"timeout,omitempty"`
+	// Retry policy for failed requests
+	Retries *RetryConfig `json:"retries,omitempty"`
+}
+
+type ProviderHeaderConfig struct {
+	// Header name
+	Name string `json:"name"`
+	// Literal header value
+	Value string `json:"value,omitempty"`
+	// Path to the file containing the header value in the app server container
+	ValuePath string `json:"value_path,omitempty"`
+}
+
+type RetryConfig struct {
+	// Maximum number of retries of a failed request
+	MaxRetries *int32 `json:"max_retries,omitempty"`
+	// Delay before the first retry in seconds
+	BackoffSeconds int32 `json:"backoff_seconds,omitempty"`
+	// Upper bound of the delay between retries in seconds
+	MaxBackoffSeconds int32 `json:"max_backoff_seconds,omitempty"`
 }
 
 type FakeProviderConfig struct {
@@ -166,11 +204,39 @@ type AzureOpenAIConfig struct {
 	// Azure OpenAI API URL
 	URL string `json:"url,omitempty"`
 	// Path where Azure OpenAI accesstoken or credentials are stored
-	CredentialsPath string `json:"credentials_path"`
+	CredentialsPath string `json:"credentials_path,omitempty"`
 	// Azure deployment name
 	AzureDeploymentName string `json:"deployment_name,omitempty"`
 }
 
+type WorkloadIdentityConfig struct {
+	// Path of the projected ServiceAccount token in the app server container
+	TokenPath string `json:"token_path"`
+	// Audience the token was issued for
+	Audience string `json:"audience"`
+	// Entra ID client ID (azure_openai)
+	ClientID string `json:"client_id,omitempty"`
+	// Entra ID tenant ID (azure_openai)
+	TenantID string `json:"tenant_id,omitempty"`
+	// Full resource name of the GCP workload identity pool provider (google_vertex*)
+	WorkloadIdentityProvider string `json:"workload_identity_provider,omitempty"`
+	// GCP service account to impersonate (google_vertex*)
+	ServiceAccountEmail string `json:"service_account_email,omitempty"`
+}
+
+type BedrockConfig struct {
+	// AWS region of the Bedrock runtime
+	Region string `json:"region"`
+	// ARN of the foundation model, inference profile or provisioned model to invoke
+	ModelARN string `json:"model_arn,omitempty"`
+	// Use the geographic cross-region inference profile of the models
+	CrossRegionInference bool `json:"cross_region_inference,omitempty"`
+	// Bedrock runtime endpoint overriding the regional default
+	EndpointURL string `json:"endpoint_url,omitempty"`
+	// IAM role assumed through STS before invoking Bedrock
+	RoleARN string `json:"role_arn,omitempty"`
+}
+
 type GoogleVertexConfig struct {
 	// Google Cloud project ID
 	Project string `json:"project,omitempty"`
@@ -198,6 +264,27 @@ type ModelConfig struct {
 	ContextWindowSize uint `json:"context_window_size,omitempty"`
 	// Model parameters
 	Parameters ModelParameters `json:"parameters,omitempty"`
+	// Request timeout in seconds, overriding the provider timeout
+	Timeout int32 `json:"timeout,omitempty"`
+	// Retry policy, overriding the provider retry policy
+	Retries *RetryConfig `json:"retries,omitempty"`
+	// Stable name of the model
+	Alias string `json:"alias,omitempty"`
+	// Model is deprecated
+	Deprecated bool `json:"deprecated,omitempty"`
+	// Features supported by the model
+	Capabilities *ModelCapabilities `json:"capabilities,omitempty"`
+}
+
+type ModelCapabilities struct {
+	// Model supports tool calling
+	ToolCalling bool `json:"tool_calling"`
+	// Model accepts image input
+	Vision bool `json:"vision"`
+	// Model supports reasoning
+	Reasoning bool `json:"reasoning"`
+	// Maximum number of output tokens
+	MaxOutputTokens int32 `json:"max_output_tokens,omitempty"`
 }
 
 type OLSConfig struct {
@@ -239,6 +326,51 @@ type OLSConfig struct {
 	Audit *AuditYAMLConfig `json:"audit,omitempty"`
 	// Solr hybrid RAG (portal-rag /hybrid-search); mirrors lightspeed-service solr_hybrid
 	SolrHybrid *SolrHybridSettings `json:"solr_hybrid,omitempty"`
+	// Query routing across providers; omitted when spec.ols.routing is not set
+	Routing *RoutingConfig `json:"routing,omitempty"`
+}
+
+// RoutingConfig configures failover, weighted splitting and rule based model selection.
+type RoutingConfig struct {
+	// Failover chain for failed queries
+	Failover *FailoverConfig `json:"failover,omitempty"`
+	// Weighted split of queries matching no rule
+	WeightedTargets []WeightedTargetConfig `json:"weighted_targets,omitempty"`
+	// Ordered rules selecting the target by request attributes
+	Rules []RoutingRuleConfig `json:"rules,omitempty"`
+}
+
+type RoutingTargetConfig struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+}
+
+type FailoverConfig struct {
+	// Targets tried in order after the selected one failed
+	Chain []RoutingTargetConfig `json:"chain"`
+	// Error classes triggering a failover: server_error, timeout, quota_exceeded
+	On []string `json:"on"`
+	// Seconds a failed target is skipped
+	CooldownSeconds int32 `json:"cooldown_seconds"`
+}
+
+type WeightedTargetConfig struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	Weight   int32  `json:"weight"`
+}
+
+type RoutingRuleConfig struct {
+	Name   string              `json:"name"`
+	Match  RoutingMatchConfig  `json:"match"`
+	Target RoutingTargetConfig `json:"target"`
+}
+
+type RoutingMatchConfig struct {
+	HasAttachments *bool    `json:"has_attachments,omitempty"`
+	MinQueryLength *int32   `json:"min_query_length,omitempty"`
+	MediaTypes     []string `json:"media_types,omitempty"`
+	UserGroups     []string `json:"user_groups,omitempty"`
 }
 
 type AuditYAMLConfig struct {
@@ -253,13 +385,25 @@ type OTELYAMLConfig struct {
 
 // SolrHybridSettings configures Solr hybrid RAG retrieval for the OLS application config file.
 type SolrHybridSettings struct {
-	SolrHTTPBase             string  `json:"solr_http_base,omitempty"`
-	MaxResults               int     `json:"max_results,omitempty"`
-	ChunkFilterQuery         string  `json:"chunk_filter_query,omitempty"`
-	HybridVectorBoost        float64 `json:"hybrid_vector_boost,omitempty"`
-	HybridPoolDocs           int     `json:"hybrid_pool_docs,omitempty"`
-	HybridScoreThreshold     float64 `json:"hybrid_score_threshold,omitempty"`
-	HybridSolrTimeoutSeconds float64 `json:"hybrid_solr_timeout_s,omitempty"`
+	SolrHTTPBase     string `json:"solr_http_base,omitempty"`
+	MaxResults       int    `json:"max_results,omitempty"`
+	ChunkFilterQuery string `json:"chunk_filter_query,omitempty"`
+	// Filter queries of the documentation scopes a query can select by name
+	ChunkFilterScopes        []SolrChunkFilterScope `json:"chunk_filter_scopes,omitempty"`
+	HybridVectorBoost        float64                `json:"hybrid_vector_boost,omitempty"`
+	HybridPoolDocs           int                    `json:"hybrid_pool_docs,omitempty"`
+	HybridScoreThreshold     float64                `json:"hybrid_score_threshold,omitempty"`
+	HybridSolrTimeoutSeconds float64                `json:"hybrid_solr_timeout_s,omitempty"`
+	// Directory holding the credentials of an external knowledge portal (apitoken, or username and password)
+	SolrCredentialsPath string `json:"solr_credentials_path,omitempty"`
+	// Weight of the OKP chunks when merged with the reference_content indexes, 1 when unset
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// SolrChunkFilterScope is a named Solr filter query selected by the documentation_scope parameter of a query.
+type SolrChunkFilterScope struct {
+	Name             string `json:"name"`
+	ChunkFilterQuery string `json:"chunk_filter_query"`
 }
 
 type TLSSecurityProfileConfig struct {
@@ -272,7 +416,7 @@ type TLSSecurityProfileConfig struct {
 }
 
 // ToolFilteringConfig defines configuration for tool filtering using hybrid RAG retrieval
-// The embedding model is not exposed as it's handled by the container image
+// The embedding model of the container image is used unless one is configured
 type ToolFilteringConfig struct {
 	// Weight for dense vs sparse retrieval (1.0 = full dense, 0.0 = full sparse)
 	Alpha float64 `json:"alpha,omitempty"`
@@ -280,6 +424,22 @@ type ToolFilteringConfig struct {
 	TopK int `json:"top_k,omitempty"`
 	// Minimum similarity threshold for filtering results
 	Threshold float64 `json:"threshold,omitempty"`
+	// Embedding model used for the tool descriptions and queries.
+	EmbeddingsModel *EmbeddingModelConfig `json:"embeddings_model,omitempty"`
+}
+
+// EmbeddingModelConfig defines a local embedding model or a remote OpenAI compatible embeddings endpoint
+type EmbeddingModelConfig struct {
+	// Path to the local embedding model directory in the app server container.
+	Path string `json:"path,omitempty"`
+	// Base URL of the remote embeddings API.
+	URL string `json:"url,omitempty"`
+	// Name of the model served by the remote embeddings API.
+	Model string `json:"model,omitempty"`
+	// Path to the file containing the API key of the remote embeddings API.
+	CredentialsPath string `json:"credentials_path,omitempty"`
+	// Dimension of the embedding vectors.
+	Dimension int32 `json:"dimension,omitempty"`
 }
 
 // ToolsApprovalConfig defines configuration for tool execution approval
@@ -382,6 +542,14 @@ type ReferenceIndex struct {
 	ProductDocsIndexId string `json:"product_docs_index_id,omitempty"`
 	// Where the database was copied from, i.e. BYOK image name.
 	ProductDocsOrigin string `json:"product_docs_origin,omitempty"`
+	// Embedding model the index was built with, overrides embeddings_model_path.
+	EmbeddingsModel *EmbeddingModelConfig `json:"embeddings_model,omitempty"`
+	// Weight of the index chunks when merged with the other knowledge sources, 1 when unset.
+	Weight int32 `json:"weight,omitempty"`
+	// Maximum number of the index chunks in the merged results.
+	MaxChunks int32 `json:"max_chunks,omitempty"`
+	// Topics for which the index chunks are ranked before the OKP documentation.
+	PreferredTopics []string `json:"preferred_topics,omitempty"`
 }
 
 type ReferenceContent struct {
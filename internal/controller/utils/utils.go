@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -99,17 +100,17 @@ func RHOOKPContainerSecurityContext() *corev1.SecurityContext {
 // Parameters:
 //   - deployment: The deployment to modify
 //   - config: The PodDeploymentConfig containing the desired settings
-//   - applyReplicas: Whether to apply the Replicas field (true for appserver and MCP server)
+//   - applyReplicas: Whether to apply the Replicas field (true for appserver, MCP server and RHOKP)
 //
 // Usage:
 //
 //	// For console/postgres (replicas always 1):
 //	utils.ApplyPodDeploymentConfig(deployment, cr.Spec.OLSConfig.DeploymentConfig.ConsoleContainer, false)
 //
-//	// For appserver or MCP server (replicas configurable):
+//	// For appserver, MCP server or RHOKP (replicas configurable):
 //	utils.ApplyPodDeploymentConfig(deployment, cr.Spec.OLSConfig.DeploymentConfig.APIContainer, true)
 func ApplyPodDeploymentConfig(deployment *appsv1.Deployment, config olsv1alpha1.Config, applyReplicas bool) {
-	// Apply replicas if allowed (appserver and MCP server)
+	// Apply replicas if allowed (appserver, MCP server and RHOKP)
 	if applyReplicas && config.Replicas != nil {
 		deployment.Spec.Replicas = config.Replicas
 	} else {
@@ -143,7 +144,7 @@ func GetSecretContent(rclient client.Client, ctx context.Context, secretName str
 }
 
 // podVolumEqual compares two slices of corev1.Volume and returns true if they are equal.
-// covers 3 volume types: Secret, ConfigMap, EmptyDir
+// covers the volume types: Secret, ConfigMap, EmptyDir, PersistentVolumeClaim, Projected, CSI, Image
 func PodVolumeEqual(a, b []corev1.Volume) bool {
 	if len(a) != len(b) {
 		return false
@@ -182,6 +183,26 @@ func PodVolumeEqual(a, b []corev1.Volume) bool {
 				}
 				continue
 			}
+			if aVolume.Projected != nil && bVolume.Projected != nil {
+				if !apiequality.Semantic.DeepEqual(aVolume.Projected.Sources, bVolume.Projected.Sources) {
+					return false
+				}
+				continue
+			}
+			if aVolume.CSI != nil && bVolume.CSI != nil {
+				if aVolume.CSI.Driver != bVolume.CSI.Driver ||
+					!apiequality.Semantic.DeepEqual(aVolume.CSI.ReadOnly, bVolume.CSI.ReadOnly) ||
+					!apiequality.Semantic.DeepEqual(aVolume.CSI.VolumeAttributes, bVolume.CSI.VolumeAttributes) {
+					return false
+				}
+				continue
+			}
+			if aVolume.Image != nil && bVolume.Image != nil {
+				if aVolume.Image.Reference != bVolume.Image.Reference || aVolume.Image.PullPolicy != bVolume.Image.PullPolicy {
+					return false
+				}
+				continue
+			}
 
 			return false
 		}
@@ -599,13 +620,23 @@ func GetCAFromSecret(rclient client.Client, ctx context.Context, namespace, secr
 }
 
 // ValidateLLMCredentials validates that all LLM provider credentials are present and usable.
-// For each provider it requires credentialsSecretRef, loads the secret, then checks Data keys:
+// Providers using workload identity or a Secrets Store CSI credentialsSource are skipped.
+// For every other provider it requires credentialsSecretRef, loads the secret, then checks Data keys:
 // Azure OpenAI accepts the default credential key or client_id/tenant_id/client_secret;
 // Google Vertex (and Anthropic) use credentialKey when set, otherwise the default key;
 // Bedrock accepts either the default credential key (Bearer token) or AWS IAM keys;
+// bedrockConfig.roleARN requires IAM keys and excludes a role_arn key in the secret;
 // all other supported types require the default credential key
 func ValidateLLMCredentials(r reconciler.Reconciler, ctx context.Context, cr *olsv1alpha1.OLSConfig) error {
 	for _, provider := range cr.Spec.LLMConfig.Providers {
+		// Workload identity providers exchange a projected ServiceAccount token and have no secret
+		if provider.WorkloadIdentity != nil {
+			continue
+		}
+		// Secrets Store CSI credentials are mounted by the kubelet and never readable by the operator
+		if provider.CredentialsSource != nil {
+			continue
+		}
 		if provider.CredentialsSecretRef.Name == "" {
 			return fmt.Errorf("provider %s missing credentials secret", provider.Name)
 		}
@@ -655,9 +686,30 @@ func ValidateLLMCredentials(r reconciler.Reconciler, ctx context.Context, cr *ol
 					BedrockSecretAccessKeyKey,
 				)
 			}
+			roleARN := ""
+			if provider.BedrockConfig != nil {
+				roleARN = provider.BedrockConfig.RoleARN
+			}
+			if roleARN != "" && strings.TrimSpace(string(secret.Data[BedrockRoleARNKey])) != "" {
+				return fmt.Errorf(
+					"LLM provider %s: set the STS role in either bedrockConfig.roleARN or the '%s' key of credential secret %s, not both",
+					provider.Name,
+					BedrockRoleARNKey,
+					provider.CredentialsSecretRef.Name,
+				)
+			}
 			if hasAccessKey && hasSecretKey {
 				continue
 			}
+			if roleARN != "" {
+				return fmt.Errorf(
+					"LLM provider %s: bedrockConfig.roleARN requires '%s' and '%s' in credential secret %s",
+					provider.Name,
+					BedrockAccessKeyIDKey,
+					BedrockSecretAccessKeyKey,
+					provider.CredentialsSecretRef.Name,
+				)
+			}
 			if strings.TrimSpace(string(secret.Data[DefaultCredentialKey])) != "" {
 				continue
 			}
@@ -715,6 +767,28 @@ func GenerateAppServerSelectorLabels() map[string]string {
 	}
 }
 
+// GenerateAppServerServiceSelectorLabels returns the labels the app server Service and NetworkPolicy select,
+// shared by the app server pods and the RAG canary pods serving queries next to them
+func GenerateAppServerServiceSelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by": "lightspeed-operator",
+		"app.kubernetes.io/name":       "lightspeed-service-api",
+		"app.kubernetes.io/part-of":    "openshift-lightspeed",
+	}
+}
+
+// GenerateRAGCanarySelectorLabels returns selector labels for the RAG canary pods, disjoint from the
+// app server Deployment selector
+func GenerateRAGCanarySelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/component":  "rag-canary",
+		"app.kubernetes.io/managed-by": "lightspeed-operator",
+		"app.kubernetes.io/name":       "lightspeed-service-api",
+		"app.kubernetes.io/part-of":    "openshift-lightspeed",
+		RAGCanaryLabel:                 "true",
+	}
+}
+
 // AnnotateSecretWatcher adds the watcher annotation to a secret
 func AnnotateSecretWatcher(secret *corev1.Secret) {
 	annotations := secret.GetAnnotations()
@@ -796,7 +870,8 @@ func GetProxyCACertHash(r reconciler.Reconciler, ctx context.Context, cr *olsv1a
 
 // The callback function receives:
 //   - name: the secret name
-//   - source: a descriptive identifier of where the secret is used (e.g., "llm-provider-openai", "tls", "mcp-myserver")
+//   - source: a descriptive identifier of where the secret is used (e.g., "llm-provider-openai", "tls", "mcp-myserver", "llm-header-openai",
+//     "embedding-rag-0", "embedding-tool-filtering", "okp-external", "rhokp-access-key")
 //
 // If fn returns an error, iteration stops immediately and that error is returned.
 // Returns nil if all iterations complete successfully.
@@ -843,9 +918,107 @@ func ForEachExternalSecret(cr *olsv1alpha1.OLSConfig, fn func(name string, sourc
 		}
 	}
 
+	// 4. LLM provider header secrets (only for type "secret")
+	for _, provider := range cr.Spec.LLMConfig.Providers {
+		for _, header := range provider.Headers {
+			if header.ValueFrom.Type != olsv1alpha1.ProviderHeaderSourceTypeSecret {
+				continue
+			}
+			if header.ValueFrom.SecretRef == nil || header.ValueFrom.SecretRef.Name == "" {
+				continue
+			}
+			if err := fn(header.ValueFrom.SecretRef.Name, "llm-header-"+provider.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	// 5. Remote embedding endpoint credentials of the RAG sources and tool filtering
+	for i, rag := range cr.Spec.OLSConfig.RAG {
+		if name := embeddingCredentialsSecretName(rag.EmbeddingModel); name != "" {
+			if err := fn(name, fmt.Sprintf("embedding-rag-%d", i)); err != nil {
+				return err
+			}
+		}
+	}
+	if cr.Spec.OLSConfig.ToolFilteringConfig != nil {
+		if name := embeddingCredentialsSecretName(cr.Spec.OLSConfig.ToolFilteringConfig.EmbeddingModel); name != "" {
+			if err := fn(name, "embedding-tool-filtering"); err != nil {
+				return err
+			}
+		}
+	}
+
+	// 6. External knowledge portal credentials
+	if external := OKPExternal(cr); external != nil && external.CredentialsSecretRef != nil &&
+		external.CredentialsSecretRef.Name != "" {
+		if err := fn(external.CredentialsSecretRef.Name, "okp-external"); err != nil {
+			return err
+		}
+	}
+
+	// 7. RHOKP access key rotated by the operator
+	if RHOKPAccessKeyRotation(cr) != nil {
+		if err := fn(RHOOKPAccessKeySecretName, "rhokp-access-key"); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// embeddingCredentialsSecretName returns the Secret holding the API key of a remote embedding endpoint,
+// empty when the model is local or the endpoint needs no credentials
+func embeddingCredentialsSecretName(model *olsv1alpha1.EmbeddingModelSpec) string {
+	if model == nil || model.Endpoint == nil || model.Endpoint.CredentialsSecretRef == nil {
+		return ""
+	}
+	return model.Endpoint.CredentialsSecretRef.Name
+}
+
+// OKPExternal returns the external knowledge portal used for OKP retrieval instead of the RHOKP
+// operand, nil when OKP is disabled or served by RHOKP.
+func OKPExternal(cr *olsv1alpha1.OLSConfig) *olsv1alpha1.OKPExternalSpec {
+	if cr.Spec.OLSConfig.ByokRAGOnly || cr.Spec.OLSConfig.OKP == nil {
+		return nil
+	}
+	return cr.Spec.OLSConfig.OKP.External
+}
+
+// IsRHOKPDeployed checks if the operator deploys the RHOKP operand: OKP is enabled and no
+// external knowledge portal is configured.
+func IsRHOKPDeployed(cr *olsv1alpha1.OLSConfig) bool {
+	return !cr.Spec.OLSConfig.ByokRAGOnly && OKPExternal(cr) == nil
+}
+
+// RHOKPAccessKeyRotation returns the access key rotation of the RHOKP operand, nil when the operand
+// is not deployed or the access key Secret is left to the user.
+func RHOKPAccessKeyRotation(cr *olsv1alpha1.OLSConfig) *olsv1alpha1.RHOKPAccessKeyRotation {
+	if !IsRHOKPDeployed(cr) {
+		return nil
+	}
+	return cr.Spec.OLSConfig.DeploymentConfig.RHOKPContainer.AccessKeyRotation
+}
+
+// IsOKPCATrusted checks if the app server trusts a dedicated CA for OKP retrieval: the service-ca
+// of the RHOKP operand, or the CA of the external knowledge portal when one is referenced.
+func IsOKPCATrusted(cr *olsv1alpha1.OLSConfig) bool {
+	if cr.Spec.OLSConfig.ByokRAGOnly {
+		return false
+	}
+	external := OKPExternal(cr)
+	return external == nil || (external.CAConfigMapRef != nil && external.CAConfigMapRef.Name != "")
+}
+
+// OKPServiceURL returns the Solr endpoint of OKP retrieval: the external knowledge portal when
+// configured, the in-cluster RHOKP Service otherwise.
+func OKPServiceURL(cr *olsv1alpha1.OLSConfig, namespace string) string {
+	if external := OKPExternal(cr); external != nil {
+		return strings.TrimSuffix(external.URL, "/")
+	}
+	return RHOKPServiceURL(namespace)
+}
+
 // AlertsAdapterConfigMapRef returns the referenced ConfigMap name when the alerts adapter
 // is enabled (configMapRef set with a non-empty name). The bool is false when disabled.
 func AlertsAdapterConfigMapRef(cr *olsv1alpha1.OLSConfig) (name string, ok bool) {
@@ -859,7 +1032,7 @@ func AlertsAdapterConfigMapRef(cr *olsv1alpha1.OLSConfig) (name string, ok bool)
 // ForEachExternalConfigMap calls fn for each external configmap referenced in the OLSConfig CR.
 // The callback function receives:
 //   - name: the configmap name
-//   - source: a descriptive identifier of where the configmap is used (e.g., "additional-ca", "proxy-ca")
+//   - source: a descriptive identifier of where the configmap is used (e.g., "additional-ca", "proxy-ca", "okp-external-ca", "rag-0")
 //
 // If fn returns an error, iteration stops immediately and that error is returned.
 // Returns nil if all iterations complete successfully.
@@ -896,6 +1069,24 @@ func ForEachExternalConfigMap(cr *olsv1alpha1.OLSConfig, fn func(name string, so
 		}
 	}
 
+	// 4. External knowledge portal CA certificate
+	if external := OKPExternal(cr); external != nil && external.CAConfigMapRef != nil &&
+		external.CAConfigMapRef.Name != "" {
+		if err := fn(external.CAConfigMapRef.Name, "okp-external-ca"); err != nil {
+			return err
+		}
+	}
+
+	// 5. BYOK RAG indexes from ConfigMaps
+	for i, rag := range cr.Spec.OLSConfig.RAG {
+		if rag.ConfigMap == nil || rag.ConfigMap.Name == "" {
+			continue
+		}
+		if err := fn(rag.ConfigMap.Name, fmt.Sprintf("rag-%d", i)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1248,6 +1439,56 @@ func ReconcileProxyCAConfigMap(r reconciler.Reconciler, ctx context.Context, cr
 	return nil
 }
 
+// FindModel returns the model of a provider matching a model name or alias, nil when the provider
+// does not list it (e.g. models discovered from InferenceServices)
+func FindModel(cr *olsv1alpha1.OLSConfig, providerName, modelName string) *olsv1alpha1.ModelSpec {
+	for i := range cr.Spec.LLMConfig.Providers {
+		provider := &cr.Spec.LLMConfig.Providers[i]
+		if provider.Name != providerName {
+			continue
+		}
+		for j := range provider.Models {
+			if provider.Models[j].Name == modelName || (provider.Models[j].Alias != "" && provider.Models[j].Alias == modelName) {
+				return &provider.Models[j]
+			}
+		}
+	}
+	return nil
+}
+
+// ToolCallingRequired checks if the configuration lets the LLM call tools: MCP servers,
+// including the built-in OpenShift MCP server, or a tools approval policy
+func ToolCallingRequired(cr *olsv1alpha1.OLSConfig) bool {
+	return len(cr.Spec.MCPServers) > 0 ||
+		BoolDeref(cr.Spec.OLSConfig.IntrospectionEnabled, true) ||
+		cr.Spec.OLSConfig.ToolsApprovalConfig != nil
+}
+
+// ModelSupportsToolCalling checks the declared tool calling capability of a model, which defaults to true
+func ModelSupportsToolCalling(model *olsv1alpha1.ModelSpec) bool {
+	if model == nil || model.Capabilities == nil {
+		return true
+	}
+	return BoolDeref(model.Capabilities.ToolCalling, true)
+}
+
+// ImageVolumesSupported checks if the OpenShift version of the cluster mounts image volumes.
+// An unknown version is assumed to be recent.
+func ImageVolumesSupported(major, minor string) bool {
+	majorVersion, err := strconv.Atoi(major)
+	if err != nil {
+		return true
+	}
+	minorVersion, err := strconv.Atoi(minor)
+	if err != nil {
+		return true
+	}
+	if majorVersion != ImageVolumeMinOpenShiftMajor {
+		return majorVersion > ImageVolumeMinOpenShiftMajor
+	}
+	return minorVersion >= ImageVolumeMinOpenShiftMinor
+}
+
 // BoolDeref returns *p when non-nil; otherwise def.
 func BoolDeref(p *bool, def bool) bool {
 	if p != nil {
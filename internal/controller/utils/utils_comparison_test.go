@@ -63,6 +63,72 @@ var _ = Describe("Volume Comparison", func() {
 			Expect(PodVolumeEqual(volumes1, volumes2)).To(BeFalse())
 		})
 
+		It("should compare projected service account token volumes correctly", func() {
+			tokenVolume := func(audience string) []corev1.Volume {
+				return []corev1.Volume{
+					{
+						Name: "token-vol",
+						VolumeSource: corev1.VolumeSource{
+							Projected: &corev1.ProjectedVolumeSource{
+								Sources: []corev1.VolumeProjection{{
+									ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+										Audience: audience,
+										Path:     "provider/token",
+									},
+								}},
+							},
+						},
+					},
+				}
+			}
+			Expect(PodVolumeEqual(tokenVolume("sts.amazonaws.com"), tokenVolume("sts.amazonaws.com"))).To(BeTrue())
+			Expect(PodVolumeEqual(tokenVolume("sts.amazonaws.com"), tokenVolume("api://AzureADTokenExchange"))).To(BeFalse())
+		})
+
+		It("should compare Secrets Store CSI volumes correctly", func() {
+			csiVolume := func(secretProviderClass string) []corev1.Volume {
+				return []corev1.Volume{
+					{
+						Name: "csi-vault",
+						VolumeSource: corev1.VolumeSource{
+							CSI: &corev1.CSIVolumeSource{
+								Driver:   SecretsStoreCSIDriverName,
+								ReadOnly: BoolPtr(true),
+								VolumeAttributes: map[string]string{
+									SecretProviderClassVolumeAttribute: secretProviderClass,
+								},
+							},
+						},
+					},
+				}
+			}
+			Expect(PodVolumeEqual(csiVolume("vault-openai"), csiVolume("vault-openai"))).To(BeTrue())
+			Expect(PodVolumeEqual(csiVolume("vault-openai"), csiVolume("vault-azure"))).To(BeFalse())
+
+			writable := csiVolume("vault-openai")
+			writable[0].CSI.ReadOnly = BoolPtr(false)
+			Expect(PodVolumeEqual(csiVolume("vault-openai"), writable)).To(BeFalse())
+		})
+
+		It("should compare image volumes correctly", func() {
+			imageVolume := func(reference string, pullPolicy corev1.PullPolicy) []corev1.Volume {
+				return []corev1.Volume{
+					{
+						Name: "rag-source-0",
+						VolumeSource: corev1.VolumeSource{
+							Image: &corev1.ImageVolumeSource{Reference: reference, PullPolicy: pullPolicy},
+						},
+					},
+				}
+			}
+			Expect(PodVolumeEqual(imageVolume("quay.io/myorg/rag-index:1.0", corev1.PullIfNotPresent),
+				imageVolume("quay.io/myorg/rag-index:1.0", corev1.PullIfNotPresent))).To(BeTrue())
+			Expect(PodVolumeEqual(imageVolume("quay.io/myorg/rag-index:1.0", corev1.PullIfNotPresent),
+				imageVolume("quay.io/myorg/rag-index:2.0", corev1.PullIfNotPresent))).To(BeFalse())
+			Expect(PodVolumeEqual(imageVolume("quay.io/myorg/rag-index:1.0", corev1.PullIfNotPresent),
+				imageVolume("quay.io/myorg/rag-index:1.0", corev1.PullAlways))).To(BeFalse())
+		})
+
 		It("should compare configmap volumes correctly", func() {
 			volumes1 := []corev1.Volume{
 				{
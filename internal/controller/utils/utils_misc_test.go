@@ -1098,6 +1098,78 @@ cNHlzbRSivTDuHmXJdCYIdd8cnH6EbPm3zNg0jU5Au6OrvDZYifP+DtuiLmJct4=
 			Expect(err.Error()).To(ContainSubstring("aws_access_key_id"))
 		})
 
+		It("should accept a workload identity provider without a credentials secret", func() {
+			testCR := WithBedrockProvider(GetDefaultOLSConfigCR())
+			testCR.Spec.LLMConfig.Providers[0].CredentialsSecretRef.Name = ""
+			testCR.Spec.LLMConfig.Providers[0].BedrockConfig = &olsv1alpha1.BedrockConfig{
+				Region:  "us-east-1",
+				RoleARN: "arn:aws:iam::123456789012:role/TestRole",
+			}
+			testCR.Spec.LLMConfig.Providers[0].WorkloadIdentity = &olsv1alpha1.WorkloadIdentitySpec{}
+
+			Expect(ValidateLLMCredentials(testReconciler, testCtx, testCR)).To(Succeed())
+		})
+
+		It("should not look up a secret for a provider with a Secrets Store CSI credentials source", func() {
+			testCR := GetDefaultOLSConfigCR()
+			testCR.Spec.LLMConfig.Providers[0].CredentialsSecretRef.Name = ""
+			testCR.Spec.LLMConfig.Providers[0].CredentialsSource = &olsv1alpha1.ProviderCredentialsSource{SecretProviderClass: "vault-openai"}
+
+			Expect(ValidateLLMCredentials(testReconciler, testCtx, testCR)).To(Succeed())
+		})
+
+		It("should fail when the Bedrock STS role is set in both the CR and the secret", func() {
+			testSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-bedrock-duplicate-role-secret",
+					Namespace: OLSNamespaceDefault,
+				},
+				Data: map[string][]byte{
+					BedrockAccessKeyIDKey:     []byte("AKIATEST"),
+					BedrockSecretAccessKeyKey: []byte("secret"),
+					BedrockRoleARNKey:         []byte("arn:aws:iam::123456789012:role/TestRole"),
+				},
+			}
+			err := k8sClient.Create(testCtx, testSecret)
+			Expect(err).NotTo(HaveOccurred())
+
+			testCR := WithBedrockProvider(GetDefaultOLSConfigCR())
+			testCR.Spec.LLMConfig.Providers[0].CredentialsSecretRef.Name = "test-bedrock-duplicate-role-secret"
+			testCR.Spec.LLMConfig.Providers[0].BedrockConfig = &olsv1alpha1.BedrockConfig{
+				Region:  "us-east-1",
+				RoleARN: "arn:aws:iam::123456789012:role/OtherRole",
+			}
+
+			err = ValidateLLMCredentials(testReconciler, testCtx, testCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not both"))
+		})
+
+		It("should fail when the Bedrock STS role is used with a Bearer token", func() {
+			testSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-bedrock-role-bearer-secret",
+					Namespace: OLSNamespaceDefault,
+				},
+				Data: map[string][]byte{
+					DefaultCredentialKey: []byte("bedrock-api-key"),
+				},
+			}
+			err := k8sClient.Create(testCtx, testSecret)
+			Expect(err).NotTo(HaveOccurred())
+
+			testCR := WithBedrockProvider(GetDefaultOLSConfigCR())
+			testCR.Spec.LLMConfig.Providers[0].CredentialsSecretRef.Name = "test-bedrock-role-bearer-secret"
+			testCR.Spec.LLMConfig.Providers[0].BedrockConfig = &olsv1alpha1.BedrockConfig{
+				Region:  "us-east-1",
+				RoleARN: "arn:aws:iam::123456789012:role/TestRole",
+			}
+
+			err = ValidateLLMCredentials(testReconciler, testCtx, testCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bedrockConfig.roleARN requires"))
+		})
+
 	})
 })
 
@@ -1147,3 +1219,20 @@ var _ = Describe("ImageStreamNameFor", func() {
 		Expect(got).To(MatchRegexp(`^[a-z0-9_-]+-[a-f0-9]{6}$`))
 	})
 })
+
+var _ = Describe("ImageVolumesSupported", func() {
+	It("supports image volumes from the first OpenShift release enabling them", func() {
+		Expect(ImageVolumesSupported("4", "20")).To(BeTrue())
+		Expect(ImageVolumesSupported("4", "21")).To(BeTrue())
+		Expect(ImageVolumesSupported("5", "0")).To(BeTrue())
+	})
+
+	It("does not support image volumes on older OpenShift releases", func() {
+		Expect(ImageVolumesSupported("4", "19")).To(BeFalse())
+		Expect(ImageVolumesSupported("3", "11")).To(BeFalse())
+	})
+
+	It("assumes a recent release when the version is unknown", func() {
+		Expect(ImageVolumesSupported("", "")).To(BeTrue())
+	})
+})
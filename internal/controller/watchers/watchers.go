@@ -3,10 +3,13 @@ package watchers
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,6 +28,7 @@ import (
 	"github.com/openshift/lightspeed-operator/internal/controller/reconciler"
 	"github.com/openshift/lightspeed-operator/internal/controller/rhokp"
 	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+	"github.com/openshift/lightspeed-operator/internal/metrics"
 )
 
 // isSecretReferencedInCR checks if a secret is referenced in the OLSConfig CR
@@ -118,6 +122,11 @@ func (h *SecretUpdateHandler) Update(ctx context.Context, evt event.UpdateEvent,
 		return
 	}
 
+	// A changed credentials expiry annotation only updates the CredentialsExpiring condition
+	if oldSecret.Annotations[utils.CredentialsExpiryAnnotationKey] != newSecret.Annotations[utils.CredentialsExpiryAnnotationKey] {
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: utils.OLSConfigName}})
+	}
+
 	// Check if the data actually changed (not just metadata/annotations)
 	if apiequality.Semantic.DeepEqual(oldSecret.Data, newSecret.Data) {
 		// Data hasn't changed, skip
@@ -224,6 +233,80 @@ func (h *ConfigMapUpdateHandler) Generic(ctx context.Context, evt event.GenericE
 	// No-op
 }
 
+// SecretProviderClassPodStatusHandler restarts the app server when the Secrets Store CSI driver rotates
+// the credentials it mounted from a SecretProviderClass referenced by an LLM provider.
+type SecretProviderClassPodStatusHandler struct {
+	Reconciler reconciler.Reconciler
+
+	mu sync.Mutex
+	// restarted holds the object versions hash each SecretProviderClass last restarted the app server for,
+	// so that the statuses of the other app server pods reporting the same rotation are ignored
+	restarted map[string]string
+}
+
+// Create implements handler.EventHandler - new pods mount the current object versions, nothing to restart
+func (h *SecretProviderClassPodStatusHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// No-op
+}
+
+// Update implements handler.EventHandler - restart the app server when the mounted object versions change
+func (h *SecretProviderClassPodStatusHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldStatus, oldOk := evt.ObjectOld.(*unstructured.Unstructured)
+	newStatus, newOk := evt.ObjectNew.(*unstructured.Unstructured)
+	if !oldOk || !newOk {
+		return
+	}
+
+	_, _, oldVersions, err := utils.SecretProviderClassPodStatusInfo(oldStatus)
+	if err != nil {
+		return
+	}
+	secretProviderClass, podName, newVersions, err := utils.SecretProviderClassPodStatusInfo(newStatus)
+	if err != nil {
+		h.Reconciler.GetLogger().Info("failed to read SecretProviderClassPodStatus", "name", newStatus.GetName(), "error", err)
+		return
+	}
+	if oldVersions == newVersions || !strings.HasPrefix(podName, utils.OLSAppServerDeploymentName+"-") {
+		return
+	}
+
+	cr := &olsv1alpha1.OLSConfig{}
+	if err := h.Reconciler.Get(ctx, types.NamespacedName{Name: utils.OLSConfigName}, cr); err != nil {
+		h.Reconciler.GetLogger().Info("failed to get OLSConfig CR for SecretProviderClassPodStatus watcher",
+			"secretProviderClass", secretProviderClass, "error", err)
+		return
+	}
+	if !utils.IsSecretProviderClassReferencedInCR(cr, secretProviderClass) {
+		return
+	}
+
+	h.mu.Lock()
+	if h.restarted == nil {
+		h.restarted = map[string]string{}
+	}
+	if h.restarted[secretProviderClass] == newVersions {
+		h.mu.Unlock()
+		return
+	}
+	h.restarted[secretProviderClass] = newVersions
+	h.mu.Unlock()
+
+	h.Reconciler.GetLogger().Info("Detected rotated Secrets Store CSI credentials",
+		"secretProviderClass", secretProviderClass, "pod", podName)
+	restartDeployment(h.Reconciler, ctx, utils.SecretProviderClassPodStatusKind, []string{utils.OLSAppServerDeploymentName},
+		newStatus.GetNamespace(), secretProviderClass)
+}
+
+// Delete implements handler.EventHandler - we don't care about deletes
+func (h *SecretProviderClassPodStatusHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// No-op
+}
+
+// Generic implements handler.EventHandler - we don't use generic events
+func (h *SecretProviderClassPodStatusHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// No-op
+}
+
 // SecretWatcherFilter is a data-driven filter function for watching Secrets.
 // It uses the reconciler's WatcherConfig to determine which deployments to restart when a Secret changes.
 //
@@ -257,10 +340,11 @@ func SecretWatcherFilter(r reconciler.Reconciler, ctx context.Context, obj clien
 					"secret", systemSecret.Name,
 					"namespace", systemSecret.Namespace,
 					"description", systemSecret.Description)
+				recordTLSSecretRotation(r, ctx, obj)
 
 				// Restart all affected deployments
 				if inClusterValue {
-					restartDeployment(r, ctx, systemSecret.AffectedDeployments, systemSecret.Namespace, systemSecret.Name)
+					restartDeployment(r, ctx, "Secret", systemSecret.AffectedDeployments, systemSecret.Namespace, systemSecret.Name)
 				}
 				return
 			}
@@ -289,8 +373,9 @@ func SecretWatcherFilter(r reconciler.Reconciler, ctx context.Context, obj clien
 		r.GetLogger().Info("Detected annotated secret change",
 			"secret", secretName,
 			"affectedDeployments", affectedDeployments)
+		recordTLSSecretRotation(r, ctx, obj)
 		if inClusterValue {
-			restartDeployment(r, ctx, affectedDeployments, obj.GetNamespace(), secretName)
+			restartDeployment(r, ctx, "Secret", affectedDeployments, obj.GetNamespace(), secretName)
 		}
 		return
 	}
@@ -330,7 +415,7 @@ func ConfigMapWatcherFilter(r reconciler.Reconciler, ctx context.Context, obj cl
 
 				// Restart all affected deployments
 				if inClusterValue {
-					restartDeployment(r, ctx, systemCM.AffectedDeployments, systemCM.Namespace, systemCM.Name)
+					restartDeployment(r, ctx, "ConfigMap", systemCM.AffectedDeployments, systemCM.Namespace, systemCM.Name)
 				}
 				return
 			}
@@ -360,7 +445,7 @@ func ConfigMapWatcherFilter(r reconciler.Reconciler, ctx context.Context, obj cl
 			"configmap", configMapName,
 			"affectedDeployments", affectedDeployments)
 		if inClusterValue {
-			restartDeployment(r, ctx, affectedDeployments, obj.GetNamespace(), configMapName)
+			restartDeployment(r, ctx, "ConfigMap", affectedDeployments, obj.GetNamespace(), configMapName)
 		}
 		return
 	}
@@ -389,8 +474,8 @@ func touchAgenticConfigurationFunc(r reconciler.Reconciler, ctx context.Context,
 	return agenticintegration.TouchAgenticConfiguration(r, ctx)
 }
 
-// restart corresponding deployment
-func restartDeployment(r reconciler.Reconciler, ctx context.Context, affectedDeployments []string, namespace string, name string) {
+// restart corresponding deployment; kind is the watched resource kind (Secret or ConfigMap)
+func restartDeployment(r reconciler.Reconciler, ctx context.Context, kind string, affectedDeployments []string, namespace string, name string) {
 
 	for _, depName := range affectedDeployments {
 		// Restart the deployment using the appropriate function
@@ -404,10 +489,25 @@ func restartDeployment(r reconciler.Reconciler, ctx context.Context, affectedDep
 		if err != nil {
 			r.GetLogger().Error(err, "failed to restart deployment",
 				"deployment", depName, "resource", name, "namespace", namespace)
+			utils.RecordOLSConfigEvent(r, ctx, v1.EventTypeWarning, utils.EventReasonDeploymentRestartFailed, utils.EventActionRestart,
+				"Failed to restart %s after %s/%s changed: %v", depName, namespace, name, err)
 			// Continue with other deployments
 		} else {
 			r.GetLogger().Info("restarted deployment",
 				"deployment", depName, "resource", name, "namespace", namespace)
+			metrics.IncWatcherRestart(kind, name, depName)
+			utils.RecordOLSConfigEvent(r, ctx, v1.EventTypeNormal, utils.EventReasonDeploymentRestarted, utils.EventActionRestart,
+				"Restarted %s after %s/%s changed", depName, namespace, name)
 		}
 	}
 }
+
+// recordTLSSecretRotation emits a TLSSecretRotated Event when the changed Secret holds TLS material.
+func recordTLSSecretRotation(r reconciler.Reconciler, ctx context.Context, obj client.Object) {
+	secret, ok := obj.(*v1.Secret)
+	if !ok || secret.Type != v1.SecretTypeTLS {
+		return
+	}
+	utils.RecordOLSConfigEvent(r, ctx, v1.EventTypeNormal, utils.EventReasonTLSSecretRotated, utils.EventActionRestart,
+		"TLS secret %s/%s changed", secret.Namespace, secret.Name)
+}
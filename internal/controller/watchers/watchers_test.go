@@ -9,9 +9,11 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -184,7 +186,7 @@ var _ = Describe("Watchers", func() {
 		It("skips unknown deployment keys without panicking", func() {
 			r := createTestReconciler()
 			Expect(func() {
-				restartDeployment(r, ctx, []string{"not-a-real-deployment-key"}, utils.OLSNamespaceDefault, "res")
+				restartDeployment(r, ctx, "Secret", []string{"not-a-real-deployment-key"}, utils.OLSNamespaceDefault, "res")
 			}).NotTo(Panic())
 		})
 	})
@@ -361,6 +363,79 @@ var _ = Describe("Watchers", func() {
 		})
 	})
 
+	Describe("SecretProviderClassPodStatusHandler", func() {
+		podStatus := func(podName, version string) *unstructured.Unstructured {
+			status := utils.NewSecretProviderClassPodStatus()
+			status.SetNamespace(utils.OLSNamespaceDefault)
+			status.SetName(podName + "-" + utils.OLSNamespaceDefault + "-vault-openai")
+			Expect(unstructured.SetNestedField(status.Object, map[string]interface{}{
+				"podName":                 podName,
+				"secretProviderClassName": "vault-openai",
+				"objects": []interface{}{
+					map[string]interface{}{"id": "secret/openai/apitoken", "version": version},
+				},
+			}, "status")).To(Succeed())
+			return status
+		}
+
+		It("restarts the app server once per rotation of a referenced SecretProviderClass", func() {
+			cr := utils.GetDefaultOLSConfigCR()
+			cr.Spec.LLMConfig.Providers[0].CredentialsSecretRef.Name = ""
+			cr.Spec.LLMConfig.Providers[0].CredentialsSource = &olsv1alpha1.ProviderCredentialsSource{SecretProviderClass: "vault-openai"}
+			dep := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      utils.OLSAppServerDeploymentName,
+					Namespace: utils.OLSNamespaceDefault,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "ols"}},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "ols"}},
+						Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}},
+					},
+				},
+			}
+			otelCACM := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      utils.OLSCAConfigMap,
+					Namespace: utils.OLSNamespaceDefault,
+				},
+				Data: map[string]string{utils.AppOtelCollectorCACertFile: "test-service-ca"},
+			}
+			r := createTestReconciler(cr, dep, otelCACM)
+			h := &SecretProviderClassPodStatusHandler{Reconciler: r}
+			restartedAt := func() string {
+				updated := &appsv1.Deployment{}
+				Expect(r.Get(ctx, client.ObjectKeyFromObject(dep), updated)).To(Succeed())
+				return updated.Spec.Template.Annotations[utils.ForceReloadAnnotationKey]
+			}
+
+			pod := utils.OLSAppServerDeploymentName + "-7d9f8-abcde"
+			h.Update(ctx, event.UpdateEvent{ObjectOld: podStatus(pod, "1"), ObjectNew: podStatus(pod, "1")}, nil)
+			Expect(restartedAt()).To(BeEmpty())
+
+			h.Update(ctx, event.UpdateEvent{ObjectOld: podStatus(pod, "1"), ObjectNew: podStatus(pod, "2")}, nil)
+			restarted := restartedAt()
+			Expect(restarted).NotTo(BeEmpty())
+
+			// The other replica reports the same rotation
+			other := utils.OLSAppServerDeploymentName + "-7d9f8-fghij"
+			h.Update(ctx, event.UpdateEvent{ObjectOld: podStatus(other, "1"), ObjectNew: podStatus(other, "2")}, nil)
+			Expect(restartedAt()).To(Equal(restarted))
+		})
+
+		It("ignores SecretProviderClasses not referenced by a provider", func() {
+			cr := utils.GetDefaultOLSConfigCR()
+			r := createTestReconciler(cr)
+			h := &SecretProviderClassPodStatusHandler{Reconciler: r}
+			pod := utils.OLSAppServerDeploymentName + "-7d9f8-abcde"
+			Expect(func() {
+				h.Update(ctx, event.UpdateEvent{ObjectOld: podStatus(pod, "1"), ObjectNew: podStatus(pod, "2")}, nil)
+			}).NotTo(Panic())
+			Expect(h.restarted).To(BeEmpty())
+		})
+	})
+
 	Describe("restartDeployment with in-cluster restart", func() {
 		It("restarts app server deployment", func() {
 			cr := utils.GetDefaultOLSConfigCR()
@@ -444,4 +519,96 @@ var _ = Describe("Watchers", func() {
 			Expect(updated.Spec.Template.Annotations).To(HaveKey(utils.ForceReloadAnnotationKey))
 		})
 	})
+
+	Describe("Kubernetes Events", func() {
+		appServerDeployment := func() *appsv1.Deployment {
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      utils.OLSAppServerDeploymentName,
+					Namespace: utils.OLSNamespaceDefault,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "ols"}},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "ols"}},
+						Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}},
+					},
+				},
+			}
+		}
+		otelCAConfigMap := func() *corev1.ConfigMap {
+			return &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      utils.OLSCAConfigMap,
+					Namespace: utils.OLSNamespaceDefault,
+				},
+				Data: map[string]string{utils.AppOtelCollectorCACertFile: "test-service-ca"},
+			}
+		}
+
+		It("emits DeploymentRestarted when a watched secret change restarts a deployment", func() {
+			r := createTestReconciler(utils.GetDefaultOLSConfigCR(), appServerDeployment(), otelCAConfigMap())
+			recorder := events.NewFakeRecorder(10)
+			r.(*utils.TestReconciler).EventRecorder = recorder
+
+			sec := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   utils.OLSNamespaceDefault,
+					Name:        "annot",
+					Annotations: map[string]string{utils.WatcherAnnotationKey: "1"},
+				},
+				Data: map[string][]byte{"k": []byte("v")},
+			}
+			SecretWatcherFilter(r, ctx, sec, true)
+
+			Expect(recorder.Events).To(Receive(And(
+				HavePrefix(corev1.EventTypeNormal+" "+utils.EventReasonDeploymentRestarted),
+				ContainSubstring(utils.OLSAppServerDeploymentName),
+			)))
+			Expect(recorder.Events).NotTo(Receive(), "opaque secrets must not report a TLS rotation")
+		})
+
+		It("emits DeploymentRestartFailed when the restart fails", func() {
+			r := createTestReconciler(utils.GetDefaultOLSConfigCR())
+			recorder := events.NewFakeRecorder(10)
+			r.(*utils.TestReconciler).EventRecorder = recorder
+
+			restartDeployment(r, ctx, "Secret", []string{utils.OLSAppServerDeploymentName}, utils.OLSNamespaceDefault, "annot")
+
+			Expect(recorder.Events).To(Receive(HavePrefix(
+				corev1.EventTypeWarning + " " + utils.EventReasonDeploymentRestartFailed)))
+		})
+
+		It("emits TLSSecretRotated for TLS secrets", func() {
+			r := createTestReconciler(utils.GetDefaultOLSConfigCR())
+			recorder := events.NewFakeRecorder(10)
+			r.(*utils.TestReconciler).EventRecorder = recorder
+
+			sec := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   utils.OLSNamespaceDefault,
+					Name:        "mapped-secret",
+					Annotations: map[string]string{utils.WatcherAnnotationKey: "1"},
+				},
+				Type: corev1.SecretTypeTLS,
+				Data: map[string][]byte{"tls.crt": []byte("c"), "tls.key": []byte("k")},
+			}
+			SecretWatcherFilter(r, ctx, sec, false)
+
+			Expect(recorder.Events).To(Receive(And(
+				HavePrefix(corev1.EventTypeNormal+" "+utils.EventReasonTLSSecretRotated),
+				ContainSubstring("mapped-secret"),
+			)))
+		})
+
+		It("drops events when the OLSConfig CR does not exist", func() {
+			r := createTestReconciler()
+			recorder := events.NewFakeRecorder(10)
+			r.(*utils.TestReconciler).EventRecorder = recorder
+
+			restartDeployment(r, ctx, "Secret", []string{utils.OLSAppServerDeploymentName}, utils.OLSNamespaceDefault, "annot")
+
+			Expect(recorder.Events).NotTo(Receive())
+		})
+	})
 })
@@ -0,0 +1,175 @@
+// Package metrics defines the operator's own Prometheus metrics.
+//
+// All collectors are registered on the controller-runtime metrics registry, so they are
+// served by the manager's metrics endpoint next to the default controller-runtime metrics
+// and scraped through the operator ServiceMonitor (controller-manager-metrics-monitor).
+//
+// Metrics:
+//   - lightspeed_operator_reconcile_step_duration_seconds{step}: duration of each ReconcileSteps entry
+//   - lightspeed_operator_reconcile_step_errors_total{step}: failed ReconcileSteps executions
+//   - lightspeed_operator_component_ready{condition_type}: 1 when the operand condition is True, 0 otherwise;
+//     disabled operands have no series
+//   - lightspeed_operator_watcher_restarts_total{kind,name,deployment}: restarts triggered by watched Secrets/ConfigMaps
+//   - lightspeed_operator_last_successful_reconcile_timestamp_seconds: Unix time of the last reconcile that returned no error
+//   - lightspeed_operator_pod_diagnostics{component,reason}: status.diagnosticInfo entries by reason
+//   - lightspeed_operator_credentials_expiry_timestamp_seconds{secret}: Unix time the credentials of a referenced Secret expire
+//   - lightspeed_operator_credentials_expiring{secret}: 1 when the credentials of a referenced Secret expire within the warning window
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+	"github.com/openshift/lightspeed-operator/internal/controller/utils"
+)
+
+const (
+	// metricsNamespace is the metric name prefix for all operator metrics
+	metricsNamespace = "lightspeed_operator"
+	// conditionReasonDisabled is the condition reason of operands that are turned off
+	conditionReasonDisabled = "Disabled"
+)
+
+// informationalConditionTypes are the OLSConfig status conditions that do not report the readiness
+// of a component and are therefore left out of component_ready
+var informationalConditionTypes = map[string]bool{
+	utils.TypeToolCallingSupported: true,
+	utils.TypeCredentialsExpiring:  true,
+}
+
+var (
+	// ReconcileStepDuration tracks how long each reconcile step takes
+	ReconcileStepDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_step_duration_seconds",
+		Help:      "Duration of OLSConfig reconcile steps in seconds.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"step"})
+
+	// ReconcileStepErrors counts failed reconcile steps
+	ReconcileStepErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_step_errors_total",
+		Help:      "Total number of failed OLSConfig reconcile steps.",
+	}, []string{"step"})
+
+	// ComponentReady reports operand readiness keyed by status condition type
+	ComponentReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "component_ready",
+		Help:      "Whether the operand reported by the status condition type is ready (1) or not (0). Disabled operands are not reported.",
+	}, []string{"condition_type"})
+
+	// WatcherRestarts counts deployment restarts triggered by watched Secrets and ConfigMaps
+	WatcherRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "watcher_restarts_total",
+		Help:      "Total number of deployment restarts triggered by changes to watched Secrets and ConfigMaps.",
+	}, []string{"kind", "name", "deployment"})
+
+	// LastSuccessfulReconcile is the Unix timestamp of the last successful reconcile
+	LastSuccessfulReconcile = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "last_successful_reconcile_timestamp_seconds",
+		Help:      "Unix timestamp of the last OLSConfig reconciliation that completed without error.",
+	})
+
+	// PodDiagnostics counts the current status.diagnosticInfo entries
+	PodDiagnostics = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "pod_diagnostics",
+		Help:      "Number of pod diagnostics currently reported in OLSConfig status, by failed component and reason.",
+	}, []string{"component", "reason"})
+
+	// CredentialsExpiryTimestamp is the expiry time of the credentials of referenced Secrets with a known expiry
+	CredentialsExpiryTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "credentials_expiry_timestamp_seconds",
+		Help:      "Unix timestamp when the credentials of a Secret referenced by OLSConfig expire.",
+	}, []string{"secret"})
+
+	// CredentialsExpiring reports referenced Secrets whose credentials expire within the warning window
+	CredentialsExpiring = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "credentials_expiring",
+		Help:      "Whether the credentials of a Secret referenced by OLSConfig expire within spec.llm.credentialsExpiryWarningDays (1) or not (0).",
+	}, []string{"secret"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		ReconcileStepDuration,
+		ReconcileStepErrors,
+		ComponentReady,
+		WatcherRestarts,
+		LastSuccessfulReconcile,
+		PodDiagnostics,
+		CredentialsExpiryTimestamp,
+		CredentialsExpiring,
+	)
+}
+
+// ObserveReconcileStep records the duration of a reconcile step and counts it as failed when err is non-nil.
+func ObserveReconcileStep(step string, start time.Time, err error) {
+	ReconcileStepDuration.WithLabelValues(step).Observe(time.Since(start).Seconds())
+	if err != nil {
+		ReconcileStepErrors.WithLabelValues(step).Inc()
+	}
+}
+
+// SetComponentReadiness updates the readiness gauge from the operand status conditions.
+// Conditions with reason Disabled remove the series so that alerts do not fire for turned-off operands.
+// Informational conditions, such as ToolCallingSupported and CredentialsExpiring, are skipped.
+func SetComponentReadiness(conditions []metav1.Condition) {
+	for _, cond := range conditions {
+		if informationalConditionTypes[cond.Type] {
+			continue
+		}
+		if cond.Reason == conditionReasonDisabled {
+			ComponentReady.DeleteLabelValues(cond.Type)
+			continue
+		}
+		value := 0.0
+		if cond.Status == metav1.ConditionTrue {
+			value = 1
+		}
+		ComponentReady.WithLabelValues(cond.Type).Set(value)
+	}
+}
+
+// IncWatcherRestart counts a deployment restart triggered by the watched resource kind/name.
+func IncWatcherRestart(kind, name, deployment string) {
+	WatcherRestarts.WithLabelValues(kind, name, deployment).Inc()
+}
+
+// SetLastSuccessfulReconcile records t as the time of the last successful reconcile.
+func SetLastSuccessfulReconcile(t time.Time) {
+	LastSuccessfulReconcile.Set(float64(t.Unix()))
+}
+
+// SetPodDiagnostics replaces the diagnostics gauge with the counts from the current status.
+func SetPodDiagnostics(diagnostics []olsv1alpha1.PodDiagnostic) {
+	PodDiagnostics.Reset()
+	for _, d := range diagnostics {
+		PodDiagnostics.WithLabelValues(d.FailedComponent, d.Reason).Inc()
+	}
+}
+
+// SetCredentialsExpiry replaces the credential expiry gauges with the expiry times of the referenced Secrets.
+// A Secret is expiring when its credentials expire within window from now.
+func SetCredentialsExpiry(expiries map[string]time.Time, window time.Duration, now time.Time) {
+	CredentialsExpiryTimestamp.Reset()
+	CredentialsExpiring.Reset()
+	for secret, expiry := range expiries {
+		CredentialsExpiryTimestamp.WithLabelValues(secret).Set(float64(expiry.Unix()))
+		expiring := 0.0
+		if expiry.Sub(now) <= window {
+			expiring = 1
+		}
+		CredentialsExpiring.WithLabelValues(secret).Set(expiring)
+	}
+}
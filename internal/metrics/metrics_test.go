@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	olsv1alpha1 "github.com/openshift/lightspeed-operator/api/v1alpha1"
+)
+
+func metricValue(c prometheus.Collector) float64 {
+	ch := make(chan prometheus.Metric, 1)
+	c.Collect(ch)
+	m := &dto.Metric{}
+	Expect((<-ch).Write(m)).To(Succeed())
+	switch {
+	case m.Gauge != nil:
+		return m.GetGauge().GetValue()
+	case m.Counter != nil:
+		return m.GetCounter().GetValue()
+	case m.Histogram != nil:
+		return float64(m.GetHistogram().GetSampleCount())
+	}
+	return 0
+}
+
+func seriesCount(c prometheus.Collector) int {
+	ch := make(chan prometheus.Metric, 100)
+	c.Collect(ch)
+	close(ch)
+	return len(ch)
+}
+
+var _ = Describe("Operator metrics", func() {
+	It("observes reconcile step duration and counts errors", func() {
+		ObserveReconcileStep("test step", time.Now(), nil)
+		ObserveReconcileStep("test step", time.Now(), errors.New("boom"))
+
+		Expect(metricValue(ReconcileStepDuration.WithLabelValues("test step").(prometheus.Histogram))).To(BeEquivalentTo(2))
+		Expect(metricValue(ReconcileStepErrors.WithLabelValues("test step"))).To(BeEquivalentTo(1))
+	})
+
+	It("sets component readiness and drops disabled components", func() {
+		SetComponentReadiness([]metav1.Condition{
+			{Type: "ApiReady", Status: metav1.ConditionTrue, Reason: "Available"},
+			{Type: "RHOKPReady", Status: metav1.ConditionFalse, Reason: "Progressing"},
+			{Type: "Reconciled", Status: metav1.ConditionTrue, Reason: "Reconciled"},
+		})
+		Expect(metricValue(ComponentReady.WithLabelValues("ApiReady"))).To(BeEquivalentTo(1))
+		Expect(metricValue(ComponentReady.WithLabelValues("RHOKPReady"))).To(BeEquivalentTo(0))
+		Expect(metricValue(ComponentReady.WithLabelValues("Reconciled"))).To(BeEquivalentTo(1))
+
+		SetComponentReadiness([]metav1.Condition{
+			{Type: "RHOKPReady", Status: metav1.ConditionFalse, Reason: "Disabled"},
+		})
+		Expect(seriesCount(ComponentReady)).To(Equal(2))
+	})
+
+	It("skips informational conditions in component readiness", func() {
+		ComponentReady.Reset()
+		SetComponentReadiness([]metav1.Condition{
+			{Type: "ApiReady", Status: metav1.ConditionTrue, Reason: "Available"},
+			{Type: "ToolCallingSupported", Status: metav1.ConditionFalse, Reason: "Unsupported"},
+			{Type: "CredentialsExpiring", Status: metav1.ConditionTrue, Reason: "Expiring"},
+		})
+		Expect(seriesCount(ComponentReady)).To(Equal(1))
+	})
+
+	It("counts watcher restarts per resource and deployment", func() {
+		IncWatcherRestart("Secret", "llm-creds", "lightspeed-app-server")
+		IncWatcherRestart("Secret", "llm-creds", "lightspeed-app-server")
+		Expect(metricValue(WatcherRestarts.WithLabelValues("Secret", "llm-creds", "lightspeed-app-server"))).To(BeEquivalentTo(2))
+	})
+
+	It("records the last successful reconcile timestamp", func() {
+		now := time.Unix(1700000000, 0)
+		SetLastSuccessfulReconcile(now)
+		Expect(metricValue(LastSuccessfulReconcile)).To(BeEquivalentTo(1700000000))
+	})
+
+	It("replaces pod diagnostic counts on every update", func() {
+		SetPodDiagnostics([]olsv1alpha1.PodDiagnostic{
+			{FailedComponent: "ApiReady", Reason: "CrashLoopBackOff"},
+			{FailedComponent: "ApiReady", Reason: "CrashLoopBackOff"},
+			{FailedComponent: "CacheReady", Reason: "OOMKilled"},
+		})
+		Expect(metricValue(PodDiagnostics.WithLabelValues("ApiReady", "CrashLoopBackOff"))).To(BeEquivalentTo(2))
+		Expect(seriesCount(PodDiagnostics)).To(Equal(2))
+
+		SetPodDiagnostics(nil)
+		Expect(seriesCount(PodDiagnostics)).To(Equal(0))
+	})
+
+	It("replaces credential expiry gauges on every update", func() {
+		now := time.Unix(1700000000, 0)
+		SetCredentialsExpiry(map[string]time.Time{
+			"azure-creds":  now.Add(48 * time.Hour),
+			"vertex-creds": now.Add(30 * 24 * time.Hour),
+		}, 7*24*time.Hour, now)
+		Expect(metricValue(CredentialsExpiryTimestamp.WithLabelValues("azure-creds"))).To(BeEquivalentTo(1700000000 + 48*3600))
+		Expect(metricValue(CredentialsExpiring.WithLabelValues("azure-creds"))).To(BeEquivalentTo(1))
+		Expect(metricValue(CredentialsExpiring.WithLabelValues("vertex-creds"))).To(BeEquivalentTo(0))
+
+		SetCredentialsExpiry(nil, 7*24*time.Hour, now)
+		Expect(seriesCount(CredentialsExpiryTimestamp)).To(Equal(0))
+		Expect(seriesCount(CredentialsExpiring)).To(Equal(0))
+	})
+})